@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// DryRunSuffix returns the suffix appended to the messages of mutating
+// commands for the --dry-run strategy, like the suffix printed by kubectl.
+func DryRunSuffix(strategy cmdutil.DryRunStrategy) string {
+	switch strategy {
+	case cmdutil.DryRunClient:
+		return " (dry run)"
+	case cmdutil.DryRunServer:
+		return " (server dry run)"
+	default:
+		return ""
+	}
+}
+
+// DryRunUpdateOptions returns the UpdateOptions for the --dry-run strategy.
+// With the server strategy, the request is sent with DryRun: All, so that the
+// API server validates but does not persist the change.
+func DryRunUpdateOptions(strategy cmdutil.DryRunStrategy) metav1.UpdateOptions {
+	if strategy == cmdutil.DryRunServer {
+		return metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}}
+	}
+	return metav1.UpdateOptions{}
+}
@@ -19,8 +19,34 @@ package util
 import (
 	"context"
 	"errors"
+	"time"
 )
 
+// Exit codes of the status and inspect commands, reporting the health of the
+// inspected certificates so that scripts and CI jobs can gate on it. The codes
+// are ordered by severity, so the code for multiple certificates is the
+// highest code of any of them. 1 is left for other errors, e.g. invalid flags.
+const (
+	// ExitCodeReady is returned if the certificate is ready and does not
+	// expire soon
+	ExitCodeReady = 0
+	// ExitCodeExpiringSoon is returned if the certificate is ready but
+	// expires within the expiring soon threshold
+	ExitCodeExpiringSoon = 2
+	// ExitCodeNotReady is returned if the certificate is not ready, e.g. the
+	// Certificate is not Ready or the certificate is not valid yet
+	ExitCodeNotReady = 3
+	// ExitCodeExpired is returned if the certificate has expired
+	ExitCodeExpired = 4
+	// ExitCodeFetchError is returned if the resources to inspect could not be
+	// fetched from the API server
+	ExitCodeFetchError = 5
+)
+
+// DefaultExpiringSoonThreshold is the default remaining validity below which
+// a certificate is reported as expiring soon.
+const DefaultExpiringSoonThreshold = 7 * 24 * time.Hour
+
 // SetExitCode sets the exit code to 1 if the error is not a context.Canceled error.
 func SetExitCode(err error) {
 	switch {
@@ -44,3 +70,32 @@ func SetExitCodeValue(code int) {
 	}
 	// If the exit code is 0, we don't need to set the exit code
 }
+
+// CertificateExitCode returns the exit code reporting the health of a
+// certificate that expires at notAfter, at time now. notAfter is zero if the
+// expiry is unknown, e.g. because no certificate was issued yet.
+func CertificateExitCode(ready bool, notAfter, now time.Time, expiringSoonThreshold time.Duration) int {
+	switch {
+	case !notAfter.IsZero() && !now.Before(notAfter):
+		return ExitCodeExpired
+	case !ready:
+		return ExitCodeNotReady
+	case !notAfter.IsZero() && notAfter.Sub(now) <= expiringSoonThreshold:
+		return ExitCodeExpiringSoon
+	default:
+		return ExitCodeReady
+	}
+}
+
+// SetFetchErrorExitCode sets the exit code to ExitCodeFetchError if err is
+// not nil, and returns err. Like SetExitCode, a context.Canceled error does
+// not set the exit code and a context.DeadlineExceeded error sets it to 124.
+func SetFetchErrorExitCode(err error) error {
+	switch {
+	case err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		SetExitCode(err)
+	default:
+		SetExitCodeValue(ExitCodeFetchError)
+	}
+	return err
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestSetExitCode(t *testing.T) {
@@ -54,3 +55,60 @@ func TestSetExitCode(t *testing.T) {
 		})
 	}
 }
+
+func TestSetFetchErrorExitCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		expCode int
+	}{
+		{"Test context.Canceled", context.Canceled, 0},
+		{"Test context.DeadlineExceeded", fmt.Errorf("wrapped: %w", context.DeadlineExceeded), 124},
+		{"Test error", errors.New("error"), ExitCodeFetchError},
+		{"Test nil", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode := testExitCode(t, func(t *testing.T) {
+				if err := SetFetchErrorExitCode(tt.err); err != tt.err {
+					t.Errorf("expected error %v to be returned, got %v", tt.err, err)
+				}
+
+				_, complete := SetupExitHandler(t.Context(), AlwaysErrCode)
+				complete()
+			})
+
+			if exitCode != tt.expCode {
+				t.Errorf("Test %s: expected exit code %d, got %d", tt.name, tt.expCode, exitCode)
+			}
+		})
+	}
+}
+
+func TestCertificateExitCode(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	threshold := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		ready    bool
+		notAfter time.Time
+		expCode  int
+	}{
+		{"Test ready", true, now.Add(30 * 24 * time.Hour), ExitCodeReady},
+		{"Test ready with unknown expiry", true, time.Time{}, ExitCodeReady},
+		{"Test expiring soon", true, now.Add(24 * time.Hour), ExitCodeExpiringSoon},
+		{"Test not ready", false, now.Add(30 * 24 * time.Hour), ExitCodeNotReady},
+		{"Test not ready and expiring soon", false, now.Add(24 * time.Hour), ExitCodeNotReady},
+		{"Test not ready with unknown expiry", false, time.Time{}, ExitCodeNotReady},
+		{"Test expired", true, now.Add(-time.Hour), ExitCodeExpired},
+		{"Test expired and not ready", false, now, ExitCodeExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := CertificateExitCode(tt.ready, tt.notAfter, now, threshold); code != tt.expCode {
+				t.Errorf("Test %s: expected exit code %d, got %d", tt.name, tt.expCode, code)
+			}
+		})
+	}
+}
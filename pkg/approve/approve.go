@@ -27,8 +27,10 @@ import (
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/templates"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
@@ -41,6 +43,10 @@ type Options struct {
 	// Message is the string that will be set on the Message field of the
 	// Approved condition.
 	Message string
+	// DryRunStrategy is the --dry-run strategy. With the client strategy, the
+	// change is only printed; with the server strategy, the status update is
+	// sent with DryRun: All.
+	DryRunStrategy cmdutil.DryRunStrategy
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -69,9 +75,16 @@ func NewCmdApprove(setupCtx context.Context, ioStreams genericclioptions.IOStrea
 
 # Approve a CertificateRequest giving a custom reason and message
 {{.BuildName}} approve my-cr --reason "ManualApproval" --reason "Approved by PKI department"
+
+# Print the condition that would be set on CertificateRequest 'my-cr', without changing it
+{{.BuildName}} approve my-cr --dry-run=client
 `)),
 		ValidArgsFunction: factory.ValidArgsListCertificateRequests(&o.Factory),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if o.DryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd); err != nil {
+				return err
+			}
 			return o.Validate(args)
 		},
 		//nolint:contextcheck // False positive
@@ -84,6 +97,7 @@ func NewCmdApprove(setupCtx context.Context, ioStreams genericclioptions.IOStrea
 		"The reason to give as to what approved this CertificateRequest.")
 	cmd.Flags().StringVar(&o.Message, "message", fmt.Sprintf("manually approved by %q", build.Name(setupCtx)),
 		"The message to give as to why this CertificateRequest was approved.")
+	cmdutil.AddDryRunFlag(cmd)
 
 	o.Factory = factory.New(cmd)
 
@@ -128,12 +142,18 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionApproved,
 		cmmeta.ConditionTrue, o.Reason, o.Message)
 
-	_, err = o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
+	if o.DryRunStrategy == cmdutil.DryRunClient {
+		fmt.Fprintf(o.Out, "Would set condition Approved=True (reason %q, message %q) on CertificateRequest '%s/%s'%s\n",
+			o.Reason, o.Message, cr.Namespace, cr.Name, cmcmdutil.DryRunSuffix(o.DryRunStrategy))
+		return nil
+	}
+
+	_, err = o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).UpdateStatus(ctx, cr, cmcmdutil.DryRunUpdateOptions(o.DryRunStrategy))
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(o.Out, "Approved CertificateRequest '%s/%s'\n", cr.Namespace, cr.Name)
+	fmt.Fprintf(o.Out, "Approved CertificateRequest '%s/%s'%s\n", cr.Namespace, cr.Name, cmcmdutil.DryRunSuffix(o.DryRunStrategy))
 
 	return nil
 }
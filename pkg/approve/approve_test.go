@@ -18,6 +18,17 @@ package approve
 
 import (
 	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	coretesting "k8s.io/client-go/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
 
 func TestValidate(t *testing.T) {
@@ -82,3 +93,65 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRunDryRun(t *testing.T) {
+	tests := map[string]struct {
+		dryRunStrategy cmdutil.DryRunStrategy
+		expOutput      string
+		expUpdate      bool
+		expDryRun      []string
+	}{
+		"No dry run updates the CertificateRequest": {
+			dryRunStrategy: cmdutil.DryRunNone,
+			expOutput:      "Approved CertificateRequest 'my-namespace/my-cr'\n",
+			expUpdate:      true,
+		},
+		"Client dry run only prints the change": {
+			dryRunStrategy: cmdutil.DryRunClient,
+			expOutput:      "Would set condition Approved=True (reason \"my-reason\", message \"my-message\") on CertificateRequest 'my-namespace/my-cr' (dry run)\n",
+			expUpdate:      false,
+		},
+		"Server dry run sends the update with DryRun: All": {
+			dryRunStrategy: cmdutil.DryRunServer,
+			expOutput:      "Approved CertificateRequest 'my-namespace/my-cr' (server dry run)\n",
+			expUpdate:      true,
+			expDryRun:      []string{metav1.DryRunAll},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmClient := cmfake.NewClientset(gen.CertificateRequest("my-cr", gen.SetCertificateRequestNamespace("my-namespace")))
+
+			o := newOptions(ioStreams)
+			o.Reason = "my-reason"
+			o.Message = "my-message"
+			o.DryRunStrategy = test.dryRunStrategy
+			o.Factory = &factory.Factory{
+				Namespace: "my-namespace",
+				CMClient:  cmClient,
+			}
+
+			if err := o.Run(t.Context(), []string{"my-cr"}); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expOutput, out.String())
+
+			var updates []coretesting.UpdateActionImpl
+			for _, action := range cmClient.Actions() {
+				if update, ok := action.(coretesting.UpdateActionImpl); ok {
+					updates = append(updates, update)
+				}
+			}
+			if !test.expUpdate {
+				assert.Empty(t, updates)
+				return
+			}
+			if assert.Len(t, updates, 1) {
+				assert.Equal(t, "status", updates[0].GetSubresource())
+				assert.Equal(t, test.expDryRun, updates[0].UpdateOptions.DryRun)
+				assert.IsType(t, &cmapi.CertificateRequest{}, updates[0].GetObject())
+			}
+		})
+	}
+}
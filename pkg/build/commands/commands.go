@@ -33,6 +33,7 @@ import (
 	"github.com/cert-manager/cmctl/v2/pkg/renew"
 	"github.com/cert-manager/cmctl/v2/pkg/status"
 	"github.com/cert-manager/cmctl/v2/pkg/upgrade"
+	"github.com/cert-manager/cmctl/v2/pkg/verify"
 	"github.com/cert-manager/cmctl/v2/pkg/version"
 )
 
@@ -48,6 +49,7 @@ func Commands() []RegisterCommandFunc {
 		renew.NewCmdRenew,
 		status.NewCmdStatus,
 		inspect.NewCmdInspect,
+		verify.NewCmdVerify,
 		approve.NewCmdApprove,
 		deny.NewCmdDeny,
 		check.NewCmdCheck,
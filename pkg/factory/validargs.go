@@ -148,3 +148,93 @@ func validArgsListNamespaces(factory *Factory) func(*cobra.Command, []string, st
 		return names, cobra.ShellCompDirectiveNoFileComp
 	}
 }
+
+// ValidArgsListIssuers returns a cobra ValidArgsFunction for listing Issuers.
+func ValidArgsListIssuers(factory **Factory) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		f := *factory
+		if err := f.complete(); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		issuerList, err := f.CMClient.CertmanagerV1().Issuers(f.Namespace).List(cmd.Context(), metav1.ListOptions{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var names []string
+		for _, issuer := range issuerList.Items {
+			names = append(names, issuer.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// ValidArgsListClusterIssuers returns a cobra ValidArgsFunction for listing
+// ClusterIssuers.
+func ValidArgsListClusterIssuers(factory **Factory) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		f := *factory
+		if err := f.complete(); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		issuerList, err := f.CMClient.CertmanagerV1().ClusterIssuers().List(cmd.Context(), metav1.ListOptions{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var names []string
+		for _, issuer := range issuerList.Items {
+			names = append(names, issuer.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// ValidArgsListOrders returns a cobra ValidArgsFunction for listing ACME Orders.
+func ValidArgsListOrders(factory **Factory) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		f := *factory
+		if err := f.complete(); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		orderList, err := f.CMClient.AcmeV1().Orders(f.Namespace).List(cmd.Context(), metav1.ListOptions{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var names []string
+		for _, order := range orderList.Items {
+			names = append(names, order.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// ValidArgsListChallenges returns a cobra ValidArgsFunction for listing ACME
+// Challenges.
+func ValidArgsListChallenges(factory **Factory) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		f := *factory
+		if err := f.complete(); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		challengeList, err := f.CMClient.AcmeV1().Challenges(f.Namespace).List(cmd.Context(), metav1.ListOptions{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var names []string
+		for _, challenge := range challengeList.Items {
+			names = append(names, challenge.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
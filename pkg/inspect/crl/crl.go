@@ -0,0 +1,555 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crl
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	k8sclock "k8s.io/utils/clock"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/secret"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+// CRLInspectionKind is the kind of the structured output of the inspect crl
+// command.
+const CRLInspectionKind = "CRLInspection"
+
+// oidExtensionDeltaCRLIndicator is the OID of the delta CRL indicator
+// extension, defined in RFC 5280 section 5.2.4
+var oidExtensionDeltaCRLIndicator = asn1.ObjectIdentifier{2, 5, 29, 27}
+
+// revocationReasons are the names of the CRL reason codes, defined in RFC 5280
+// section 5.3.1. The reason code 0, unspecified, is also used if the CRL
+// entry has no reason code, so it is not printed.
+var revocationReasons = map[int]string{
+	1:  "keyCompromise",
+	2:  "cACompromise",
+	3:  "affiliationChanged",
+	4:  "superseded",
+	5:  "cessationOfOperation",
+	6:  "certificateHold",
+	8:  "removeFromCRL",
+	9:  "privilegeWithdrawn",
+	10: "aACompromise",
+}
+
+// CRLInspection is the structured representation of a CRL, printed by the
+// inspect crl command when --output is used.
+type CRLInspection struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Source of the CRL, as passed as argument
+	Source string `json:"source"`
+	// Issuer of the CRL, as RFC 2253 distinguished name
+	Issuer string `json:"issuer"`
+	// Number is the CRL number in decimal
+	Number string `json:"number,omitempty"`
+	// DeltaCRL is true if the CRL is a delta CRL
+	DeltaCRL bool `json:"deltaCRL"`
+	// BaseCRLNumber is the number of the base CRL updated by a delta CRL
+	BaseCRLNumber      string       `json:"baseCRLNumber,omitempty"`
+	SignatureAlgorithm string       `json:"signatureAlgorithm"`
+	ThisUpdate         metav1.Time  `json:"thisUpdate"`
+	NextUpdate         *metav1.Time `json:"nextUpdate,omitempty"`
+	// Outdated is true if the next update of the CRL has passed
+	Outdated            bool `json:"outdated"`
+	RevokedCertificates int  `json:"revokedCertificates"`
+	// Signature is the result of verifying the signature of the CRL with the
+	// certificates of --ca
+	Signature secret.VerificationCheck `json:"signature"`
+	// Certificates are the results of looking up the certificates of --check
+	// on the CRL
+	Certificates []CertificateRevocation `json:"certificates,omitempty"`
+}
+
+// CertificateRevocation is the result of looking up a certificate on a CRL.
+type CertificateRevocation struct {
+	// Source of the certificate, as passed to --check
+	Source string `json:"source"`
+	// Subject of the certificate, as RFC 2253 distinguished name
+	Subject string `json:"subject"`
+	// SerialNumber in decimal
+	SerialNumber string `json:"serialNumber"`
+	Revoked      bool   `json:"revoked"`
+	// RevokedAt and Reason are only set if the certificate is revoked
+	RevokedAt *metav1.Time `json:"revokedAt,omitempty"`
+	Reason    string       `json:"reason,omitempty"`
+	// Message explains why the certificate could not be looked up
+	Message string `json:"message,omitempty"`
+}
+
+// String returns the result as a line of the human readable output
+func (r *CertificateRevocation) String() string {
+	var result string
+	switch {
+	case r.Message != "":
+		result = "Not checked: " + r.Message
+	case r.Revoked:
+		result = fmt.Sprintf("Revoked at %s", r.RevokedAt.Format(time.RFC1123))
+		if r.Reason != "" {
+			result += ", reason: " + r.Reason
+		}
+	default:
+		result = "Not revoked"
+	}
+	return fmt.Sprintf("%s (serial number %s):\t%s", r.Source, r.SerialNumber, result)
+}
+
+// String returns the inspection as the human readable output
+func (i *CRLInspection) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CRL:\n\tSource:\t%s\n\tIssuer:\t%s\n", i.Source, i.Issuer)
+	fmt.Fprintf(&b, "\tCRL Number:\t%s\n", printOrNone(i.Number))
+	if i.DeltaCRL {
+		fmt.Fprintf(&b, "\tDelta CRL:\tyes, base CRL number %s\n", printOrNone(i.BaseCRLNumber))
+	} else {
+		b.WriteString("\tDelta CRL:\tno\n")
+	}
+	fmt.Fprintf(&b, "\tSignature Algorithm:\t%s\n", i.SignatureAlgorithm)
+	fmt.Fprintf(&b, "\tThis Update:\t%s\n", i.ThisUpdate.Format(time.RFC1123))
+	switch {
+	case i.NextUpdate == nil:
+		b.WriteString("\tNext Update:\t<none>\n")
+	case i.Outdated:
+		fmt.Fprintf(&b, "\tNext Update:\t%s, outdated\n", i.NextUpdate.Format(time.RFC1123))
+	default:
+		fmt.Fprintf(&b, "\tNext Update:\t%s\n", i.NextUpdate.Format(time.RFC1123))
+	}
+	fmt.Fprintf(&b, "\tRevoked Certificates:\t%d\n", i.RevokedCertificates)
+	fmt.Fprintf(&b, "\tSignature:\t%s", i.Signature.Status)
+	if i.Signature.Message != "" {
+		fmt.Fprintf(&b, ": %s", i.Signature.Message)
+	}
+
+	if len(i.Certificates) > 0 {
+		b.WriteString("\nChecked Certificates:")
+		for _, certificate := range i.Certificates {
+			fmt.Fprintf(&b, "\n\t%s", &certificate)
+		}
+	}
+	return b.String()
+}
+
+// exitCode returns the exit code of the inspection: the CRL is reported as
+// expired if it is outdated, and as not ready if its signature is invalid or
+// one of the checked certificates is revoked.
+func (i *CRLInspection) exitCode() int {
+	exitCode := cmcmdutil.ExitCodeReady
+	if i.Signature.Status == secret.CheckFailed {
+		exitCode = cmcmdutil.ExitCodeNotReady
+	}
+	for _, certificate := range i.Certificates {
+		if certificate.Revoked {
+			exitCode = cmcmdutil.ExitCodeNotReady
+		}
+	}
+	if i.Outdated {
+		exitCode = cmcmdutil.ExitCodeExpired
+	}
+	return exitCode
+}
+
+// Options is a struct to support inspect crl command
+type Options struct {
+	// CA is the file or Secret with the CA certificates used to verify the
+	// signature of the CRL
+	CA string
+	// Check are the files or Secrets with the certificates that are looked up
+	// on the CRL
+	Check []string
+	// SecretKey is the key of the Secret that holds the CRL. If empty, the
+	// first key that holds a CRL is used.
+	SecretKey string
+
+	// Revocation holds the timeout and proxy used to download the CRL
+	Revocation *secret.RevocationOptions
+
+	// PrintFlags holds the flags used to print the CRL in a structured format.
+	// If no output format is set, the human readable output is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Revocation: secret.NewRevocationOptions(),
+		PrintFlags: util.NewPrintFlags(),
+		IOStreams:  ioStreams,
+	}
+}
+
+// NewCmdInspectCRL returns a cobra command for inspect crl
+func NewCmdInspectCRL(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:   "crl <path|url|secret/[namespace/]name>",
+		Short: "Get details about a certificate revocation list (CRL)",
+		Long: templates.LongDesc(`
+Get details about a certificate revocation list (CRL), e.g. a CRL published for a private CA issuer.
+
+The CRL is read from a local file, from stdin if the path is '-', from an http or https URL, or from a Secret
+given as 'secret/[namespace/]name'. PEM and DER encoded CRLs are supported. The CRL is read from the key
+--secret-key of a Secret, or else from the first key that holds a CRL.
+
+With --ca, the signature of the CRL is verified with the CA certificates in a file or Secret. Of a Secret,
+the certificates in 'ca.crt' are used, or the certificate in 'tls.crt' if 'ca.crt' is empty, so that the Secret
+of a CA issuer can be used directly.
+
+With --check, the leaf certificate of a file or of the 'tls.crt' of a Secret is looked up on the CRL.
+--check can be repeated.
+
+The exit code is 4 if the next update of the CRL has passed, and 3 if the signature of the CRL is invalid or
+one of the checked certificates is revoked.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Query information about a CRL file
+{{.BuildName}} inspect crl ca.crl
+
+# Verify the CRL published by a CA issuer with the CA certificate in secret 'ca-key-pair'
+{{.BuildName}} inspect crl http://ca.example.com/ca.crl --ca secret/cert-manager/ca-key-pair
+
+# Check whether the certificate in secret 'my-crt' in namespace 'my-namespace' is revoked
+{{.BuildName}} inspect crl http://ca.example.com/ca.crl --check secret/my-namespace/my-crt
+`)),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.CA, "ca", o.CA, "File or secret/[namespace/]name with the CA certificates used to verify the signature of the CRL.")
+	cmd.Flags().StringSliceVar(&o.Check, "check", o.Check, "File or secret/[namespace/]name with a certificate that is looked up on the CRL. Can be repeated.")
+	cmd.Flags().StringVar(&o.SecretKey, "secret-key", o.SecretKey, "Key of the Secret that holds the CRL. Defaults to the first key that holds a CRL.")
+	o.Revocation.AddNetworkFlags(cmd)
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(cmd)
+
+	// The cluster is only needed to fetch Secrets, so the Factory is only
+	// populated if a Secret is referenced.
+	factoryPreRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if !o.referencesSecret(args) {
+			return o.Validate(args)
+		}
+		return factoryPreRunE(cmd, args)
+	}
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the CRL has to be provided as argument: a path, '-' to read from stdin, a URL or secret/[namespace/]name")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the path, URL or Secret of the CRL")
+	}
+	if u, err := url.Parse(args[0]); err == nil && u.Scheme != "" && u.Host != "" {
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("unsupported CRL URL scheme %q, only http and https are supported", u.Scheme)
+		}
+	}
+	for _, source := range append([]string{args[0], o.CA}, o.Check...) {
+		if _, _, _, err := secret.ParseResourceSource(source, secret.SecretSourcePrefix); err != nil {
+			return err
+		}
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run executes inspect crl command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	source := args[0]
+
+	checker, err := o.Revocation.ToChecker()
+	if err != nil {
+		return err
+	}
+
+	crl, err := o.loadCRL(ctx, source, checker)
+	if err != nil {
+		return cmcmdutil.SetFetchErrorExitCode(err)
+	}
+
+	inspection := inspectCRL(source, crl)
+
+	if o.CA == "" {
+		inspection.Signature.Status = secret.CheckSkipped
+		inspection.Signature.Message = "no CA certificate provided with --ca"
+	} else {
+		cas, err := o.loadCertificates(ctx, o.CA, cmmeta.TLSCAKey, corev1.TLSCertKey)
+		if err != nil {
+			return cmcmdutil.SetFetchErrorExitCode(err)
+		}
+		inspection.Signature = verifySignature(crl, cas)
+	}
+
+	for _, check := range o.Check {
+		certs, err := o.loadCertificates(ctx, check, corev1.TLSCertKey)
+		if err != nil {
+			return cmcmdutil.SetFetchErrorExitCode(err)
+		}
+		inspection.Certificates = append(inspection.Certificates, lookUpCertificate(crl, check, certs[0]))
+	}
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		if err := util.PrintObject(printer, inspection, o.Out); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(o.Out, "%s\n", inspection)
+	}
+
+	cmcmdutil.SetExitCodeValue(inspection.exitCode())
+	return nil
+}
+
+// referencesSecret returns true if the CRL or any of the certificates are read
+// from a Secret.
+func (o *Options) referencesSecret(args []string) bool {
+	for _, source := range append(append([]string{}, args...), append([]string{o.CA}, o.Check...)...) {
+		if strings.HasPrefix(source, secret.SecretSourcePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadCRL reads the CRL from source, which is a file, '-' for stdin, a URL or
+// a Secret.
+func (o *Options) loadCRL(ctx context.Context, source string, checker *secret.RevocationChecker) (*x509.RevocationList, error) {
+	if u, err := url.Parse(source); err == nil && u.Scheme != "" && u.Host != "" {
+		crl, err := checker.FetchCRL(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("error when downloading the CRL from %q: %w", source, err)
+		}
+		return crl, nil
+	}
+
+	namespace, name, isSecret, err := secret.ParseResourceSource(source, secret.SecretSourcePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if isSecret {
+		s, err := o.getSecret(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if o.SecretKey != "" {
+			crl, err := secret.ParseCRL(s.Data[o.SecretKey])
+			if err != nil {
+				return nil, fmt.Errorf("error when parsing the CRL in key %q of Secret %q: %w", o.SecretKey, name, err)
+			}
+			return crl, nil
+		}
+		keys := make([]string, 0, len(s.Data))
+		for key := range s.Data {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if crl, err := secret.ParseCRL(s.Data[key]); err == nil {
+				return crl, nil
+			}
+		}
+		return nil, fmt.Errorf("no CRL found in Secret %q in namespace %q", name, namespace)
+	}
+
+	data, err := secret.ReadFile(o.In, source)
+	if err != nil {
+		return nil, err
+	}
+	crl, err := secret.ParseCRL(data)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing the CRL in %q: %w", source, err)
+	}
+	return crl, nil
+}
+
+// loadCertificates reads the certificates from source, which is a file or a
+// Secret. Of a Secret, the certificates of the first non-empty key of keys are
+// returned.
+func (o *Options) loadCertificates(ctx context.Context, source string, keys ...string) ([]*x509.Certificate, error) {
+	namespace, name, isSecret, err := secret.ParseResourceSource(source, secret.SecretSourcePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if isSecret {
+		s, err := o.getSecret(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if data = s.Data[key]; len(data) > 0 {
+				break
+			}
+		}
+		if len(data) == 0 {
+			return nil, fmt.Errorf("no certificate found in keys %s of Secret %q in namespace %q", strings.Join(keys, ", "), name, namespace)
+		}
+	} else if data, err = secret.ReadFile(o.In, source); err != nil {
+		return nil, err
+	}
+
+	return secret.DecodeCertificates(data, source)
+}
+
+// getSecret returns the Secret name in namespace, or in the namespace of the
+// Factory if namespace is empty.
+func (o *Options) getSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	if namespace == "" {
+		namespace = o.Namespace
+	}
+	s, err := o.KubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when finding Secret %q in namespace %q: %w", name, namespace, err)
+	}
+	return s, nil
+}
+
+// inspectCRL returns the structured representation of crl, read from source.
+func inspectCRL(source string, crl *x509.RevocationList) *CRLInspection {
+	inspection := &CRLInspection{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       CRLInspectionKind,
+		},
+		Source:              source,
+		Issuer:              crl.Issuer.String(),
+		SignatureAlgorithm:  crl.SignatureAlgorithm.String(),
+		ThisUpdate:          metav1.Time{Time: crl.ThisUpdate},
+		RevokedCertificates: len(crl.RevokedCertificateEntries),
+		Signature:           secret.VerificationCheck{Name: "Signature"},
+	}
+	if crl.Number != nil {
+		inspection.Number = crl.Number.String()
+	}
+	if !crl.NextUpdate.IsZero() {
+		inspection.NextUpdate = &metav1.Time{Time: crl.NextUpdate}
+		inspection.Outdated = clock.Now().After(crl.NextUpdate)
+	}
+	for _, extension := range crl.Extensions {
+		if !extension.Id.Equal(oidExtensionDeltaCRLIndicator) {
+			continue
+		}
+		inspection.DeltaCRL = true
+		var baseCRLNumber *big.Int
+		if _, err := asn1.Unmarshal(extension.Value, &baseCRLNumber); err == nil {
+			inspection.BaseCRLNumber = baseCRLNumber.String()
+		}
+	}
+	return inspection
+}
+
+// verifySignature verifies the signature of crl with the certificate in cas
+// whose subject is the issuer of crl.
+func verifySignature(crl *x509.RevocationList, cas []*x509.Certificate) secret.VerificationCheck {
+	check := secret.VerificationCheck{Name: "Signature", Status: secret.CheckFailed}
+
+	var problems []string
+	for _, ca := range cas {
+		if !bytes.Equal(ca.RawSubject, crl.RawIssuer) {
+			continue
+		}
+		err := crl.CheckSignatureFrom(ca)
+		if err == nil {
+			check.Status = secret.CheckPassed
+			return check
+		}
+		problems = append(problems, fmt.Sprintf("%s: %s", ca.Subject, err))
+	}
+
+	if len(problems) == 0 {
+		check.Message = fmt.Sprintf("no CA certificate with subject %q provided", crl.Issuer)
+	} else {
+		check.Message = strings.Join(problems, "; ")
+	}
+	return check
+}
+
+// lookUpCertificate looks up cert, read from source, on crl.
+func lookUpCertificate(crl *x509.RevocationList, source string, cert *x509.Certificate) CertificateRevocation {
+	result := CertificateRevocation{
+		Source:       source,
+		Subject:      cert.Subject.String(),
+		SerialNumber: cert.SerialNumber.String(),
+	}
+	if !bytes.Equal(cert.RawIssuer, crl.RawIssuer) {
+		result.Message = fmt.Sprintf("the certificate is issued by %q, not by the issuer of the CRL", cert.Issuer)
+		return result
+	}
+
+	for _, revoked := range crl.RevokedCertificateEntries {
+		if cert.SerialNumber.Cmp(revoked.SerialNumber) != 0 {
+			continue
+		}
+		result.Revoked = true
+		result.RevokedAt = &metav1.Time{Time: revoked.RevocationTime}
+		result.Reason = revocationReasons[revoked.ReasonCode]
+		break
+	}
+	return result
+}
+
+// printOrNone returns in, or "<none>" if in is empty.
+func printOrNone(in string) string {
+	if in == "" {
+		return "<none>"
+	}
+	return in
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crl
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/secret"
+)
+
+type testKeyPair struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+}
+
+// newTestKeyPair returns a certificate with an ECDSA key, signed by issuer or
+// self-signed if issuer is nil. Self-signed certificates are CAs that can sign
+// CRLs.
+func newTestKeyPair(t *testing.T, commonName string, serialNumber int64, issuer *testKeyPair) *testKeyPair {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serialNumber),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	issuerCert, issuerKey := template, crypto.Signer(key)
+	if issuer != nil {
+		issuerCert, issuerKey = issuer.cert, issuer.key
+	} else {
+		template.BasicConstraintsValid = true
+		template.IsCA = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+	certPEM, cert, err := pki.SignCertificate(template, issuerCert, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKeyPair{cert: cert, certPEM: certPEM, key: key}
+}
+
+// newTestCRL returns the DER encoded CRL number 7 of issuer, revoking the
+// given entries.
+func newTestCRL(t *testing.T, issuer *testKeyPair, nextUpdate time.Time, extensions []pkix.Extension, revoked ...x509.RevocationListEntry) []byte {
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(7),
+		ThisUpdate:                time.Now().Add(-time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: revoked,
+		ExtraExtensions:           extensions,
+	}, issuer.cert, issuer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestRun(t *testing.T) {
+	ca := newTestKeyPair(t, "ca", 1, nil)
+	otherCA := newTestKeyPair(t, "other-ca", 1, nil)
+	revokedLeaf := newTestKeyPair(t, "revoked", 10, ca)
+	validLeaf := newTestKeyPair(t, "valid", 11, ca)
+	otherLeaf := newTestKeyPair(t, "other", 10, otherCA)
+
+	revokedAt := time.Now().Add(-2 * time.Hour).Truncate(time.Second).UTC()
+	crlDER := newTestCRL(t, ca, time.Now().Add(24*time.Hour), nil, x509.RevocationListEntry{
+		SerialNumber:   revokedLeaf.cert.SerialNumber,
+		RevocationTime: revokedAt,
+		ReasonCode:     1,
+	})
+	crlPEM := pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crlDER})
+	deltaIndicator, err := asn1.Marshal(big.NewInt(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	deltaCRLDER := newTestCRL(t, ca, time.Now().Add(-time.Minute), []pkix.Extension{
+		{Id: oidExtensionDeltaCRLIndicator, Critical: true, Value: deltaIndicator},
+	})
+
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	crlFile := writeFile("ca.crl", crlDER)
+	deltaCRLFile := writeFile("delta.crl", deltaCRLDER)
+	caFile := writeFile("ca.crt", ca.certPEM)
+	otherCAFile := writeFile("other-ca.crt", otherCA.certPEM)
+	validLeafFile := writeFile("valid.crt", validLeaf.certPEM)
+	otherLeafFile := writeFile("other.crt", otherLeaf.certPEM)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(crlPEM)
+	}))
+	defer server.Close()
+
+	secrets := []*corev1.Secret{
+		gen.Secret("ca-key-pair",
+			gen.SetSecretNamespace("cert-manager"),
+			gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: ca.certPEM})),
+		gen.Secret("crl",
+			gen.SetSecretNamespace("cert-manager"),
+			gen.SetSecretData(map[string][]byte{"README": []byte("published CRL"), "ca.crl": crlPEM})),
+		gen.Secret("revoked-tls",
+			gen.SetSecretNamespace("my-namespace"),
+			gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: revokedLeaf.certPEM})),
+	}
+
+	tests := map[string]struct {
+		source       string
+		ca           string
+		check        []string
+		secretKey    string
+		outputFormat string
+		expOutput    []string
+		expErr       string
+	}{
+		"DER file": {
+			source: crlFile,
+			expOutput: []string{
+				"\tSource:\t" + crlFile + "\n",
+				"\tIssuer:\tCN=ca\n",
+				"\tCRL Number:\t7\n",
+				"\tDelta CRL:\tno\n",
+				"\tRevoked Certificates:\t1\n",
+				"\tSignature:\tSkipped: no CA certificate provided with --ca",
+			},
+		},
+		"PEM from URL with CA file": {
+			source:    server.URL,
+			ca:        caFile,
+			expOutput: []string{"\tSignature:\tPassed"},
+		},
+		"CA of another issuer": {
+			source:    crlFile,
+			ca:        otherCAFile,
+			expOutput: []string{`Signature:	Failed: no CA certificate with subject "CN=ca" provided`},
+		},
+		"Secret with CA issuer Secret and checked certificates": {
+			source: "secret/cert-manager/crl",
+			ca:     "secret/cert-manager/ca-key-pair",
+			check:  []string{"secret/revoked-tls", validLeafFile, otherLeafFile},
+			expOutput: []string{
+				"\tSignature:\tPassed\n",
+				"Checked Certificates:\n",
+				"\tsecret/revoked-tls (serial number 10):\tRevoked at " + revokedAt.Format(time.RFC1123) + ", reason: keyCompromise\n",
+				"\t" + validLeafFile + " (serial number 11):\tNot revoked\n",
+				"\t" + otherLeafFile + ` (serial number 10):	Not checked: the certificate is issued by "CN=other-ca", not by the issuer of the CRL`,
+			},
+		},
+		"Outdated delta CRL": {
+			source:    deltaCRLFile,
+			expOutput: []string{"\tDelta CRL:\tyes, base CRL number 6\n", ", outdated\n"},
+		},
+		"JSON": {
+			source:       crlFile,
+			check:        []string{"secret/revoked-tls"},
+			outputFormat: "json",
+			expOutput:    []string{`"kind": "CRLInspection"`, `"deltaCRL": false`, `"revoked": true`, `"reason": "keyCompromise"`},
+		},
+		"Secret key without CRL": {
+			source:    "secret/cert-manager/crl",
+			secretKey: "README",
+			expErr:    `error when parsing the CRL in key "README" of Secret "crl"`,
+		},
+		"Secret without CRL": {
+			source: "secret/cert-manager/ca-key-pair",
+			expErr: `no CRL found in Secret "ca-key-pair" in namespace "cert-manager"`,
+		},
+		"Missing file": {
+			source: filepath.Join(dir, "missing.crl"),
+			expErr: "error when reading",
+		},
+		"Certificate instead of CRL": {
+			source: caFile,
+			expErr: `unexpected PEM block type "CERTIFICATE", expected "X509 CRL"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			o := NewOptions(ioStreams)
+			o.CA = test.ca
+			o.Check = test.check
+			o.SecretKey = test.secretKey
+			*o.PrintFlags.OutputFormat = test.outputFormat
+			o.Factory = &factory.Factory{
+				Namespace:  "my-namespace",
+				KubeClient: kubefake.NewClientset(secrets[0], secrets[1], secrets[2]),
+			}
+			if err := o.Validate([]string{test.source}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Run(t.Context(), []string{test.source})
+			if test.expErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.expErr)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for _, expOutput := range test.expOutput {
+				assert.Contains(t, out.String(), expOutput)
+			}
+			if test.outputFormat == "json" {
+				assert.True(t, json.Valid(bytes.TrimSpace(out.Bytes())), "output is not valid JSON: %s", strings.TrimSpace(out.String()))
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := map[string]struct {
+		inspection  *CRLInspection
+		expExitCode int
+	}{
+		"Valid CRL": {
+			inspection:  &CRLInspection{Signature: secret.VerificationCheck{Status: secret.CheckPassed}},
+			expExitCode: cmcmdutil.ExitCodeReady,
+		},
+		"Invalid signature": {
+			inspection:  &CRLInspection{Signature: secret.VerificationCheck{Status: secret.CheckFailed}},
+			expExitCode: cmcmdutil.ExitCodeNotReady,
+		},
+		"Revoked certificate": {
+			inspection:  &CRLInspection{Certificates: []CertificateRevocation{{}, {Revoked: true}}},
+			expExitCode: cmcmdutil.ExitCodeNotReady,
+		},
+		"Outdated CRL": {
+			inspection:  &CRLInspection{Outdated: true, Certificates: []CertificateRevocation{{Revoked: true}}},
+			expExitCode: cmcmdutil.ExitCodeExpired,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expExitCode, test.inspection.exitCode())
+		})
+	}
+}
+
+func TestLoadCRLInvalidSecret(t *testing.T) {
+	o := NewOptions(genericclioptions.IOStreams{})
+	_, err := o.loadCRL(t.Context(), "secret/a/b/c", nil)
+	assert.EqualError(t, err, `invalid argument "secret/a/b/c", expected secret/[namespace/]name`)
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args   []string
+		ca     string
+		check  []string
+		expErr string
+	}{
+		"Valid file": {
+			args: []string{"ca.crl"},
+		},
+		"Valid URL and Secrets": {
+			args:  []string{"https://ca.example.com/ca.crl"},
+			ca:    "secret/cert-manager/ca-key-pair",
+			check: []string{"secret/my-crt"},
+		},
+		"No CRL": {
+			expErr: "the CRL has to be provided as argument: a path, '-' to read from stdin, a URL or secret/[namespace/]name",
+		},
+		"Too many arguments": {
+			args:   []string{"a.crl", "b.crl"},
+			expErr: "only one argument can be passed in: the path, URL or Secret of the CRL",
+		},
+		"Unsupported URL scheme": {
+			args:   []string{"ldap://ca.example.com/ca.crl"},
+			expErr: `unsupported CRL URL scheme "ldap", only http and https are supported`,
+		},
+		"Invalid Secret": {
+			args:   []string{"ca.crl"},
+			check:  []string{"secret/a/b/c"},
+			expErr: `invalid argument "secret/a/b/c", expected secret/[namespace/]name`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.CA = test.ca
+			o.Check = test.check
+
+			err := o.Validate(test.args)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRevocationFlags(t *testing.T) {
+	cmd := NewCmdInspectCRL(t.Context(), genericclioptions.NewTestIOStreamsDiscard())
+
+	// The timeout and proxy are registered with the names used in the error
+	// messages of RevocationOptions.ToChecker.
+	for _, name := range []string{"revocation-timeout", "revocation-proxy"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "flag --%s", name)
+	}
+	for _, name := range []string{"timeout", "proxy", "offline", "crl-file", "ocsp-response-file"} {
+		assert.Nil(t, cmd.Flags().Lookup(name), "flag --%s", name)
+	}
+}
@@ -0,0 +1,434 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/secret"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// CertificateDiffKind is the kind of the structured output of the inspect
+// diff command.
+const CertificateDiffKind = "CertificateDiff"
+
+// Prefixes of the arguments that refer to a CertificateRequest instead of a
+// file, see secret.SecretSourcePrefix for Secrets.
+const (
+	certificateRequestSourcePrefix = "certificaterequest/"
+	crSourcePrefix                 = "cr/"
+)
+
+// extensionNames are the names of the extensions shown in the comparison of
+// the extensions, all others are shown by their OID.
+var extensionNames = map[string]string{
+	"2.5.29.14":               "Subject Key ID",
+	"2.5.29.15":               "Key Usage",
+	"2.5.29.17":               "Subject Alternative Name",
+	"2.5.29.19":               "Basic Constraints",
+	"2.5.29.30":               "Name Constraints",
+	"2.5.29.31":               "CRL Distribution Points",
+	"2.5.29.32":               "Certificate Policies",
+	"2.5.29.35":               "Authority Key ID",
+	"2.5.29.37":               "Extended Key Usage",
+	"1.3.6.1.5.5.7.1.1":       "Authority Information Access",
+	"1.3.6.1.4.1.11129.2.4.2": "Signed Certificate Timestamps",
+}
+
+// CertificateDiff is the field by field comparison of the leaf certificates
+// and chains of two sources, printed by the inspect diff command.
+type CertificateDiff struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// A and B are the compared sources, as passed as arguments
+	A string `json:"a"`
+	B string `json:"b"`
+	// Identical is true if all compared fields are equal
+	Identical bool `json:"identical"`
+	// Fields are the compared fields, in the order they are printed
+	Fields []FieldDiff `json:"fields"`
+}
+
+// FieldDiff is the comparison of a field of the two certificates.
+type FieldDiff struct {
+	Name  string `json:"name"`
+	Equal bool   `json:"equal"`
+	// A and B are the values of the field in the certificates. Fields with a
+	// single value, e.g. the subject, have one element.
+	A []string `json:"a"`
+	B []string `json:"b"`
+}
+
+// String returns the comparison of the field as lines of the human readable
+// output. Equal fields are prefixed with "  " and changed fields with "~ ".
+// The values of a changed field are listed below it, prefixed with "-" if
+// they are only in a, "+" if they are only in b and " " if they are in both.
+func (f *FieldDiff) String() string {
+	if f.Equal {
+		return fmt.Sprintf("  %s:\t%s", f.Name, printValues(f.A))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "~ %s:", f.Name)
+	if len(f.A) == 0 {
+		b.WriteString("\n\t- <none>")
+	}
+	for _, value := range f.A {
+		if slices.Contains(f.B, value) {
+			fmt.Fprintf(&b, "\n\t  %s", value)
+		} else {
+			fmt.Fprintf(&b, "\n\t- %s", value)
+		}
+	}
+	for _, value := range f.B {
+		if !slices.Contains(f.A, value) {
+			fmt.Fprintf(&b, "\n\t+ %s", value)
+		}
+	}
+	if len(f.B) == 0 {
+		b.WriteString("\n\t+ <none>")
+	}
+	return b.String()
+}
+
+// String returns the comparison as the human readable output
+func (d *CertificateDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s", d.A, d.B)
+	for _, field := range d.Fields {
+		fmt.Fprintf(&b, "\n%s", &field)
+	}
+	if d.Identical {
+		b.WriteString("\n\nThe certificates are identical")
+	}
+	return b.String()
+}
+
+// Options is a struct to support inspect diff command
+type Options struct {
+	// PrintFlags holds the flags used to print the comparison in a structured
+	// format. If no output format is set, the human readable output is
+	// printed.
+	PrintFlags *genericclioptions.PrintFlags
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		PrintFlags: util.NewPrintFlags(),
+		IOStreams:  ioStreams,
+	}
+}
+
+// NewCmdInspectDiff returns a cobra command for inspect diff
+func NewCmdInspectDiff(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:   "diff <a> <b>",
+		Short: "Compare two certificates field by field",
+		Long: templates.LongDesc(`
+Compare two certificates field by field, e.g. the certificate before and after a renewal.
+
+Each side is a local file, stdin if the path is '-', the 'tls.crt' of a Secret given as
+'secret/[namespace/]name', or the issued certificate of a CertificateRequest given as
+'certificaterequest/[namespace/]name' or 'cr/[namespace/]name'. Files are decoded like in the inspect file
+command.
+
+The leaf certificates are compared by subject, SANs, public key, issuer, validity, usages and extensions, and
+the chains by their intermediate certificates. Extensions are compared by OID, criticality and a digest of
+their value. Changed fields are prefixed with '~', followed by the values only in <a> ('-'), only in <b> ('+')
+and in both.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Compare the certificate in secret 'my-crt' with a backup of it
+{{.BuildName}} inspect diff secret/my-crt my-crt-backup.yaml
+
+# Compare the certificates issued for the last two CertificateRequests of Certificate 'my-crt'
+{{.BuildName}} inspect diff cr/my-crt-1 cr/my-crt-2
+
+# Compare a certificate file with the certificate in secret 'my-crt' in namespace 'my-namespace' as JSON
+{{.BuildName}} inspect diff tls.crt secret/my-namespace/my-crt -o json
+`)),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(cmd)
+
+	// The cluster is only needed to fetch Secrets and CertificateRequests, so
+	// the Factory is only populated if one is referenced.
+	factoryPreRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		for _, arg := range args {
+			if kind, _, _, _ := parseResourceSource(arg); kind != "" {
+				return factoryPreRunE(cmd, args)
+			}
+		}
+		return o.Validate(args)
+	}
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) != 2 {
+		return errors.New("the two certificates to compare have to be provided as arguments: a path, '-' to read from stdin, secret/[namespace/]name or certificaterequest/[namespace/]name")
+	}
+	if args[0] == "-" && args[1] == "-" {
+		return errors.New("only one of the certificates can be read from stdin")
+	}
+	for _, arg := range args {
+		if _, _, _, err := parseResourceSource(arg); err != nil {
+			return err
+		}
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run executes inspect diff command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	a, err := o.loadChain(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	b, err := o.loadChain(ctx, args[1])
+	if err != nil {
+		return err
+	}
+
+	certDiff := diffChains(a, b)
+	certDiff.A, certDiff.B = args[0], args[1]
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		return util.PrintObject(printer, certDiff, o.Out)
+	}
+
+	fmt.Fprintf(o.Out, "%s\n", certDiff)
+	return nil
+}
+
+// loadChain returns the certificates of source, leaf certificate first.
+func (o *Options) loadChain(ctx context.Context, source string) ([]*x509.Certificate, error) {
+	kind, namespace, name, err := parseResourceSource(source)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" && o.Factory != nil {
+		namespace = o.Namespace
+	}
+
+	var data []byte
+	switch kind {
+	case secret.SecretSourcePrefix:
+		s, err := o.KubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, cmcmdutil.SetFetchErrorExitCode(fmt.Errorf("error when finding Secret %q in namespace %q: %w", name, namespace, err))
+		}
+		if data = s.Data[corev1.TLSCertKey]; len(data) == 0 {
+			return nil, fmt.Errorf("no certificate found in 'tls.crt' of Secret %q in namespace %q", name, namespace)
+		}
+	case certificateRequestSourcePrefix:
+		req, err := o.CMClient.CertmanagerV1().CertificateRequests(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, cmcmdutil.SetFetchErrorExitCode(fmt.Errorf("error when finding CertificateRequest %q in namespace %q: %w", name, namespace, err))
+		}
+		if data = req.Status.Certificate; len(data) == 0 {
+			return nil, fmt.Errorf("CertificateRequest %q in namespace %q has no issued certificate yet", name, namespace)
+		}
+	default:
+		if data, err = secret.ReadFile(o.In, source); err != nil {
+			return nil, err
+		}
+	}
+
+	return secret.DecodeCertificates(data, source)
+}
+
+// parseResourceSource parses source in the form <kind>/[namespace/]name. kind
+// is the prefix of the source, with 'cr/' normalized to
+// 'certificaterequest/', or empty if source is a file.
+func parseResourceSource(source string) (kind, namespace, name string, err error) {
+	for _, prefix := range []string{secret.SecretSourcePrefix, certificateRequestSourcePrefix, crSourcePrefix} {
+		namespace, name, found, err := secret.ParseResourceSource(source, prefix)
+		if !found {
+			continue
+		}
+		if prefix == crSourcePrefix {
+			prefix = certificateRequestSourcePrefix
+		}
+		return prefix, namespace, name, err
+	}
+	return "", "", "", nil
+}
+
+// diffChains compares the leaf certificates and intermediate certificates of
+// chains a and b.
+func diffChains(a, b []*x509.Certificate) *CertificateDiff {
+	leafA, leafB := a[0], b[0]
+	certDiff := &CertificateDiff{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       CertificateDiffKind,
+		},
+	}
+
+	addScalar := func(name string, value func(*x509.Certificate) string) {
+		certDiff.Fields = append(certDiff.Fields, newFieldDiff(name, []string{value(leafA)}, []string{value(leafB)}))
+	}
+	addList := func(name string, values func(*x509.Certificate) []string) {
+		certDiff.Fields = append(certDiff.Fields, newFieldDiff(name, values(leafA), values(leafB)))
+	}
+
+	addScalar("Subject", func(c *x509.Certificate) string { return c.Subject.String() })
+	addList("DNS Names", func(c *x509.Certificate) []string { return c.DNSNames })
+	addList("IP Addresses", func(c *x509.Certificate) []string { return pki.IPAddressesToString(c.IPAddresses) })
+	addList("URIs", func(c *x509.Certificate) []string { return pki.URLsToString(c.URIs) })
+	addList("Email Addresses", func(c *x509.Certificate) []string { return c.EmailAddresses })
+	addScalar("Public Key Algorithm", func(c *x509.Certificate) string { return secret.PublicKeyType(c.PublicKey) })
+	addScalar("Public Key", func(c *x509.Certificate) string {
+		return fmt.Sprintf("SHA-256 %x", sha256.Sum256(c.RawSubjectPublicKeyInfo))
+	})
+	addScalar("Issuer", func(c *x509.Certificate) string { return c.Issuer.String() })
+	addScalar("Serial Number", func(c *x509.Certificate) string { return c.SerialNumber.String() })
+	addScalar("Signature Algorithm", func(c *x509.Certificate) string { return c.SignatureAlgorithm.String() })
+	addScalar("Not Before", func(c *x509.Certificate) string { return c.NotBefore.Format(time.RFC1123) })
+	addScalar("Not After", func(c *x509.Certificate) string { return c.NotAfter.Format(time.RFC1123) })
+	addScalar("Duration", func(c *x509.Certificate) string {
+		return duration.HumanDuration(c.NotAfter.Sub(c.NotBefore))
+	})
+	addScalar("Is a CA certificate", func(c *x509.Certificate) string { return fmt.Sprint(c.IsCA) })
+	addList("Usages", usages)
+	addList("Extensions", extensions)
+
+	intermediates := func(chain []*x509.Certificate) []string {
+		var values []string
+		for _, cert := range chain[1:] {
+			values = append(values, fmt.Sprintf("%s (SHA-256 %x)", cert.Subject, sha256.Sum256(cert.Raw)))
+		}
+		return values
+	}
+	certDiff.Fields = append(certDiff.Fields, newOrderedFieldDiff("Intermediates", intermediates(a), intermediates(b)))
+
+	certDiff.Identical = true
+	for _, field := range certDiff.Fields {
+		certDiff.Identical = certDiff.Identical && field.Equal
+	}
+	return certDiff
+}
+
+// newFieldDiff returns the comparison of the values a and b of the field name.
+// The values are compared as sets: a field whose values are only reordered is
+// equal.
+func newFieldDiff(name string, a, b []string) FieldDiff {
+	return FieldDiff{
+		Name:  name,
+		Equal: slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b))),
+		A:     a,
+		B:     b,
+	}
+}
+
+// newOrderedFieldDiff returns the comparison of the values a and b of the
+// field name, whose order is significant, e.g. the certificates of a chain.
+func newOrderedFieldDiff(name string, a, b []string) FieldDiff {
+	return FieldDiff{
+		Name:  name,
+		Equal: slices.Equal(a, b),
+		A:     a,
+		B:     b,
+	}
+}
+
+// usages returns the key usages and extended key usages of cert, with the
+// names of the usages of a Certificate resource.
+func usages(cert *x509.Certificate) []string {
+	var values []string
+	for _, usage := range apiutil.KeyUsageStrings(cert.KeyUsage) {
+		values = append(values, string(usage))
+	}
+	for _, usage := range apiutil.ExtKeyUsageStrings(cert.ExtKeyUsage) {
+		values = append(values, string(usage))
+	}
+	return values
+}
+
+// extensions returns the extensions of cert by name or OID, criticality and a
+// digest of their value.
+func extensions(cert *x509.Certificate) []string {
+	var values []string
+	for _, extension := range cert.Extensions {
+		name := extension.Id.String()
+		if knownName, ok := extensionNames[name]; ok {
+			name = fmt.Sprintf("%s (%s)", knownName, name)
+		}
+		if extension.Critical {
+			name += ", critical"
+		}
+		digest := sha256.Sum256(extension.Value)
+		values = append(values, fmt.Sprintf("%s, SHA-256 %x", name, digest[:8]))
+	}
+	return values
+}
+
+// printValues returns the values of a field separated by commas, or "<none>"
+// if there are none.
+func printValues(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+type testKeyPair struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+}
+
+// newTestKeyPair returns a certificate with an ECDSA key and the given DNS
+// names, signed by issuer or self-signed if issuer is nil.
+func newTestKeyPair(t *testing.T, commonName string, isCA bool, issuer *testKeyPair, dnsNames ...string) *testKeyPair {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template, err := pki.CertificateTemplateFromCertificate(gen.Certificate(commonName,
+		gen.SetCertificateCommonName(commonName),
+		gen.SetCertificateIsCA(isCA),
+		gen.SetCertificateDNSNames(dnsNames...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.NotBefore, template.NotAfter = time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	issuerCert, issuerKey := template, crypto.Signer(key)
+	if issuer != nil {
+		issuerCert, issuerKey = issuer.cert, issuer.key
+	}
+	certPEM, cert, err := pki.SignCertificate(template, issuerCert, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKeyPair{cert: cert, certPEM: certPEM, key: key}
+}
+
+func TestRun(t *testing.T) {
+	root := newTestKeyPair(t, "root", true, nil)
+	oldIntermediate := newTestKeyPair(t, "intermediate-1", true, root)
+	newIntermediate := newTestKeyPair(t, "intermediate-2", true, root)
+	oldLeaf := newTestKeyPair(t, "example.com", false, oldIntermediate, "example.com", "www.example.com")
+	newLeaf := newTestKeyPair(t, "example.com", false, newIntermediate, "example.com")
+
+	oldChain := append(append([]byte{}, oldLeaf.certPEM...), oldIntermediate.certPEM...)
+	newChain := append(append([]byte{}, newLeaf.certPEM...), newIntermediate.certPEM...)
+
+	dir := t.TempDir()
+	oldChainFile := filepath.Join(dir, "old.crt")
+	if err := os.WriteFile(oldChainFile, oldChain, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	kubeClient := kubefake.NewClientset(gen.Secret("my-crt",
+		gen.SetSecretNamespace("my-namespace"),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: newChain})))
+	cmClient := cmfake.NewClientset(
+		gen.CertificateRequest("my-crt-1",
+			gen.SetCertificateRequestNamespace("my-namespace"),
+			gen.SetCertificateRequestCertificate(oldChain)),
+		gen.CertificateRequest("my-crt-2",
+			gen.SetCertificateRequestNamespace("my-namespace")),
+	)
+
+	tests := map[string]struct {
+		args         []string
+		outputFormat string
+		expOutput    []string
+		expErr       string
+	}{
+		"Renewal dropped a SAN and swapped the intermediate": {
+			args: []string{oldChainFile, "secret/my-crt"},
+			expOutput: []string{
+				"--- " + oldChainFile + "\n+++ secret/my-crt\n",
+				"  Subject:\tCN=example.com\n",
+				"~ DNS Names:\n\t  example.com\n\t- www.example.com\n",
+				"  Public Key Algorithm:\tECDSA 256\n",
+				"~ Public Key:\n",
+				"~ Issuer:\n\t- CN=intermediate-1\n\t+ CN=intermediate-2\n",
+				"  Duration:\t120m\n",
+				"  Usages:\tdigital signature, key encipherment\n",
+				fmt.Sprintf("~ Intermediates:\n\t- CN=intermediate-1 (SHA-256 %x)\n\t+ CN=intermediate-2 (SHA-256 %x)", sha256.Sum256(oldIntermediate.cert.Raw), sha256.Sum256(newIntermediate.cert.Raw)),
+			},
+		},
+		"Identical certificates": {
+			args:      []string{"cr/my-namespace/my-crt-1", oldChainFile},
+			expOutput: []string{"\n\nThe certificates are identical\n"},
+		},
+		"JSON": {
+			args:         []string{"certificaterequest/my-crt-1", "secret/my-crt"},
+			outputFormat: "json",
+			expOutput:    []string{`"kind": "CertificateDiff"`, `"identical": false`, `"name": "DNS Names"`},
+		},
+		"CertificateRequest without certificate": {
+			args:   []string{"cr/my-crt-2", "secret/my-crt"},
+			expErr: `CertificateRequest "my-crt-2" in namespace "my-namespace" has no issued certificate yet`,
+		},
+		"Missing Secret": {
+			args:   []string{oldChainFile, "secret/missing"},
+			expErr: `error when finding Secret "missing" in namespace "my-namespace"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			o := NewOptions(ioStreams)
+			*o.PrintFlags.OutputFormat = test.outputFormat
+			o.Factory = &factory.Factory{
+				Namespace:  "my-namespace",
+				KubeClient: kubeClient,
+				CMClient:   cmClient,
+			}
+			if err := o.Validate(test.args); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Run(t.Context(), test.args)
+			if test.expErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.expErr)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for _, expOutput := range test.expOutput {
+				assert.Contains(t, out.String(), expOutput)
+			}
+			if test.outputFormat == "json" {
+				assert.True(t, json.Valid(bytes.TrimSpace(out.Bytes())), "output is not valid JSON: %s", strings.TrimSpace(out.String()))
+			}
+		})
+	}
+}
+
+func TestFieldDiffString(t *testing.T) {
+	tests := map[string]struct {
+		field FieldDiff
+		exp   string
+	}{
+		"Equal field": {
+			field: newFieldDiff("DNS Names", []string{"a.example.com", "b.example.com"}, []string{"a.example.com", "b.example.com"}),
+			exp:   "  DNS Names:\ta.example.com, b.example.com",
+		},
+		"Equal empty field": {
+			field: newFieldDiff("URIs", nil, nil),
+			exp:   "  URIs:\t<none>",
+		},
+		"Changed field": {
+			field: newFieldDiff("DNS Names", []string{"a.example.com", "b.example.com"}, []string{"a.example.com", "c.example.com"}),
+			exp:   "~ DNS Names:\n\t  a.example.com\n\t- b.example.com\n\t+ c.example.com",
+		},
+		"Reordered field is equal": {
+			field: newFieldDiff("DNS Names", []string{"a.example.com", "b.example.com"}, []string{"b.example.com", "a.example.com"}),
+			exp:   "  DNS Names:\ta.example.com, b.example.com",
+		},
+		"Reordered ordered field": {
+			field: newOrderedFieldDiff("Intermediates", []string{"CN=a", "CN=b"}, []string{"CN=b", "CN=a"}),
+			exp:   "~ Intermediates:\n\t  CN=a\n\t  CN=b",
+		},
+		"Added field": {
+			field: newFieldDiff("URIs", nil, []string{"spiffe://example.com/app"}),
+			exp:   "~ URIs:\n\t- <none>\n\t+ spiffe://example.com/app",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, test.field.String())
+		})
+	}
+}
+
+func TestDiffChainsListOrder(t *testing.T) {
+	root := newTestKeyPair(t, "root", true, nil)
+	intermediate1 := newTestKeyPair(t, "intermediate-1", true, root)
+	intermediate2 := newTestKeyPair(t, "intermediate-2", true, root)
+	leaf := newTestKeyPair(t, "leaf", false, intermediate1, "a.example.com", "b.example.com")
+	reorderedLeaf := newTestKeyPair(t, "leaf", false, intermediate1, "b.example.com", "a.example.com")
+
+	certDiff := diffChains(
+		[]*x509.Certificate{leaf.cert, intermediate1.cert, intermediate2.cert},
+		[]*x509.Certificate{reorderedLeaf.cert, intermediate2.cert, intermediate1.cert})
+
+	equal := map[string]bool{}
+	for _, field := range certDiff.Fields {
+		equal[field.Name] = field.Equal
+	}
+	assert.True(t, equal["DNS Names"], "reordered DNS names are equal")
+	assert.False(t, equal["Intermediates"], "reordered intermediates are changed")
+}
+
+func TestLoadChainInvalidSource(t *testing.T) {
+	o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+	_, err := o.loadChain(t.Context(), "secret/a/b/c")
+	assert.EqualError(t, err, `invalid argument "secret/a/b/c", expected secret/[namespace/]name`)
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args   []string
+		expErr string
+	}{
+		"Files and resources": {
+			args: []string{"-", "cr/my-namespace/my-crt-1"},
+		},
+		"One argument": {
+			args:   []string{"tls.crt"},
+			expErr: "the two certificates to compare have to be provided as arguments: a path, '-' to read from stdin, secret/[namespace/]name or certificaterequest/[namespace/]name",
+		},
+		"Both from stdin": {
+			args:   []string{"-", "-"},
+			expErr: "only one of the certificates can be read from stdin",
+		},
+		"Invalid Secret": {
+			args:   []string{"tls.crt", "secret/"},
+			expErr: `invalid argument "secret/", expected secret/[namespace/]name`,
+		},
+		"Invalid CertificateRequest": {
+			args:   []string{"cr/a/b/c", "tls.crt"},
+			expErr: `invalid argument "cr/a/b/c", expected cr/[namespace/]name`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+
+			err := o.Validate(test.args)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	k8sclock "k8s.io/utils/clock"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/secret"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+// EndpointInspectionKind is the kind of the structured output of the inspect
+// endpoint command.
+const EndpointInspectionKind = "EndpointInspection"
+
+// EndpointInspection is the structured representation of the certificates
+// served by a TLS endpoint, printed by the inspect endpoint command when
+// --output is used.
+type EndpointInspection struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Address of the endpoint, in the form host:port
+	Address string `json:"address"`
+	// ServerName sent in the TLS handshake (SNI)
+	ServerName string `json:"serverName,omitempty"`
+	// TLSVersion negotiated in the TLS handshake
+	TLSVersion string `json:"tlsVersion"`
+	// Certificates served by the endpoint, leaf certificate first
+	Certificates []*secret.CertificateInspection `json:"certificates"`
+	// SecretComparison is the result of the comparison with --compare-secret,
+	// if set
+	SecretComparison *SecretComparison `json:"secretComparison,omitempty"`
+}
+
+// SecretComparison is the result of comparing the certificate served by an
+// endpoint with the certificate stored in a Secret.
+type SecretComparison struct {
+	// Secret is the compared Secret, in the form namespace/name
+	Secret string `json:"secret"`
+	// Serving is true if the endpoint serves the leaf certificate of the Secret
+	Serving bool `json:"serving"`
+	// Message explains the difference if the endpoint serves another
+	// certificate
+	Message string `json:"message,omitempty"`
+}
+
+// String returns the comparison as a section of the human readable output
+func (c *SecretComparison) String() string {
+	result := "yes"
+	if !c.Serving {
+		result = "no: " + c.Message
+	}
+	return fmt.Sprintf("Secret %s:\n\tServing the certificate of the Secret:\t%s", c.Secret, result)
+}
+
+// Options is a struct to support inspect endpoint command
+type Options struct {
+	// SNI is the server name sent in the TLS handshake. Defaults to the host of
+	// the address.
+	SNI string
+	// CompareSecret is the Secret, in the form [namespace/]name, whose
+	// certificate is compared with the certificate served by the endpoint
+	CompareSecret string
+	// Timeout of the TLS handshake
+	Timeout time.Duration
+
+	// ExpiringSoonThreshold is the remaining validity below which the
+	// certificate is reported as expiring soon by the exit code.
+	ExpiringSoonThreshold time.Duration
+
+	// Revocation holds the flags that configure the CRL and OCSP checks
+	Revocation *secret.RevocationOptions
+
+	// PrintFlags holds the flags used to print the certificates in a
+	// structured format. If no output format is set, the human readable output
+	// is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Timeout:               10 * time.Second,
+		ExpiringSoonThreshold: cmcmdutil.DefaultExpiringSoonThreshold,
+		Revocation:            secret.NewRevocationOptions(),
+		PrintFlags:            util.NewPrintFlags(),
+		IOStreams:             ioStreams,
+	}
+}
+
+// NewCmdInspectEndpoint returns a cobra command for inspect endpoint
+func NewCmdInspectEndpoint(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:   "endpoint <host:port>",
+		Short: "Get details about the certificates served by a TLS endpoint",
+		Long: templates.LongDesc(`
+Get details about the certificates served by a TLS endpoint.
+
+A TLS handshake is performed with the endpoint, and the served leaf certificate is described and checked like the
+certificate of the inspect secret command, using the other served certificates as intermediates. The handshake
+does not verify the served certificates, so that expired or untrusted certificates can be inspected. An OCSP
+response stapled by the endpoint is used instead of querying the OCSP responder. The CRL and OCSP checks are
+configured like those of the inspect secret command, e.g. with --offline and --crl-file.
+
+With --compare-secret, the served certificate is compared with the certificate in 'tls.crt' of the given Secret,
+to detect servers that still serve an old certificate after the Secret was renewed. The 'ca.crt' of the Secret
+is then also used to find the issuer of the served certificate for the CRL and OCSP checks.
+
+The exit code reports the health of the served certificate, like the exit code of the inspect secret command.
+If the endpoint does not serve the certificate of --compare-secret, the exit code is at least 3.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Query information about the certificate served by example.com
+{{.BuildName}} inspect endpoint example.com:443
+
+# Query information about the certificate served for app.example.com by an ingress controller
+{{.BuildName}} inspect endpoint 10.0.0.10:443 --sni app.example.com
+
+# Check that the ingress controller serves the certificate in secret 'app-tls' in namespace 'my-namespace'
+{{.BuildName}} inspect endpoint app.example.com:443 --compare-secret my-namespace/app-tls
+`)),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.SNI, "sni", o.SNI, "Server name sent in the TLS handshake. Defaults to the host of the address.")
+	cmd.Flags().StringVar(&o.CompareSecret, "compare-secret", o.CompareSecret, "Secret, in the form [namespace/]name, whose certificate is compared with the served certificate.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "Timeout of the TLS handshake.")
+	cmd.Flags().DurationVar(&o.ExpiringSoonThreshold, "expiring-soon-threshold", o.ExpiringSoonThreshold, "Remaining validity below which the certificate is reported as expiring soon by the exit code.")
+	o.Revocation.AddFlags(cmd)
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(cmd)
+
+	// The cluster is only needed to fetch the Secret of --compare-secret, so
+	// the Factory is only populated if it is set.
+	factoryPreRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if o.CompareSecret == "" {
+			return o.Validate(args)
+		}
+		return factoryPreRunE(cmd, args)
+	}
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the address of the endpoint has to be provided as argument, in the form host:port")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the address of the endpoint")
+	}
+	if _, _, err := net.SplitHostPort(args[0]); err != nil {
+		return fmt.Errorf("the address of the endpoint has to be in the form host:port: %w", err)
+	}
+	if o.CompareSecret != "" {
+		if _, _, _, err := secret.ParseResourceSource(o.CompareSecret, ""); err != nil {
+			return fmt.Errorf("invalid --compare-secret %q, expected [namespace/]name", o.CompareSecret)
+		}
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run executes inspect endpoint command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	address := args[0]
+
+	revocation, err := o.Revocation.ToChecker()
+	if err != nil {
+		return err
+	}
+
+	var compared *corev1.Secret
+	if o.CompareSecret != "" {
+		if compared, err = o.getSecret(ctx); err != nil {
+			return cmcmdutil.SetFetchErrorExitCode(err)
+		}
+	}
+
+	state, serverName, err := o.handshake(ctx, address)
+	if err != nil {
+		return cmcmdutil.SetFetchErrorExitCode(err)
+	}
+	if len(state.PeerCertificates) < 1 {
+		return fmt.Errorf("no certificate served by %q", address)
+	}
+
+	certs := make([][]byte, 0, len(state.PeerCertificates))
+	for _, cert := range state.PeerCertificates {
+		certs = append(certs, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+	leaf := state.PeerCertificates[0]
+	if len(state.OCSPResponse) > 0 {
+		revocation.AddOCSPResponse(state.OCSPResponse)
+	}
+
+	var ca []byte
+	var comparison *SecretComparison
+	if compared != nil {
+		ca = compared.Data[cmmeta.TLSCAKey]
+		if comparison, err = compareWithSecret(leaf, compared); err != nil {
+			return err
+		}
+	}
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		certInspections, err := secret.InspectCertificates(ctx, certs, ca, revocation)
+		if err != nil {
+			return err
+		}
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		if err := util.PrintObject(printer, &EndpointInspection{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: util.OutputGroupVersion.String(),
+				Kind:       EndpointInspectionKind,
+			},
+			Address:          address,
+			ServerName:       serverName,
+			TLSVersion:       tls.VersionName(state.Version),
+			Certificates:     certInspections,
+			SecretComparison: comparison,
+		}, o.Out); err != nil {
+			return err
+		}
+	} else {
+		desc, err := secret.DescribeCertificate(ctx, leaf, certs[1:], ca, revocation)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "Endpoint: %s\nServer Name: %s\nTLS Version: %s\nServed Certificates: %d\n\n%s\n",
+			address, serverName, tls.VersionName(state.Version), len(certs), desc)
+		if comparison != nil {
+			fmt.Fprintf(o.Out, "\n%s\n", comparison)
+		}
+	}
+
+	exitCode := secret.ExitCodeForCertificate(leaf, clock.Now(), o.ExpiringSoonThreshold)
+	if comparison != nil && !comparison.Serving {
+		exitCode = max(exitCode, cmcmdutil.ExitCodeNotReady)
+	}
+	cmcmdutil.SetExitCodeValue(exitCode)
+	return nil
+}
+
+// handshake performs a TLS handshake with address and returns the connection
+// state and the server name that was sent.
+func (o *Options) handshake(ctx context.Context, address string) (*tls.ConnectionState, string, error) {
+	serverName := o.SNI
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, "", err
+		}
+		if net.ParseIP(host) == nil {
+			serverName = host
+		}
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: o.Timeout},
+		Config: &tls.Config{
+			ServerName: serverName,
+			// The served certificates are inspected, not trusted: expired and
+			// untrusted certificates have to be described too.
+			InsecureSkipVerify: true, // #nosec G402
+		},
+	}
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, "", fmt.Errorf("error when performing the TLS handshake with %q: %w", address, err)
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	return &state, serverName, nil
+}
+
+// getSecret returns the Secret of --compare-secret.
+func (o *Options) getSecret(ctx context.Context) (*corev1.Secret, error) {
+	namespace, name, _, err := secret.ParseResourceSource(o.CompareSecret, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid --compare-secret %q, expected [namespace/]name", o.CompareSecret)
+	}
+	if namespace == "" {
+		namespace = o.Namespace
+	}
+
+	s, err := o.KubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when finding Secret %q in namespace %q: %w", name, namespace, err)
+	}
+	return s, nil
+}
+
+// compareWithSecret compares leaf, the certificate served by the endpoint,
+// with the leaf certificate in 'tls.crt' of s.
+func compareWithSecret(leaf *x509.Certificate, s *corev1.Secret) (*SecretComparison, error) {
+	secretLeaf, err := pki.DecodeX509CertificateBytes(s.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing 'tls.crt' of Secret %q: %w", s.Name, err)
+	}
+
+	comparison := &SecretComparison{
+		Secret:  s.Namespace + "/" + s.Name,
+		Serving: bytes.Equal(leaf.Raw, secretLeaf.Raw),
+	}
+	if comparison.Serving {
+		return comparison, nil
+	}
+
+	comparison.Message = fmt.Sprintf("the endpoint serves serial number %s (not after %s), the Secret holds serial number %s (not after %s)",
+		leaf.SerialNumber, leaf.NotAfter.Format(time.RFC1123), secretLeaf.SerialNumber, secretLeaf.NotAfter.Format(time.RFC1123))
+	if secretLeaf.NotBefore.After(leaf.NotBefore) {
+		comparison.Message += "; the certificate in the Secret is newer, the server has not loaded it yet"
+	}
+	return comparison, nil
+}
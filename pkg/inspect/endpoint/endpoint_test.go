@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+// newServingCertificate returns a self-signed certificate and private key for
+// example.com, valid from notBefore for two hours.
+func newServingCertificate(t *testing.T, notBefore time.Time) (certPEM, keyPEM []byte) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err = pki.EncodePrivateKey(key, cmapi.PKCS8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.CertificateTemplateFromCertificate(gen.Certificate("test",
+		gen.SetCertificateDNSNames("example.com")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.NotBefore, template.NotAfter = notBefore, notBefore.Add(2*time.Hour)
+	certPEM, _, err = pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, keyPEM
+}
+
+func TestRun(t *testing.T) {
+	servedCert, servedKey := newServingCertificate(t, time.Now().Add(-time.Hour))
+	renewedCert, _ := newServingCertificate(t, time.Now().Add(-time.Minute))
+
+	keyPair, err := tls.X509KeyPair(servedCert, servedKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The server name is written by the server goroutine
+	var serverName atomic.Value
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverName.Store(hello.ServerName)
+			return nil, nil
+		},
+	}
+	server.StartTLS()
+	defer server.Close()
+	address := server.Listener.Addr().String()
+
+	tlsSecret := func(certPEM []byte) *corev1.Secret {
+		return gen.Secret("app-tls",
+			gen.SetSecretNamespace("my-namespace"),
+			gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certPEM}))
+	}
+
+	tests := map[string]struct {
+		address       string
+		sni           string
+		compareSecret string
+		secret        *corev1.Secret
+		outputFormat  string
+		expServerName string
+		expOutput     []string
+		expErr        string
+	}{
+		"Served certificate": {
+			address:   address,
+			expOutput: []string{"Endpoint: " + address + "\n", "Served Certificates: 1\n", "\t\t- example.com\n"},
+		},
+		"Served certificate with SNI": {
+			address:       address,
+			sni:           "example.com",
+			expServerName: "example.com",
+			expOutput:     []string{"Server Name: example.com\n"},
+		},
+		"Served certificate is the certificate of the Secret": {
+			address:       address,
+			compareSecret: "my-namespace/app-tls",
+			secret:        tlsSecret(servedCert),
+			expOutput:     []string{"Secret my-namespace/app-tls:\n\tServing the certificate of the Secret:\tyes\n"},
+		},
+		"Secret was renewed but the old certificate is served": {
+			address:       address,
+			compareSecret: "app-tls",
+			secret:        tlsSecret(renewedCert),
+			expOutput:     []string{"\tServing the certificate of the Secret:\tno: the endpoint serves serial number", "the certificate in the Secret is newer"},
+		},
+		"Comparison as JSON": {
+			address:       address,
+			compareSecret: "app-tls",
+			secret:        tlsSecret(renewedCert),
+			outputFormat:  "json",
+			expOutput:     []string{`"kind": "EndpointInspection"`, `"tlsVersion": "TLS 1.3"`, `"secret": "my-namespace/app-tls"`, `"serving": false`},
+		},
+		"Missing Secret": {
+			address:       address,
+			compareSecret: "missing",
+			secret:        tlsSecret(servedCert),
+			expErr:        `error when finding Secret "missing" in namespace "my-namespace"`,
+		},
+		"Endpoint not listening": {
+			address: "127.0.0.1:1",
+			expErr:  `error when performing the TLS handshake with "127.0.0.1:1"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverName.Store("")
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			o := NewOptions(ioStreams)
+			o.SNI = test.sni
+			o.CompareSecret = test.compareSecret
+			*o.PrintFlags.OutputFormat = test.outputFormat
+			if test.secret != nil {
+				o.Factory = &factory.Factory{
+					Namespace:  "my-namespace",
+					KubeClient: kubefake.NewClientset(test.secret),
+				}
+			}
+			if err := o.Validate([]string{test.address}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Run(t.Context(), []string{test.address})
+			if test.expErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.expErr)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, test.expServerName, serverName.Load())
+			for _, expOutput := range test.expOutput {
+				assert.Contains(t, out.String(), expOutput)
+			}
+			if test.outputFormat == "json" {
+				assert.True(t, json.Valid(bytes.TrimSpace(out.Bytes())), "output is not valid JSON: %s", strings.TrimSpace(out.String()))
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args          []string
+		compareSecret string
+		expErr        string
+	}{
+		"Valid address": {
+			args: []string{"example.com:443"},
+		},
+		"No address": {
+			expErr: "the address of the endpoint has to be provided as argument, in the form host:port",
+		},
+		"Address without port": {
+			args:   []string{"example.com"},
+			expErr: "the address of the endpoint has to be in the form host:port: address example.com: missing port in address",
+		},
+		"Invalid Secret": {
+			args:          []string{"example.com:443"},
+			compareSecret: "a/b/c",
+			expErr:        `invalid --compare-secret "a/b/c", expected [namespace/]name`,
+		},
+		"Secret without name": {
+			args:          []string{"example.com:443"},
+			compareSecret: "my-namespace/",
+			expErr:        `invalid --compare-secret "my-namespace/", expected [namespace/]name`,
+		},
+		"Secret without namespace": {
+			args:          []string{"example.com:443"},
+			compareSecret: "/app-tls",
+			expErr:        `invalid --compare-secret "/app-tls", expected [namespace/]name`,
+		},
+		"Secret with namespace": {
+			args:          []string{"example.com:443"},
+			compareSecret: "my-namespace/app-tls",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.CompareSecret = test.compareSecret
+
+			err := o.Validate(test.args)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	k8sclock "k8s.io/utils/clock"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/secret"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+// FileInspectionKind is the kind of the structured output of the inspect file
+// command.
+const FileInspectionKind = "FileInspection"
+
+// FileInspection is the structured representation of the certificates in a
+// file, printed by the inspect file command when --output is used.
+type FileInspection struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Path of the file, "-" for stdin
+	Path string `json:"path"`
+	// Format the file was decoded from
+	Format string `json:"format"`
+	// Certificates in the file, leaf certificate first
+	Certificates []*secret.CertificateInspection `json:"certificates"`
+}
+
+// Options is a struct to support inspect file command
+type Options struct {
+	// Password used to decrypt PKCS#12 and JKS keystores
+	Password string
+
+	// ExpiringSoonThreshold is the remaining validity below which the
+	// certificate is reported as expiring soon by the exit code.
+	ExpiringSoonThreshold time.Duration
+
+	// Revocation holds the flags that configure the CRL and OCSP checks
+	Revocation *secret.RevocationOptions
+
+	// PrintFlags holds the flags used to print the certificates in a
+	// structured format. If no output format is set, the human readable output
+	// is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		ExpiringSoonThreshold: cmcmdutil.DefaultExpiringSoonThreshold,
+		Revocation:            secret.NewRevocationOptions(),
+		PrintFlags:            util.NewPrintFlags(),
+		IOStreams:             ioStreams,
+	}
+}
+
+// NewCmdInspectFile returns a cobra command for inspect file
+func NewCmdInspectFile(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:   "file <path|->",
+		Short: "Get details about the certificates in a local file",
+		Long: templates.LongDesc(`
+Get details about the certificates in a local file, or in stdin if the path is '-', without access to a cluster.
+
+The format of the file is detected automatically. Supported are PEM bundles, DER encoded certificates,
+PKCS#12 and JKS keystores and truststores, and kubernetes.io/tls Secret manifests in YAML or JSON.
+Keystores are decrypted with --password.
+
+Every certificate in the file is described, the leaf certificate first. Each certificate is checked
+with the certificates following it as intermediates. The CRL and OCSP checks are configured like those of the
+inspect secret command, e.g. with --offline and --crl-file.
+
+The exit code reports the health of the first certificate, like the exit code of the inspect secret command.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Query information about the certificates in a PEM bundle
+{{.BuildName}} inspect file tls.crt
+
+# Query information about the certificates in a keystore written by cert-manager
+{{.BuildName}} inspect file keystore.p12 --password changeit
+
+# Query information about the certificate in a Secret manifest from a backup
+kubectl get secret my-crt -o yaml | {{.BuildName}} inspect file -
+
+# Print every certificate in a DER encoded file as JSON
+{{.BuildName}} inspect file ca.der -o json
+`)),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Password, "password", o.Password, "Password used to decrypt PKCS#12 and JKS keystores.")
+	cmd.Flags().DurationVar(&o.ExpiringSoonThreshold, "expiring-soon-threshold", o.ExpiringSoonThreshold, "Remaining validity below which the certificate is reported as expiring soon by the exit code.")
+	o.Revocation.AddFlags(cmd)
+	o.PrintFlags.AddFlags(cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the path of the file has to be provided as argument, or '-' to read from stdin")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the path of the file")
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run executes inspect file command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	path := args[0]
+
+	revocation, err := o.Revocation.ToChecker()
+	if err != nil {
+		return err
+	}
+
+	data, err := secret.ReadFile(o.In, path)
+	if err != nil {
+		return cmcmdutil.SetFetchErrorExitCode(err)
+	}
+
+	bundle, err := secret.DecodeBundle(data, o.Password)
+	if err != nil {
+		return fmt.Errorf("error when decoding %q: %w", path, err)
+	}
+
+	leaf, err := pki.DecodeX509CertificateBytes(bundle.Certificates[0])
+	if err != nil {
+		return fmt.Errorf("error when parsing the first certificate of %q: %w", path, err)
+	}
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if err := o.printInspection(ctx, path, bundle, revocation); err != nil {
+			return err
+		}
+	} else if err := o.describeBundle(ctx, bundle, revocation); err != nil {
+		return err
+	}
+
+	cmcmdutil.SetExitCodeValue(secret.ExitCodeForCertificate(leaf, clock.Now(), o.ExpiringSoonThreshold))
+	return nil
+}
+
+// printInspection prints all certificates in bundle in the requested
+// structured output format.
+func (o *Options) printInspection(ctx context.Context, path string, bundle *secret.Bundle, revocation *secret.RevocationChecker) error {
+	certs, err := secret.InspectCertificates(ctx, bundle.Certificates, bundle.CA, revocation)
+	if err != nil {
+		return err
+	}
+
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+	return util.PrintObject(printer, &FileInspection{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       FileInspectionKind,
+		},
+		Path:         path,
+		Format:       bundle.Format,
+		Certificates: certs,
+	}, o.Out)
+}
+
+// describeBundle prints the human readable description of every certificate
+// in bundle.
+func (o *Options) describeBundle(ctx context.Context, bundle *secret.Bundle, revocation *secret.RevocationChecker) error {
+	fmt.Fprintf(o.Out, "Format: %s\n", bundle.Format)
+
+	for i, certData := range bundle.Certificates {
+		cert, err := pki.DecodeX509CertificateBytes(certData)
+		if err != nil {
+			return fmt.Errorf("error when parsing certificate %d: %w", i, err)
+		}
+
+		desc, err := secret.DescribeCertificate(ctx, cert, bundle.Certificates[i+1:], bundle.CA, revocation)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(o.Out, "\n--- Certificate %d of %d ---\n%s\n", i+1, len(bundle.Certificates), desc)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func selfSignedCertificatePEM(t *testing.T) []byte {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.CertificateTemplateFromCertificate(gen.Certificate("test",
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateNotBefore(metav1.Time{Time: time.Now().Add(-time.Hour)}),
+		gen.SetCertificateNotAfter(metav1.Time{Time: time.Now().Add(time.Hour)})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM
+}
+
+func TestRun(t *testing.T) {
+	certPEM := selfSignedCertificatePEM(t)
+	path := filepath.Join(t.TempDir(), "tls.crt")
+	if err := os.WriteFile(path, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		path         string
+		stdin        []byte
+		outputFormat string
+		expOutput    []string
+		expErr       string
+	}{
+		"PEM file": {
+			path:      path,
+			expOutput: []string{"Format: PEM\n", "--- Certificate 1 of 1 ---\n", "\t\t- example.com\n"},
+		},
+		"PEM from stdin as JSON": {
+			path:         "-",
+			stdin:        certPEM,
+			outputFormat: "json",
+			expOutput:    []string{`"kind": "FileInspection"`, `"path": "-"`, `"format": "PEM"`, `"example.com"`},
+		},
+		"Missing file": {
+			path:   filepath.Join(t.TempDir(), "missing.crt"),
+			expErr: "error when reading",
+		},
+		"Invalid data": {
+			path:   "-",
+			stdin:  []byte("not a certificate"),
+			expErr: `error when decoding "-": unrecognized format`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, in, out, _ := genericclioptions.NewTestIOStreams()
+			in.Write(test.stdin)
+
+			o := NewOptions(ioStreams)
+			*o.PrintFlags.OutputFormat = test.outputFormat
+			if err := o.Validate([]string{test.path}); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Run(t.Context(), []string{test.path})
+			if test.expErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), test.expErr)
+				}
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for _, expOutput := range test.expOutput {
+				assert.Contains(t, out.String(), expOutput)
+			}
+			if test.outputFormat == "json" {
+				assert.True(t, json.Valid(bytes.TrimSpace(out.Bytes())), "output is not valid JSON: %s", strings.TrimSpace(out.String()))
+			}
+		})
+	}
+}
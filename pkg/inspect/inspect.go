@@ -22,6 +22,10 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/crl"
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/diff"
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/endpoint"
+	"github.com/cert-manager/cmctl/v2/pkg/inspect/file"
 	"github.com/cert-manager/cmctl/v2/pkg/inspect/secret"
 )
 
@@ -29,10 +33,14 @@ func NewCmdInspect(setupCtx context.Context, ioStreams genericclioptions.IOStrea
 	cmds := &cobra.Command{
 		Use:   "inspect",
 		Short: "Get details on certificate related resources",
-		Long:  `Get details on certificate related resources, e.g. secrets`,
+		Long:  `Get details on certificate related resources, e.g. secrets, local files, TLS endpoints or CRLs`,
 	}
 
 	cmds.AddCommand(secret.NewCmdInspectSecret(setupCtx, ioStreams))
+	cmds.AddCommand(file.NewCmdInspectFile(setupCtx, ioStreams))
+	cmds.AddCommand(endpoint.NewCmdInspectEndpoint(setupCtx, ioStreams))
+	cmds.AddCommand(crl.NewCmdInspectCRL(setupCtx, ioStreams))
+	cmds.AddCommand(diff.NewCmdInspectDiff(setupCtx, ioStreams))
 
 	return cmds
 }
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	jks "github.com/pavlo-v-chernykh/keystore-go/v4"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Formats of the data decoded by DecodeBundle.
+const (
+	FormatPEM    = "PEM"
+	FormatDER    = "DER"
+	FormatPKCS12 = "PKCS#12"
+	FormatJKS    = "JKS"
+	FormatSecret = "Secret"
+)
+
+// jksMagic are the first bytes of every JKS keystore.
+const jksMagic = 0xFEEDFEED
+
+// Bundle is a set of certificates decoded from a file.
+type Bundle struct {
+	// Format the certificates were decoded from
+	Format string
+	// Certificates are the PEM encoded certificates of the bundle. If the
+	// bundle contains a certificate chain, the leaf certificate is first.
+	Certificates [][]byte
+	// CA are the PEM encoded CA certificates, e.g. 'ca.crt' of a Secret or the
+	// trusted certificates of a keystore that also contains a chain
+	CA []byte
+	// PrivateKey is the PEM encoded private key of the leaf certificate, only
+	// set for keystores
+	PrivateKey []byte
+}
+
+// DecodeBundle decodes the certificates in data, which is a PEM bundle, DER
+// encoded certificates, a PKCS#12 or JKS keystore, or a kubernetes.io/tls
+// Secret manifest in YAML or JSON. password is used to decrypt keystores.
+func DecodeBundle(data []byte, password string) (*Bundle, error) {
+	if len(data) >= 4 && binary.BigEndian.Uint32(data) == jksMagic {
+		return decodeJKS(data, password)
+	}
+
+	if bundle, ok, err := decodeSecretManifest(data); ok {
+		return bundle, err
+	}
+
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		certs, err := splitPEMs(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(certs) < 1 {
+			return nil, errors.New("no certificate found in PEM data")
+		}
+		return &Bundle{Format: FormatPEM, Certificates: certs}, nil
+	}
+
+	if certs, err := x509.ParseCertificates(data); err == nil && len(certs) > 0 {
+		return &Bundle{Format: FormatDER, Certificates: encodeCertificates(certs)}, nil
+	}
+
+	return decodePKCS12(data, password)
+}
+
+// decodeSecretManifest decodes the certificates in the Secret manifest data.
+// It returns false if data is not a Secret manifest.
+func decodeSecretManifest(data []byte) (*Bundle, bool, error) {
+	var secret corev1.Secret
+	if err := yaml.Unmarshal(data, &secret); err != nil || secret.Kind != "Secret" {
+		return nil, false, nil
+	}
+
+	secretData := map[string][]byte{}
+	for key, value := range secret.Data {
+		secretData[key] = value
+	}
+	// stringData takes precedence, like on the API server
+	for key, value := range secret.StringData {
+		secretData[key] = []byte(value)
+	}
+
+	certs, err := splitPEMs(secretData[corev1.TLSCertKey])
+	if err != nil {
+		return nil, true, err
+	}
+	if len(certs) < 1 {
+		return nil, true, fmt.Errorf("no PEM data found in 'tls.crt' of Secret %q", secret.Name)
+	}
+
+	return &Bundle{Format: FormatSecret, Certificates: certs, CA: secretData[cmmeta.TLSCAKey]}, true, nil
+}
+
+// decodePKCS12 decodes the certificates in the PKCS#12 keystore or truststore
+// data.
+func decodePKCS12(data []byte, password string) (*Bundle, error) {
+	key, cert, caCerts, err := pkcs12.DecodeChain(data, password)
+	if err == nil {
+		keyPEM, err := pki.EncodePKCS8PrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("error when encoding the private key of PKCS#12 keystore: %w", err)
+		}
+		return &Bundle{
+			Format:       FormatPKCS12,
+			Certificates: encodeCertificates(append([]*x509.Certificate{cert}, caCerts...)),
+			PrivateKey:   keyPEM,
+		}, nil
+	}
+	if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+		return nil, fmt.Errorf("error when decoding PKCS#12 keystore, check the password: %w", err)
+	}
+
+	// Truststores contain certificates without a private key
+	certs, trustStoreErr := pkcs12.DecodeTrustStore(data, password)
+	if trustStoreErr == nil && len(certs) > 0 {
+		return &Bundle{Format: FormatPKCS12, Certificates: encodeCertificates(certs)}, nil
+	}
+
+	return nil, fmt.Errorf("unrecognized format, expected PEM, DER, PKCS#12, JKS or a Secret manifest: decoding as PKCS#12 failed: %w", err)
+}
+
+// decodeJKS decodes the certificates in the JKS keystore or truststore data.
+// The chain and key of the first private key entry are returned as the
+// certificates and private key, and the trusted certificate entries as the
+// CA. If the keystore has no private key entry, the trusted certificates are
+// returned as the certificates.
+func decodeJKS(data []byte, password string) (*Bundle, error) {
+	ks := jks.New(jks.WithOrderedAliases())
+	if err := ks.Load(bytes.NewReader(data), []byte(password)); err != nil {
+		return nil, fmt.Errorf("error when decoding JKS keystore, check the password: %w", err)
+	}
+
+	var chain, trusted [][]byte
+	var keyPEM []byte
+	for _, alias := range ks.Aliases() {
+		switch {
+		case ks.IsPrivateKeyEntry(alias) && chain == nil:
+			entry, err := ks.GetPrivateKeyEntry(alias, []byte(password))
+			if err != nil {
+				return nil, fmt.Errorf("error when reading entry %q of JKS keystore: %w", alias, err)
+			}
+			for _, cert := range entry.CertificateChain {
+				chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Content}))
+			}
+			keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: entry.PrivateKey})
+		case ks.IsTrustedCertificateEntry(alias):
+			entry, err := ks.GetTrustedCertificateEntry(alias)
+			if err != nil {
+				return nil, fmt.Errorf("error when reading entry %q of JKS keystore: %w", alias, err)
+			}
+			trusted = append(trusted, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: entry.Certificate.Content}))
+		}
+	}
+
+	switch {
+	case len(chain) > 0:
+		return &Bundle{Format: FormatJKS, Certificates: chain, CA: bytes.Join(trusted, nil), PrivateKey: keyPEM}, nil
+	case len(trusted) > 0:
+		return &Bundle{Format: FormatJKS, Certificates: trusted}, nil
+	default:
+		return nil, errors.New("no certificate found in JKS keystore")
+	}
+}
+
+func encodeCertificates(certs []*x509.Certificate) [][]byte {
+	encoded := make([][]byte, 0, len(certs))
+	for _, cert := range certs {
+		encoded = append(encoded, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+	return encoded
+}
@@ -0,0 +1,407 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const extensionsTemplate = `Extensions:
+	Subject:	{{ .Subject }}
+	Basic Constraints:	{{ .BasicConstraints }}
+	Name Constraints:	{{ .NameConstraints }}
+	Certificate Policies:	{{ .Policies }}
+	Subject Key ID:	{{ .SubjectKeyID }}
+	Authority Key ID:	{{ .AuthorityKeyID }}
+	CA Issuers:	{{ .CAIssuers }}
+	Other Names:	{{ .OtherNames }}
+	Signed Certificate Timestamps:	{{ .SCTs }}
+	Unknown Extensions:	{{ .UnknownExtensions }}`
+
+var (
+	oidExtensionSubjectKeyID          = asn1.ObjectIdentifier{2, 5, 29, 14}
+	oidExtensionKeyUsage              = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionSubjectAltName        = asn1.ObjectIdentifier{2, 5, 29, 17}
+	oidExtensionBasicConstraints      = asn1.ObjectIdentifier{2, 5, 29, 19}
+	oidExtensionNameConstraints       = asn1.ObjectIdentifier{2, 5, 29, 30}
+	oidExtensionCRLDistributionPoints = asn1.ObjectIdentifier{2, 5, 29, 31}
+	oidExtensionCertificatePolicies   = asn1.ObjectIdentifier{2, 5, 29, 32}
+	oidExtensionAuthorityKeyID        = asn1.ObjectIdentifier{2, 5, 29, 35}
+	oidExtensionExtendedKeyUsage      = asn1.ObjectIdentifier{2, 5, 29, 37}
+	oidExtensionAuthorityInfoAccess   = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 1}
+	// oidExtensionSCTList is the extension with the signed certificate
+	// timestamps of Certificate Transparency logs, see RFC 6962 section 3.3
+	oidExtensionSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+)
+
+// knownExtensions are the extensions that are shown by the human readable
+// output or the extension dump, all others are listed as unknown.
+var knownExtensions = []asn1.ObjectIdentifier{
+	oidExtensionSubjectKeyID,
+	oidExtensionKeyUsage,
+	oidExtensionSubjectAltName,
+	oidExtensionBasicConstraints,
+	oidExtensionNameConstraints,
+	oidExtensionCRLDistributionPoints,
+	oidExtensionCertificatePolicies,
+	oidExtensionAuthorityKeyID,
+	oidExtensionExtendedKeyUsage,
+	oidExtensionAuthorityInfoAccess,
+	oidExtensionSCTList,
+}
+
+// CertificateExtensions are the parts of a certificate that are only shown
+// with --verbose.
+type CertificateExtensions struct {
+	// Subject is the full subject of the certificate, in RFC 2253 format
+	Subject string `json:"subject"`
+	// BasicConstraints is nil if the certificate has no basic constraints
+	// extension
+	BasicConstraints *BasicConstraints `json:"basicConstraints,omitempty"`
+	// NameConstraints is nil if the certificate has no name constraints
+	// extension
+	NameConstraints *NameConstraints `json:"nameConstraints,omitempty"`
+	// Policies are the OIDs of the certificate policies
+	Policies []string `json:"policies,omitempty"`
+	// SubjectKeyID and AuthorityKeyID are hex encoded, colon separated
+	SubjectKeyID   string `json:"subjectKeyID,omitempty"`
+	AuthorityKeyID string `json:"authorityKeyID,omitempty"`
+	// CAIssuers are the URLs of the issuer certificate from the authority
+	// information access extension
+	CAIssuers []string `json:"caIssuers,omitempty"`
+	// OtherNames are the otherName SANs, which are not shown with the other
+	// SANs
+	OtherNames []OtherName `json:"otherNames,omitempty"`
+	// SCTs are the signed certificate timestamps embedded by Certificate
+	// Transparency logs
+	SCTs []SignedCertificateTimestamp `json:"scts,omitempty"`
+	// UnknownExtensions are the extensions that are not described otherwise
+	UnknownExtensions []UnknownExtension `json:"unknownExtensions,omitempty"`
+}
+
+// BasicConstraints is the basic constraints extension of a certificate.
+type BasicConstraints struct {
+	IsCA bool `json:"isCA"`
+	// MaxPathLen is nil if the path length is not constrained
+	MaxPathLen *int `json:"maxPathLen,omitempty"`
+}
+
+// NameConstraints is the name constraints extension of a CA certificate.
+type NameConstraints struct {
+	Critical                bool     `json:"critical"`
+	PermittedDNSDomains     []string `json:"permittedDNSDomains,omitempty"`
+	ExcludedDNSDomains      []string `json:"excludedDNSDomains,omitempty"`
+	PermittedIPRanges       []string `json:"permittedIPRanges,omitempty"`
+	ExcludedIPRanges        []string `json:"excludedIPRanges,omitempty"`
+	PermittedEmailAddresses []string `json:"permittedEmailAddresses,omitempty"`
+	ExcludedEmailAddresses  []string `json:"excludedEmailAddresses,omitempty"`
+	PermittedURIDomains     []string `json:"permittedURIDomains,omitempty"`
+	ExcludedURIDomains      []string `json:"excludedURIDomains,omitempty"`
+}
+
+// OtherName is an otherName SAN of a certificate.
+type OtherName struct {
+	OID string `json:"oid"`
+	// Value is the string value, or the hex encoded DER value if it is not a
+	// string
+	Value string `json:"value"`
+}
+
+// SignedCertificateTimestamp is a signed certificate timestamp of a
+// Certificate Transparency log embedded in a certificate.
+type SignedCertificateTimestamp struct {
+	Version int `json:"version"`
+	// LogID is the base64 encoded ID of the log
+	LogID     string      `json:"logID"`
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// UnknownExtension is an extension of a certificate that is not described
+// otherwise.
+type UnknownExtension struct {
+	OID      string `json:"oid"`
+	Critical bool   `json:"critical"`
+	// Value is the hex encoded DER value of the extension
+	Value string `json:"value"`
+}
+
+// extensionsOf returns the extensions of cert.
+func extensionsOf(cert *x509.Certificate) (*CertificateExtensions, error) {
+	extensions := &CertificateExtensions{
+		Subject:        cert.Subject.String(),
+		SubjectKeyID:   formatFingerprint(cert.SubjectKeyId),
+		AuthorityKeyID: formatFingerprint(cert.AuthorityKeyId),
+		CAIssuers:      cert.IssuingCertificateURL,
+	}
+
+	if cert.BasicConstraintsValid {
+		extensions.BasicConstraints = &BasicConstraints{IsCA: cert.IsCA}
+		if cert.MaxPathLen > 0 || cert.MaxPathLenZero {
+			maxPathLen := cert.MaxPathLen
+			extensions.BasicConstraints.MaxPathLen = &maxPathLen
+		}
+	}
+
+	for _, policy := range cert.Policies {
+		extensions.Policies = append(extensions.Policies, policy.String())
+	}
+
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidExtensionNameConstraints):
+			extensions.NameConstraints = nameConstraintsOf(cert, ext.Critical)
+		case ext.Id.Equal(oidExtensionSubjectAltName):
+			otherNames, err := parseOtherNames(ext.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error when parsing the subject alternative names: %w", err)
+			}
+			extensions.OtherNames = otherNames
+		case ext.Id.Equal(oidExtensionSCTList):
+			scts, err := parseSCTList(ext.Value)
+			if err != nil {
+				return nil, fmt.Errorf("error when parsing the signed certificate timestamps: %w", err)
+			}
+			extensions.SCTs = scts
+		case !isKnownExtension(ext.Id):
+			extensions.UnknownExtensions = append(extensions.UnknownExtensions, UnknownExtension{
+				OID:      ext.Id.String(),
+				Critical: ext.Critical,
+				Value:    hex.EncodeToString(ext.Value),
+			})
+		}
+	}
+
+	return extensions, nil
+}
+
+func isKnownExtension(oid asn1.ObjectIdentifier) bool {
+	for _, known := range knownExtensions {
+		if oid.Equal(known) {
+			return true
+		}
+	}
+	return false
+}
+
+func nameConstraintsOf(cert *x509.Certificate, critical bool) *NameConstraints {
+	ipRanges := func(in []*net.IPNet) []string {
+		var out []string
+		for _, ipNet := range in {
+			out = append(out, ipNet.String())
+		}
+		return out
+	}
+
+	return &NameConstraints{
+		Critical:                critical,
+		PermittedDNSDomains:     cert.PermittedDNSDomains,
+		ExcludedDNSDomains:      cert.ExcludedDNSDomains,
+		PermittedIPRanges:       ipRanges(cert.PermittedIPRanges),
+		ExcludedIPRanges:        ipRanges(cert.ExcludedIPRanges),
+		PermittedEmailAddresses: cert.PermittedEmailAddresses,
+		ExcludedEmailAddresses:  cert.ExcludedEmailAddresses,
+		PermittedURIDomains:     cert.PermittedURIDomains,
+		ExcludedURIDomains:      cert.ExcludedURIDomains,
+	}
+}
+
+// parseOtherNames returns the otherName SANs in the DER encoded subject
+// alternative name extension value.
+func parseOtherNames(value []byte) ([]OtherName, error) {
+	generalNames, err := pki.UnmarshalSANs(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var otherNames []OtherName
+	for _, otherName := range generalNames.OtherNames {
+		// The value is still wrapped in its explicit context specific tag
+		var inner asn1.RawValue
+		if _, err := asn1.Unmarshal(otherName.Value.Bytes, &inner); err != nil {
+			return nil, err
+		}
+
+		value := hex.EncodeToString(inner.FullBytes)
+		if uv, err := pki.UnmarshalUniversalValue(inner); err == nil {
+			switch uv.Type() {
+			case pki.UniversalValueTypeUTF8String:
+				value = uv.UTF8String
+			case pki.UniversalValueTypeIA5String:
+				value = uv.IA5String
+			case pki.UniversalValueTypePrintableString:
+				value = uv.PrintableString
+			}
+		}
+
+		otherNames = append(otherNames, OtherName{OID: otherName.TypeID.String(), Value: value})
+	}
+	return otherNames, nil
+}
+
+// parseSCTList returns the signed certificate timestamps in the DER encoded
+// SCT list extension value. The list is a TLS encoded
+// SignedCertificateTimestampList wrapped in an OCTET STRING, see RFC 6962
+// section 3.3.
+func parseSCTList(value []byte) ([]SignedCertificateTimestamp, error) {
+	var list []byte
+	if _, err := asn1.Unmarshal(value, &list); err != nil {
+		return nil, err
+	}
+
+	// readVector reads a TLS vector with a 2 byte length prefix
+	readVector := func(data []byte) ([]byte, []byte, error) {
+		if len(data) < 2 {
+			return nil, nil, errors.New("truncated data")
+		}
+		length := int(binary.BigEndian.Uint16(data))
+		if len(data) < 2+length {
+			return nil, nil, errors.New("truncated data")
+		}
+		return data[2 : 2+length], data[2+length:], nil
+	}
+
+	sctList, rest, err := readVector(list)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("trailing data")
+	}
+
+	var scts []SignedCertificateTimestamp
+	for len(sctList) > 0 {
+		var sct []byte
+		if sct, sctList, err = readVector(sctList); err != nil {
+			return nil, err
+		}
+		// version (1 byte), log ID (32 bytes), timestamp (8 bytes)
+		if len(sct) < 41 {
+			return nil, errors.New("truncated signed certificate timestamp")
+		}
+		// The timestamp is in milliseconds since the epoch
+		timestamp := int64(binary.BigEndian.Uint64(sct[33:41])) // #nosec G115 -- timestamps fit in an int64 until the year 292278994
+		scts = append(scts, SignedCertificateTimestamp{
+			Version:   int(sct[0]) + 1,
+			LogID:     base64.StdEncoding.EncodeToString(sct[1:33]),
+			Timestamp: metav1.Time{Time: time.UnixMilli(timestamp).UTC()},
+		})
+	}
+	return scts, nil
+}
+
+func describeExtensions(cert *x509.Certificate) (string, error) {
+	extensions, err := extensionsOf(cert)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("extensionsTemplate").Parse(extensionsTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	basicConstraints := "<none>"
+	if bc := extensions.BasicConstraints; bc != nil {
+		maxPathLen := "unlimited"
+		if bc.MaxPathLen != nil {
+			maxPathLen = strconv.Itoa(*bc.MaxPathLen)
+		}
+		basicConstraints = fmt.Sprintf("CA: %t, Max Path Length: %s", bc.IsCA, maxPathLen)
+	}
+
+	var nameConstraints []string
+	if nc := extensions.NameConstraints; nc != nil {
+		nameConstraints = append(nameConstraints, fmt.Sprintf("Critical: %t", nc.Critical))
+		for _, constraint := range []struct {
+			kind   string
+			values []string
+		}{
+			{"Permitted DNS Domain", nc.PermittedDNSDomains},
+			{"Excluded DNS Domain", nc.ExcludedDNSDomains},
+			{"Permitted IP Range", nc.PermittedIPRanges},
+			{"Excluded IP Range", nc.ExcludedIPRanges},
+			{"Permitted Email Address", nc.PermittedEmailAddresses},
+			{"Excluded Email Address", nc.ExcludedEmailAddresses},
+			{"Permitted URI Domain", nc.PermittedURIDomains},
+			{"Excluded URI Domain", nc.ExcludedURIDomains},
+		} {
+			for _, value := range constraint.values {
+				nameConstraints = append(nameConstraints, constraint.kind+": "+value)
+			}
+		}
+	}
+
+	var otherNames []string
+	for _, otherName := range extensions.OtherNames {
+		otherNames = append(otherNames, otherName.OID+": "+otherName.Value)
+	}
+
+	var scts []string
+	for _, sct := range extensions.SCTs {
+		scts = append(scts, fmt.Sprintf("v%d, Log ID %s, %s", sct.Version, sct.LogID, sct.Timestamp.Format(time.RFC1123)))
+	}
+
+	var unknownExtensions []string
+	for _, ext := range extensions.UnknownExtensions {
+		critical := ""
+		if ext.Critical {
+			critical = " (critical)"
+		}
+		unknownExtensions = append(unknownExtensions, fmt.Sprintf("%s%s: %s", ext.OID, critical, ext.Value))
+	}
+
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, struct {
+		Subject           string
+		BasicConstraints  string
+		NameConstraints   string
+		Policies          string
+		SubjectKeyID      string
+		AuthorityKeyID    string
+		CAIssuers         string
+		OtherNames        string
+		SCTs              string
+		UnknownExtensions string
+	}{
+		Subject:           printOrNone(extensions.Subject),
+		BasicConstraints:  basicConstraints,
+		NameConstraints:   printSlice(nameConstraints),
+		Policies:          printSliceOrOne(extensions.Policies),
+		SubjectKeyID:      printOrNone(extensions.SubjectKeyID),
+		AuthorityKeyID:    printOrNone(extensions.AuthorityKeyID),
+		CAIssuers:         printSliceOrOne(extensions.CAIssuers),
+		OtherNames:        printSlice(otherNames),
+		SCTs:              printSlice(scts),
+		UnknownExtensions: printSlice(unknownExtensions),
+	})
+
+	return b.String(), err
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sctListExtension returns the SCT list extension value with one SCT of the
+// log logID at timestamp.
+func sctListExtension(t *testing.T, logID []byte, timestamp time.Time) []byte {
+	var sct bytes.Buffer
+	sct.WriteByte(0) // v1
+	sct.Write(logID)
+	_ = binary.Write(&sct, binary.BigEndian, uint64(timestamp.UnixMilli()))
+	sct.Write([]byte{0, 0})                   // no extensions
+	sct.Write([]byte{4, 3, 0, 2, 0xAB, 0xCD}) // ECDSA SHA-256 signature
+
+	var list bytes.Buffer
+	_ = binary.Write(&list, binary.BigEndian, uint16(2+sct.Len()))
+	_ = binary.Write(&list, binary.BigEndian, uint16(sct.Len()))
+	list.Write(sct.Bytes())
+
+	value, err := asn1.Marshal(list.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestExtensionsOf(t *testing.T) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logID := bytes.Repeat([]byte{1}, 32)
+	timestamp := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	otherNameValue, err := asn1.MarshalWithParams("user@example.com", "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sans, err := pki.MarshalSANs(pki.GeneralNames{
+		DNSNames: []string{"example.com"},
+		OtherNames: []pki.OtherName{{
+			TypeID: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3},
+			Value:  asn1.RawValue{Tag: 0, Class: asn1.ClassContextSpecific, IsCompound: true, Bytes: otherNameValue},
+		}},
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, permittedIPRange, _ := net.ParseCIDR("10.0.0.0/8")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName:    "intermediate",
+			Province:      []string{"Berlin"},
+			Locality:      []string{"Berlin"},
+			StreetAddress: []string{"Main Street 1"},
+			SerialNumber:  "1234",
+		},
+		NotBefore:                   time.Now().Add(-time.Hour),
+		NotAfter:                    time.Now().Add(time.Hour),
+		BasicConstraintsValid:       true,
+		IsCA:                        true,
+		MaxPathLenZero:              true,
+		PermittedDNSDomains:         []string{"example.com"},
+		ExcludedDNSDomains:          []string{"internal.example.com"},
+		PermittedIPRanges:           []*net.IPNet{permittedIPRange},
+		PermittedDNSDomainsCritical: true,
+		Policies:                    []x509.OID{mustOID(t, "2.23.140.1.2.1")},
+		SubjectKeyId:                []byte{1, 2, 3},
+		AuthorityKeyId:              []byte{4, 5, 6},
+		IssuingCertificateURL:       []string{"http://ca.example.com/ca.crt"},
+		ExtraExtensions: []pkix.Extension{
+			sans,
+			{Id: oidExtensionSCTList, Value: sctListExtension(t, logID, timestamp)},
+			{Id: asn1.ObjectIdentifier{1, 2, 3, 4}, Critical: false, Value: []byte{0x05, 0x00}},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extensions, err := extensionsOf(cert)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	maxPathLen := 0
+	assert.Equal(t, &CertificateExtensions{
+		Subject:          "SERIALNUMBER=1234,CN=intermediate,STREET=Main Street 1,L=Berlin,ST=Berlin",
+		BasicConstraints: &BasicConstraints{IsCA: true, MaxPathLen: &maxPathLen},
+		NameConstraints: &NameConstraints{
+			Critical:            true,
+			PermittedDNSDomains: []string{"example.com"},
+			ExcludedDNSDomains:  []string{"internal.example.com"},
+			PermittedIPRanges:   []string{"10.0.0.0/8"},
+		},
+		Policies:       []string{"2.23.140.1.2.1"},
+		SubjectKeyID:   "01:02:03",
+		AuthorityKeyID: "04:05:06",
+		CAIssuers:      []string{"http://ca.example.com/ca.crt"},
+		OtherNames:     []OtherName{{OID: "1.3.6.1.4.1.311.20.2.3", Value: "user@example.com"}},
+		SCTs: []SignedCertificateTimestamp{{
+			Version:   1,
+			LogID:     base64.StdEncoding.EncodeToString(logID),
+			Timestamp: metav1.Time{Time: timestamp},
+		}},
+		UnknownExtensions: []UnknownExtension{{OID: "1.2.3.4", Value: "0500"}},
+	}, extensions)
+
+	desc, err := describeExtensions(cert)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, expLine := range []string{
+		"\tBasic Constraints:\tCA: true, Max Path Length: 0\n",
+		"\t\t- Critical: true\n\t\t- Permitted DNS Domain: example.com\n\t\t- Excluded DNS Domain: internal.example.com\n\t\t- Permitted IP Range: 10.0.0.0/8\n",
+		"\tCertificate Policies:\t2.23.140.1.2.1\n",
+		"\tOther Names:\t\n\t\t- 1.3.6.1.4.1.311.20.2.3: user@example.com\n",
+		"\t\t- v1, Log ID " + base64.StdEncoding.EncodeToString(logID) + ", Fri, 02 Jan 2026 03:04:05 UTC\n",
+		"\tUnknown Extensions:\t\n\t\t- 1.2.3.4: 0500",
+	} {
+		assert.Contains(t, desc, expLine)
+	}
+}
+
+func TestExtensionsOfMinimalCertificate(t *testing.T) {
+	extensions, err := extensionsOf(MustParseCertificate(t, testCert))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Nil(t, extensions.NameConstraints)
+	assert.Empty(t, extensions.SCTs)
+	assert.Empty(t, extensions.UnknownExtensions)
+
+	desc, err := describeExtensions(MustParseCertificate(t, testCert))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Contains(t, desc, "\tName Constraints:\t<none>\n")
+	assert.Contains(t, desc, "\tSigned Certificate Timestamps:\t<none>\n")
+}
+
+func mustOID(t *testing.T, s string) x509.OID {
+	oid, err := x509.ParseOID(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return oid
+}
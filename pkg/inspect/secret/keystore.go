@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// additionalOutputKeys are the keys of the keystores and additional output
+// formats cert-manager writes into the Secret of a Certificate, in the order
+// they are verified.
+var additionalOutputKeys = []string{
+	cmapi.PKCS12SecretKey,
+	cmapi.PKCS12TruststoreKey,
+	cmapi.JKSSecretKey,
+	cmapi.JKSTruststoreKey,
+	cmapi.CertificateOutputFormatCombinedPEMKey,
+	cmapi.CertificateOutputFormatDERKey,
+}
+
+// verifyAdditionalOutputs checks that the keystores and additional output
+// formats in secret contain the same certificates and private key as the
+// certificate, private key and CA keys of the Secret. chain is the parsed
+// certificate chain of the Secret, leaf certificate first. Keys that are not
+// present in the Secret are not checked.
+func (o *Options) verifyAdditionalOutputs(ctx context.Context, secret *corev1.Secret, chain []*x509.Certificate) []VerificationCheck {
+	var checks []VerificationCheck
+	for _, key := range additionalOutputKeys {
+		data, ok := secret.Data[key]
+		if !ok {
+			continue
+		}
+
+		matches := o.CertKey + " and " + o.KeyKey
+		switch key {
+		case cmapi.PKCS12TruststoreKey, cmapi.JKSTruststoreKey:
+			matches = cmmeta.TLSCAKey
+		case cmapi.CertificateOutputFormatDERKey:
+			matches = o.KeyKey
+		}
+
+		status, message := o.verifyAdditionalOutput(ctx, secret, key, data, chain)
+		checks = append(checks, VerificationCheck{
+			Name:    fmt.Sprintf("%s matches %s", key, matches),
+			Status:  status,
+			Message: message,
+		})
+	}
+	return checks
+}
+
+// verifyAdditionalOutput checks the content of the Secret key named key.
+func (o *Options) verifyAdditionalOutput(ctx context.Context, secret *corev1.Secret, key string, data []byte, chain []*x509.Certificate) (CheckStatus, string) {
+	caCerts, err := pki.DecodeX509CertificateSetBytes(secret.Data[cmmeta.TLSCAKey])
+	if err != nil && len(secret.Data[cmmeta.TLSCAKey]) > 0 {
+		return CheckSkipped, fmt.Sprintf("cannot decode %s: %s", cmmeta.TLSCAKey, err)
+	}
+
+	var problems []string
+	switch key {
+	case cmapi.PKCS12SecretKey, cmapi.PKCS12TruststoreKey, cmapi.JKSSecretKey, cmapi.JKSTruststoreKey:
+		format := FormatPKCS12
+		if key == cmapi.JKSSecretKey || key == cmapi.JKSTruststoreKey {
+			format = FormatJKS
+		}
+		password, err := o.keystorePassword(ctx, secret, format)
+		if err != nil {
+			return CheckSkipped, err.Error()
+		}
+
+		var bundle *Bundle
+		if format == FormatJKS {
+			bundle, err = decodeJKS(data, password)
+		} else {
+			bundle, err = decodePKCS12(data, password)
+		}
+		if err != nil {
+			return CheckFailed, err.Error()
+		}
+		certs, err := pki.DecodeX509CertificateSetBytes(append(bytes.Join(bundle.Certificates, nil), bundle.CA...))
+		if err != nil {
+			return CheckFailed, err.Error()
+		}
+
+		if key == cmapi.PKCS12TruststoreKey || key == cmapi.JKSTruststoreKey {
+			problems = compareCertificateSets(certs, caCerts, cmmeta.TLSCAKey)
+			break
+		}
+
+		problems = compareCertificateSets(certs, append(append([]*x509.Certificate{}, chain...), caCerts...), o.CertKey+" and "+cmmeta.TLSCAKey)
+		if len(bundle.PrivateKey) == 0 {
+			problems = append(problems, "the keystore has no private key")
+			break
+		}
+		if !bytes.Equal(certs[0].Raw, chain[0].Raw) {
+			problems = append(problems, fmt.Sprintf("the certificate of the private key entry (%s) is not the leaf certificate of %s", describeName(certs[0]), o.CertKey))
+		}
+		privateKey, err := pki.DecodePrivateKeyBytes(bundle.PrivateKey)
+		if err != nil {
+			return CheckFailed, fmt.Sprintf("cannot decode the private key of the keystore: %s", err)
+		}
+		problems = append(problems, o.keyProblems(privateKey, chain[0])...)
+	case cmapi.CertificateOutputFormatCombinedPEMKey:
+		privateKey, err := pki.DecodePrivateKeyBytes(data)
+		if err != nil {
+			return CheckFailed, fmt.Sprintf("cannot decode the private key: %s", err)
+		}
+		certPEMs, err := splitPEMs(data)
+		if err != nil {
+			return CheckFailed, err.Error()
+		}
+		certs, err := pki.DecodeX509CertificateSetBytes(bytes.Join(certPEMs, nil))
+		if err != nil {
+			return CheckFailed, fmt.Sprintf("cannot decode the certificates: %s", err)
+		}
+		if !sameCertificates(certs, chain) {
+			problems = append(problems, fmt.Sprintf("the certificates are not the certificates of %s", o.CertKey))
+		}
+		problems = append(problems, o.keyProblems(privateKey, chain[0])...)
+	case cmapi.CertificateOutputFormatDERKey:
+		privateKey, err := parseDERPrivateKey(data)
+		if err != nil {
+			return CheckFailed, err.Error()
+		}
+		problems = o.keyProblems(privateKey, chain[0])
+	}
+
+	if len(problems) > 0 {
+		return CheckFailed, strings.Join(problems, "; ")
+	}
+	return CheckPassed, ""
+}
+
+// keystorePassword returns the password of the keystores in format in secret:
+// the --keystore-password flag if it is set, otherwise the password configured
+// in the keystores of the Certificate the Secret belongs to.
+func (o *Options) keystorePassword(ctx context.Context, secret *corev1.Secret, format string) (string, error) {
+	if o.KeystorePassword != "" {
+		return o.KeystorePassword, nil
+	}
+
+	crtName := secret.Annotations[cmapi.CertificateNameKey]
+	if crtName == "" {
+		return "", errors.New("the Secret does not belong to a Certificate, use --keystore-password to set the password")
+	}
+	crt, err := o.CMClient.CertmanagerV1().Certificates(secret.Namespace).Get(ctx, crtName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot get Certificate %q to find the password, use --keystore-password to set it: %s", crtName, err)
+	}
+
+	var password *string
+	var passwordRef cmmeta.SecretKeySelector
+	switch keystores := crt.Spec.Keystores; {
+	case keystores != nil && format == FormatJKS && keystores.JKS != nil:
+		password, passwordRef = keystores.JKS.Password, keystores.JKS.PasswordSecretRef
+	case keystores != nil && format == FormatPKCS12 && keystores.PKCS12 != nil:
+		password, passwordRef = keystores.PKCS12.Password, keystores.PKCS12.PasswordSecretRef
+	default:
+		return "", fmt.Errorf("Certificate %q has no %s keystore configured, use --keystore-password to set the password", crtName, format)
+	}
+	if password != nil {
+		return *password, nil
+	}
+
+	passwordSecret, err := o.KubeClient.CoreV1().Secrets(secret.Namespace).Get(ctx, passwordRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot get the password Secret %q, use --keystore-password to set the password: %s", passwordRef.Name, err)
+	}
+	value, ok := passwordSecret.Data[passwordRef.Key]
+	if !ok {
+		return "", fmt.Errorf("no key %q in the password Secret %q, use --keystore-password to set the password", passwordRef.Key, passwordRef.Name)
+	}
+	return string(value), nil
+}
+
+// keyProblems returns why key is not the private key of leaf, if it is not.
+func (o *Options) keyProblems(key crypto.Signer, leaf *x509.Certificate) []string {
+	matches, err := pki.PublicKeyMatchesCertificate(key.Public(), leaf)
+	switch {
+	case err != nil:
+		return []string{err.Error()}
+	case !matches:
+		return []string{fmt.Sprintf("the private key is not the private key of the leaf certificate of %s", o.CertKey)}
+	default:
+		return nil
+	}
+}
+
+// compareCertificateSets returns the certificates that are only in got or only
+// in expected, ignoring order and duplicates. source names where the expected
+// certificates come from.
+func compareCertificateSets(got, expected []*x509.Certificate, source string) []string {
+	contains := func(certs []*x509.Certificate, cert *x509.Certificate) bool {
+		for _, c := range certs {
+			if bytes.Equal(c.Raw, cert.Raw) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var problems []string
+	for _, cert := range expected {
+		if !contains(got, cert) {
+			problems = append(problems, fmt.Sprintf("missing certificate %s of %s", describeName(cert), source))
+		}
+	}
+	for _, cert := range got {
+		if !contains(expected, cert) {
+			problems = append(problems, fmt.Sprintf("certificate %s is not in %s", describeName(cert), source))
+		}
+	}
+	return problems
+}
+
+// sameCertificates returns true if a and b contain the same certificates in
+// the same order.
+func sameCertificates(a, b []*x509.Certificate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i].Raw, b[i].Raw) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDERPrivateKey parses a DER encoded PKCS#8, PKCS#1 or SEC 1 private key.
+func parseDERPrivateKey(data []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(data); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(data); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(data); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("cannot decode the DER encoded private key as PKCS#8, PKCS#1 or SEC 1")
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	jks "github.com/pavlo-v-chernykh/keystore-go/v4"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+func TestVerifyAdditionalOutputs(t *testing.T) {
+	const namespace = "testns"
+
+	root := newTestKeyPair(t, "root", true, nil)
+	leaf := newTestKeyPair(t, "leaf", false, root)
+	otherLeaf := newTestKeyPair(t, "other-leaf", false, root)
+
+	keystoreP12 := func(pair *testKeyPair) []byte {
+		data, err := pkcs12.Modern.Encode(pair.key, pair.cert, []*x509.Certificate{root.cert}, "changeit")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	truststoreP12, err := pkcs12.Modern.EncodeTrustStore([]*x509.Certificate{root.cert}, "changeit")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER := func(pair *testKeyPair) []byte {
+		block, _ := pem.Decode(pair.keyPEM)
+		return block.Bytes
+	}
+	ks := jks.New()
+	if err := ks.SetPrivateKeyEntry("certificate", jks.PrivateKeyEntry{
+		CreationTime:     time.Now(),
+		PrivateKey:       keyDER(leaf),
+		CertificateChain: []jks.Certificate{{Type: "X509", Content: leaf.cert.Raw}},
+	}, []byte("changeit")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.SetTrustedCertificateEntry("ca", jks.TrustedCertificateEntry{
+		CreationTime: time.Now(),
+		Certificate:  jks.Certificate{Type: "X509", Content: root.cert.Raw},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var keystoreJKS bytes.Buffer
+	if err := ks.Store(&keystoreJKS, []byte("changeit")); err != nil {
+		t.Fatal(err)
+	}
+
+	password := "changeit"
+	crtWithPKCS12Password := gen.Certificate("my-crt",
+		gen.SetCertificateNamespace(namespace),
+		gen.SetCertificateKeystore(&cmapi.CertificateKeystores{
+			PKCS12: &cmapi.PKCS12Keystore{Create: true, Password: &password},
+		}))
+	crtWithJKSPasswordRef := gen.Certificate("my-crt",
+		gen.SetCertificateNamespace(namespace),
+		gen.SetCertificateKeystore(&cmapi.CertificateKeystores{
+			JKS: &cmapi.JKSKeystore{Create: true, PasswordSecretRef: cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: "jks-password"},
+				Key:                  "password",
+			}},
+		}))
+	passwordSecret := gen.Secret("jks-password",
+		gen.SetSecretNamespace(namespace),
+		gen.SetSecretData(map[string][]byte{"password": []byte("changeit")}))
+
+	tlsData := func(extra map[string][]byte) map[string][]byte {
+		data := map[string][]byte{
+			corev1.TLSCertKey:       leaf.certPEM,
+			corev1.TLSPrivateKeyKey: leaf.keyPEM,
+			cmmeta.TLSCAKey:         root.certPEM,
+		}
+		for key, value := range extra {
+			data[key] = value
+		}
+		return data
+	}
+
+	tests := map[string]struct {
+		data             map[string][]byte
+		certificateName  string
+		cmObjects        []runtime.Object
+		kubeObjects      []runtime.Object
+		keystorePassword string
+		certKey, keyKey  string
+		expStatuses      map[string]CheckStatus
+	}{
+		"No additional outputs": {
+			data:        tlsData(nil),
+			expStatuses: map[string]CheckStatus{},
+		},
+		"PKCS#12 keystore and truststore with the password flag": {
+			data: tlsData(map[string][]byte{
+				cmapi.PKCS12SecretKey:     keystoreP12(leaf),
+				cmapi.PKCS12TruststoreKey: truststoreP12,
+			}),
+			keystorePassword: "changeit",
+			expStatuses: map[string]CheckStatus{
+				"keystore.p12 matches tls.crt and tls.key": CheckPassed,
+				"truststore.p12 matches ca.crt":            CheckPassed,
+			},
+		},
+		"PKCS#12 keystore with the password of the Certificate": {
+			data:            tlsData(map[string][]byte{cmapi.PKCS12SecretKey: keystoreP12(leaf)}),
+			certificateName: "my-crt",
+			cmObjects:       []runtime.Object{crtWithPKCS12Password},
+			expStatuses: map[string]CheckStatus{
+				"keystore.p12 matches tls.crt and tls.key": CheckPassed,
+			},
+		},
+		"PKCS#12 keystore of another certificate": {
+			data:             tlsData(map[string][]byte{cmapi.PKCS12SecretKey: keystoreP12(otherLeaf)}),
+			keystorePassword: "changeit",
+			expStatuses: map[string]CheckStatus{
+				"keystore.p12 matches tls.crt and tls.key": CheckFailed,
+			},
+		},
+		"PKCS#12 keystore with the wrong password": {
+			data:             tlsData(map[string][]byte{cmapi.PKCS12SecretKey: keystoreP12(leaf)}),
+			keystorePassword: "wrong",
+			expStatuses: map[string]CheckStatus{
+				"keystore.p12 matches tls.crt and tls.key": CheckFailed,
+			},
+		},
+		"JKS keystore with the password Secret of the Certificate": {
+			data:            tlsData(map[string][]byte{cmapi.JKSSecretKey: keystoreJKS.Bytes()}),
+			certificateName: "my-crt",
+			cmObjects:       []runtime.Object{crtWithJKSPasswordRef},
+			kubeObjects:     []runtime.Object{passwordSecret},
+			expStatuses: map[string]CheckStatus{
+				"keystore.jks matches tls.crt and tls.key": CheckPassed,
+			},
+		},
+		"JKS keystore of a Certificate without JKS keystore": {
+			data:            tlsData(map[string][]byte{cmapi.JKSSecretKey: keystoreJKS.Bytes()}),
+			certificateName: "my-crt",
+			cmObjects:       []runtime.Object{crtWithPKCS12Password},
+			expStatuses: map[string]CheckStatus{
+				"keystore.jks matches tls.crt and tls.key": CheckSkipped,
+			},
+		},
+		"Keystore without password": {
+			data: tlsData(map[string][]byte{cmapi.JKSSecretKey: keystoreJKS.Bytes()}),
+			expStatuses: map[string]CheckStatus{
+				"keystore.jks matches tls.crt and tls.key": CheckSkipped,
+			},
+		},
+		"Combined PEM and DER key": {
+			data: tlsData(map[string][]byte{
+				cmapi.CertificateOutputFormatCombinedPEMKey: append(append([]byte{}, leaf.keyPEM...), leaf.certPEM...),
+				cmapi.CertificateOutputFormatDERKey:         keyDER(leaf),
+			}),
+			expStatuses: map[string]CheckStatus{
+				"tls-combined.pem matches tls.crt and tls.key": CheckPassed,
+				"key.der matches tls.key":                      CheckPassed,
+			},
+		},
+		"Combined PEM and DER key of another certificate": {
+			data: tlsData(map[string][]byte{
+				cmapi.CertificateOutputFormatCombinedPEMKey: append(append([]byte{}, otherLeaf.keyPEM...), otherLeaf.certPEM...),
+				cmapi.CertificateOutputFormatDERKey:         keyDER(otherLeaf),
+			}),
+			expStatuses: map[string]CheckStatus{
+				"tls-combined.pem matches tls.crt and tls.key": CheckFailed,
+				"key.der matches tls.key":                      CheckFailed,
+			},
+		},
+		"Custom keys": {
+			data: map[string][]byte{
+				"cert.pem":                          leaf.certPEM,
+				"key.pem":                           leaf.keyPEM,
+				cmapi.CertificateOutputFormatDERKey: keyDER(leaf),
+			},
+			certKey: "cert.pem",
+			keyKey:  "key.pem",
+			expStatuses: map[string]CheckStatus{
+				"key.der matches key.pem": CheckPassed,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.KeystorePassword = test.keystorePassword
+			if test.certKey != "" {
+				o.CertKey, o.KeyKey = test.certKey, test.keyKey
+			}
+			o.Factory = &factory.Factory{
+				CMClient:   cmfake.NewClientset(test.cmObjects...),
+				KubeClient: kubefake.NewClientset(test.kubeObjects...),
+			}
+
+			secret := gen.Secret("my-crt-tls",
+				gen.SetSecretNamespace(namespace),
+				gen.SetSecretAnnotations(map[string]string{cmapi.CertificateNameKey: test.certificateName}),
+				gen.SetSecretData(test.data))
+
+			statuses := map[string]CheckStatus{}
+			for _, check := range o.verifyAdditionalOutputs(t.Context(), secret, []*x509.Certificate{leaf.cert}) {
+				statuses[check.Name] = check.Status
+				if check.Status != CheckPassed {
+					assert.NotEmpty(t, check.Message, "check %q", check.Name)
+				}
+			}
+			assert.Equal(t, test.expStatuses, statuses)
+		})
+	}
+}
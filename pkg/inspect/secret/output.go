@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"context"
+	"crypto/sha1" // #nosec G505 -- SHA-1 is only used to print the fingerprint commonly shown by other tools
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// SecretInspectionKind is the kind of the structured output of the inspect
+// secret command.
+const SecretInspectionKind = "SecretInspection"
+
+// SecretInspection is the structured representation of a kubernetes.io/tls
+// Secret, printed by the inspect secret command when --output is used.
+type SecretInspection struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Name of the Secret resource
+	Name string `json:"name"`
+	// Namespace of the Secret resource
+	Namespace string `json:"namespace"`
+	// Certificates in 'tls.crt', leaf certificate first
+	Certificates []*CertificateInspection `json:"certificates"`
+	// Verification of the private key and the certificate chain
+	Verification *Verification `json:"verification,omitempty"`
+}
+
+// CertificateInspection is the structured representation of a certificate in
+// the chain of a Secret.
+type CertificateInspection struct {
+	Subject            DistinguishedName `json:"subject"`
+	Issuer             DistinguishedName `json:"issuer"`
+	DNSNames           []string          `json:"dnsNames,omitempty"`
+	URIs               []string          `json:"uris,omitempty"`
+	IPAddresses        []string          `json:"ipAddresses,omitempty"`
+	EmailAddresses     []string          `json:"emailAddresses,omitempty"`
+	KeyUsages          []cmapi.KeyUsage  `json:"keyUsages,omitempty"`
+	SignatureAlgorithm string            `json:"signatureAlgorithm"`
+	PublicKeyAlgorithm string            `json:"publicKeyAlgorithm"`
+	// SerialNumber in decimal, as printed by the human readable output
+	SerialNumber string       `json:"serialNumber"`
+	Fingerprints Fingerprints `json:"fingerprints"`
+	IsCA         bool         `json:"isCA"`
+	NotBefore    metav1.Time  `json:"notBefore"`
+	NotAfter     metav1.Time  `json:"notAfter"`
+	CRLURLs      []string     `json:"crlURLs,omitempty"`
+	OCSPURLs     []string     `json:"ocspURLs,omitempty"`
+	// Checks are the results of checking the certificate against the trust
+	// store of this computer and its revocation endpoints
+	Checks CertificateChecks `json:"checks"`
+	// Extensions are only set with --verbose
+	Extensions *CertificateExtensions `json:"extensions,omitempty"`
+}
+
+// DistinguishedName is the subject or issuer of a certificate.
+type DistinguishedName struct {
+	CommonName         string   `json:"commonName,omitempty"`
+	Organization       []string `json:"organization,omitempty"`
+	OrganizationalUnit []string `json:"organizationalUnit,omitempty"`
+	Country            []string `json:"country,omitempty"`
+}
+
+// Fingerprints are the hex encoded, colon separated hashes of the DER encoded
+// certificate.
+type Fingerprints struct {
+	SHA1   string `json:"sha1"`
+	SHA256 string `json:"sha256"`
+}
+
+// CertificateChecks are the results of the trust, CRL and OCSP checks of a
+// certificate, with the same descriptions as the human readable output.
+type CertificateChecks struct {
+	Trusted bool `json:"trusted"`
+	// TrustError is the reason the certificate is not trusted
+	TrustError string `json:"trustError,omitempty"`
+	CRLStatus  string `json:"crlStatus"`
+	// CRLThisUpdate and CRLNextUpdate are the freshness of the CRL the CRL
+	// status is based on
+	CRLThisUpdate *metav1.Time `json:"crlThisUpdate,omitempty"`
+	CRLNextUpdate *metav1.Time `json:"crlNextUpdate,omitempty"`
+	OCSPStatus    string       `json:"ocspStatus"`
+	// OCSPProducedAt and OCSPNextUpdate are the freshness of the OCSP response
+	// the OCSP status is based on
+	OCSPProducedAt *metav1.Time `json:"ocspProducedAt,omitempty"`
+	OCSPNextUpdate *metav1.Time `json:"ocspNextUpdate,omitempty"`
+}
+
+// inspectionFromSecret returns the structured representation of secret, whose
+// 'tls.crt' contains the PEM encoded certificates certs.
+func inspectionFromSecret(ctx context.Context, secret *corev1.Secret, certs [][]byte, revocation *RevocationChecker) (*SecretInspection, error) {
+	inspections, err := InspectCertificates(ctx, certs, secret.Data[cmmeta.TLSCAKey], revocation)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing 'tls.crt': %w", err)
+	}
+
+	return &SecretInspection{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       SecretInspectionKind,
+		},
+		Name:         secret.Name,
+		Namespace:    secret.Namespace,
+		Certificates: inspections,
+	}, nil
+}
+
+// InspectCertificates returns the structured representation of the PEM
+// encoded certificates certs. Each certificate is checked with the
+// certificates following it in the chain as intermediates, and with ca.
+// revocation performs the CRL and OCSP checks.
+func InspectCertificates(ctx context.Context, certs [][]byte, ca []byte, revocation *RevocationChecker) ([]*CertificateInspection, error) {
+	inspections := []*CertificateInspection{}
+	for i, certData := range certs {
+		cert, err := pki.DecodeX509CertificateBytes(certData)
+		if err != nil {
+			return nil, fmt.Errorf("error when parsing certificate %d: %w", i, err)
+		}
+		inspections = append(inspections, inspectCertificate(ctx, cert, certs[i+1:], ca, revocation))
+	}
+	return inspections, nil
+}
+
+func inspectCertificate(ctx context.Context, cert *x509.Certificate, intermediates [][]byte, ca []byte, revocation *RevocationChecker) *CertificateInspection {
+	sha1Sum := sha1.Sum(cert.Raw) // #nosec G401 -- see import
+	sha256Sum := sha256.Sum256(cert.Raw)
+
+	inspection := &CertificateInspection{
+		Subject:            distinguishedName(cert.Subject),
+		Issuer:             distinguishedName(cert.Issuer),
+		DNSNames:           cert.DNSNames,
+		URIs:               pki.URLsToString(cert.URIs),
+		IPAddresses:        pki.IPAddressesToString(cert.IPAddresses),
+		EmailAddresses:     cert.EmailAddresses,
+		KeyUsages:          buildCertManagerKeyUsages(cert.KeyUsage, cert.ExtKeyUsage),
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		PublicKeyAlgorithm: cert.PublicKeyAlgorithm.String(),
+		SerialNumber:       cert.SerialNumber.String(),
+		Fingerprints: Fingerprints{
+			SHA1:   formatFingerprint(sha1Sum[:]),
+			SHA256: formatFingerprint(sha256Sum[:]),
+		},
+		IsCA:      cert.IsCA,
+		NotBefore: metav1.Time{Time: cert.NotBefore},
+		NotAfter:  metav1.Time{Time: cert.NotAfter},
+		CRLURLs:   cert.CRLDistributionPoints,
+		OCSPURLs:  cert.OCSPServer,
+	}
+
+	crlStatus := revocation.crlStatus(ctx, cert, intermediates, ca)
+	inspection.Checks.CRLStatus = crlStatus.status
+	inspection.Checks.CRLThisUpdate = optionalTime(crlStatus.thisUpdate)
+	inspection.Checks.CRLNextUpdate = optionalTime(crlStatus.nextUpdate)
+
+	ocspStatus := revocation.ocspStatus(ctx, cert, intermediates, ca)
+	inspection.Checks.OCSPStatus = ocspStatus.status
+	inspection.Checks.OCSPProducedAt = optionalTime(ocspStatus.thisUpdate)
+	inspection.Checks.OCSPNextUpdate = optionalTime(ocspStatus.nextUpdate)
+
+	if err := verifyTrusted(cert, intermediates); err != nil {
+		inspection.Checks.TrustError = err.Error()
+	} else {
+		inspection.Checks.Trusted = true
+	}
+
+	return inspection
+}
+
+func distinguishedName(name pkix.Name) DistinguishedName {
+	return DistinguishedName{
+		CommonName:         name.CommonName,
+		Organization:       name.Organization,
+		OrganizationalUnit: name.OrganizationalUnit,
+		Country:            name.Country,
+	}
+}
+
+// optionalTime returns nil for the zero time.
+func optionalTime(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_inspectionFromSecret(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-crt", Namespace: "my-namespace"}}
+
+	inspection, err := inspectionFromSecret(t.Context(), secret, [][]byte{[]byte(testCert), []byte(testCACert)}, NewRevocationChecker())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "SecretInspection", inspection.Kind)
+	assert.Equal(t, "my-crt", inspection.Name)
+	assert.Equal(t, "my-namespace", inspection.Namespace)
+	if !assert.Len(t, inspection.Certificates, 2) {
+		return
+	}
+
+	leaf := inspection.Certificates[0]
+	assert.Equal(t, DistinguishedName{Organization: []string{"cncf"}, OrganizationalUnit: []string{"cert-manager"}, Country: []string{"GB"}}, leaf.Subject)
+	assert.Equal(t, DistinguishedName{CommonName: "testing-ca", Organization: []string{"Internet Widgets, Inc."}, OrganizationalUnit: []string{"WWW"}, Country: []string{"US"}}, leaf.Issuer)
+	assert.Equal(t, []string{"cert-manager.test"}, leaf.DNSNames)
+	assert.Equal(t, []string{"spiffe://cert-manager.test"}, leaf.URIs)
+	assert.Equal(t, []string{"10.0.0.1"}, leaf.IPAddresses)
+	assert.Equal(t, []string{"test@cert-manager.io"}, leaf.EmailAddresses)
+	assert.Equal(t, []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth, cmapi.UsageClientAuth}, leaf.KeyUsages)
+	assert.Equal(t, testCertSerial, leaf.SerialNumber)
+	assert.Equal(t, testCertFingerprint, leaf.Fingerprints.SHA256)
+	assert.Len(t, leaf.Fingerprints.SHA1, 59)
+	assert.False(t, leaf.IsCA)
+	assert.Equal(t, CertificateChecks{
+		Trusted:    true,
+		CRLStatus:  "No CRL endpoints set",
+		OCSPStatus: "Cannot check OCSP: No OCSP Server set",
+	}, leaf.Checks)
+
+	ca := inspection.Certificates[1]
+	assert.Equal(t, "testing-ca", ca.Subject.CommonName)
+	assert.True(t, ca.IsCA)
+	assert.False(t, ca.Checks.Trusted)
+	assert.NotEmpty(t, ca.Checks.TrustError)
+	assert.Equal(t, "Cannot check OCSP, does not have a CA or intermediate certificate provided", ca.Checks.OCSPStatus)
+}
+
+func Test_inspectionFromSecretInvalidCertificate(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-crt"}}
+
+	_, err := inspectionFromSecret(t.Context(), secret, [][]byte{[]byte(testCert), []byte("not a certificate")}, NewRevocationChecker())
+	assert.EqualError(t, err, "error when parsing 'tls.crt': error when parsing certificate 1: error decoding certificate PEM block: no valid certificates found")
+}
@@ -0,0 +1,443 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// defaultRevocationTimeout bounds the total time spent contacting a single
+	// revocation endpoint (an OCSP responder or CRL distribution point)
+	defaultRevocationTimeout = 10 * time.Second
+
+	// maxRevocationResponseSize caps how many bytes we read from a revocation endpoint.
+	maxRevocationResponseSize = 1 << 20 // 1 MiB
+
+	// crlValid is the status of a certificate that is not revoked by a CRL
+	crlValid = "Valid"
+)
+
+// RevocationOptions are the flags that configure how the CRL and OCSP status
+// of certificates is checked.
+type RevocationOptions struct {
+	// Offline disables all requests to CRL distribution points and OCSP
+	// responders. Only the CRLFiles and OCSPResponseFiles are used.
+	Offline bool
+	// CRLFiles are PEM or DER encoded CRLs used instead of downloading the CRLs
+	// of the certificates
+	CRLFiles []string
+	// OCSPResponseFiles are DER encoded OCSP responses, e.g. stapled by a
+	// server, used instead of querying the OCSP responders of the certificates
+	OCSPResponseFiles []string
+	// Timeout of every request to a CRL distribution point or OCSP responder
+	Timeout time.Duration
+	// Proxy is the URL of the proxy used for the requests to CRL distribution
+	// points and OCSP responders. If empty, the proxy is read from the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+	Proxy string
+}
+
+// NewRevocationOptions returns initialized RevocationOptions
+func NewRevocationOptions() *RevocationOptions {
+	return &RevocationOptions{
+		Timeout: defaultRevocationTimeout,
+	}
+}
+
+// AddFlags adds the revocation check flags to cmd.
+func (o *RevocationOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.Offline, "offline", o.Offline, "Do not contact CRL distribution points and OCSP responders. Only the CRLs and OCSP responses of --crl-file and --ocsp-response-file are checked.")
+	cmd.Flags().StringSliceVar(&o.CRLFiles, "crl-file", o.CRLFiles, "PEM or DER encoded CRL used instead of downloading the CRL of the certificate. Can be repeated.")
+	cmd.Flags().StringSliceVar(&o.OCSPResponseFiles, "ocsp-response-file", o.OCSPResponseFiles, "DER encoded OCSP response, e.g. a stapled response, used instead of querying the OCSP responder of the certificate. Can be repeated.")
+	o.AddNetworkFlags(cmd)
+}
+
+// AddNetworkFlags adds only the --revocation-timeout and --revocation-proxy
+// flags to cmd, for commands that download CRLs but do not check the
+// revocation of certificates.
+func (o *RevocationOptions) AddNetworkFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&o.Timeout, "revocation-timeout", o.Timeout, "Timeout of every request to a CRL distribution point or OCSP responder.")
+	cmd.Flags().StringVar(&o.Proxy, "revocation-proxy", o.Proxy, "URL of the proxy used to contact CRL distribution points and OCSP responders. Defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.")
+}
+
+// ToChecker returns a RevocationChecker configured by the options. The CRL and
+// OCSP response files are read and parsed.
+func (o *RevocationOptions) ToChecker() (*RevocationChecker, error) {
+	if o.Timeout <= 0 {
+		return nil, fmt.Errorf("--revocation-timeout must be positive, got %s", o.Timeout)
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if o.Proxy != "" {
+		proxyURL, err := url.Parse(o.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid --revocation-proxy %q, expected a URL like http://proxy.example.com:3128", o.Proxy)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+
+	c := &RevocationChecker{
+		offline: o.Offline,
+		client: &http.Client{
+			Timeout:   o.Timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+
+	for _, path := range o.CRLFiles {
+		data, err := os.ReadFile(path) // #nosec G304 -- the user chooses the CRL to check against
+		if err != nil {
+			return nil, fmt.Errorf("error when reading CRL file: %w", err)
+		}
+		crl, err := ParseCRL(data)
+		if err != nil {
+			return nil, fmt.Errorf("error when parsing CRL file %q: %w", path, err)
+		}
+		c.crls = append(c.crls, crl)
+	}
+
+	for _, path := range o.OCSPResponseFiles {
+		data, err := os.ReadFile(path) // #nosec G304 -- the user chooses the OCSP response to check against
+		if err != nil {
+			return nil, fmt.Errorf("error when reading OCSP response file: %w", err)
+		}
+		c.AddOCSPResponse(data)
+	}
+
+	return c, nil
+}
+
+// RevocationChecker checks the CRL and OCSP status of certificates.
+type RevocationChecker struct {
+	offline       bool
+	crls          []*x509.RevocationList
+	ocspResponses [][]byte
+	client        *http.Client
+}
+
+// NewRevocationChecker returns a RevocationChecker with the default options,
+// which downloads the CRLs and queries the OCSP responders of the certificates.
+func NewRevocationChecker() *RevocationChecker {
+	c, err := NewRevocationOptions().ToChecker()
+	if err != nil {
+		// The default options are always valid
+		panic(err)
+	}
+	return c
+}
+
+// AddOCSPResponse adds a DER encoded OCSP response, e.g. stapled by a server,
+// that is used instead of querying the OCSP responder of the certificate it
+// is for.
+func (c *RevocationChecker) AddOCSPResponse(data []byte) {
+	c.ocspResponses = append(c.ocspResponses, data)
+}
+
+// revocationStatus is the result of a CRL or OCSP check.
+type revocationStatus struct {
+	// status is the human readable result of the check
+	status string
+	// thisUpdate is the thisUpdate of the CRL or the producedAt of the OCSP
+	// response the status is based on
+	thisUpdate time.Time
+	// nextUpdate is the nextUpdate of the CRL or OCSP response the status is
+	// based on
+	nextUpdate time.Time
+	// thisUpdateName is the name of thisUpdate in the human readable output
+	thisUpdateName string
+}
+
+// String returns the status with the freshness of the CRL or OCSP response it
+// is based on.
+func (s revocationStatus) String() string {
+	if s.thisUpdate.IsZero() {
+		return s.status
+	}
+
+	freshness := fmt.Sprintf("%s: %s", s.thisUpdateName, s.thisUpdate.Format(time.RFC1123))
+	switch {
+	case s.nextUpdate.IsZero():
+		freshness += ", next update: <none>"
+	case clock.Now().After(s.nextUpdate):
+		freshness += fmt.Sprintf(", next update: %s, outdated", s.nextUpdate.Format(time.RFC1123))
+	default:
+		freshness += fmt.Sprintf(", next update: %s", s.nextUpdate.Format(time.RFC1123))
+	}
+	return fmt.Sprintf("%s (%s)", s.status, freshness)
+}
+
+// crlStatus checks cert against the CRLs of --crl-file, or else against the
+// CRLs of its CRL distribution points. intermediates and ca are the PEM
+// encoded certificates used to find the issuer that signed the CRL.
+func (c *RevocationChecker) crlStatus(ctx context.Context, cert *x509.Certificate, intermediates [][]byte, ca []byte) revocationStatus {
+	issuer := findIssuer(cert, intermediates, ca)
+
+	if len(c.crls) > 0 {
+		for _, crl := range c.crls {
+			if bytes.Equal(crl.RawIssuer, cert.RawIssuer) {
+				return checkCRL(crl, cert, issuer, "the CRL file")
+			}
+		}
+		return revocationStatus{status: "No CRL file issued by the issuer of the certificate"}
+	}
+
+	if len(cert.CRLDistributionPoints) < 1 {
+		return revocationStatus{status: "No CRL endpoints set"}
+	}
+	if c.offline {
+		return revocationStatus{status: "Not checked, offline"}
+	}
+
+	var status revocationStatus
+	for _, crlURL := range cert.CRLDistributionPoints {
+		u, err := url.Parse(crlURL)
+		if err != nil {
+			return revocationStatus{status: fmt.Sprintf("Invalid CRL URL: %v", err)}
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			continue
+		}
+
+		crl, err := c.FetchCRL(ctx, crlURL)
+		if err != nil {
+			return revocationStatus{status: fmt.Sprintf("Cannot check CRL: %s", err.Error())}
+		}
+		if status = checkCRL(crl, cert, issuer, crlURL); status.status != crlValid {
+			return status
+		}
+	}
+
+	if status.status == "" {
+		return revocationStatus{status: "No CRL endpoints we support found"}
+	}
+
+	return status
+}
+
+// checkCRL checks whether crl, downloaded from source, revokes cert. The
+// signature of the CRL is checked if the issuer is known.
+func checkCRL(crl *x509.RevocationList, cert, issuer *x509.Certificate, source string) revocationStatus {
+	status := revocationStatus{
+		status:         crlValid,
+		thisUpdate:     crl.ThisUpdate,
+		nextUpdate:     crl.NextUpdate,
+		thisUpdateName: "this update",
+	}
+
+	if issuer != nil {
+		if err := crl.CheckSignatureFrom(issuer); err != nil {
+			status.status = fmt.Sprintf("Invalid CRL signature of %s: %s", source, err)
+			return status
+		}
+	}
+
+	for _, revoked := range crl.RevokedCertificateEntries {
+		if cert.SerialNumber.Cmp(revoked.SerialNumber) == 0 {
+			status.status = fmt.Sprintf("Revoked by %s", source)
+			return status
+		}
+	}
+
+	return status
+}
+
+// FetchCRL downloads the PEM or DER encoded CRL at url. Redirects are not
+// followed.
+func (c *RevocationChecker) FetchCRL(ctx context.Context, url string) (*x509.RevocationList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+
+	resp, err := c.client.Do(req) // #nosec G704 -- CRL URL scheme validated by caller, redirects disabled, response size and timeout bounded
+	if err != nil {
+		return nil, fmt.Errorf("error getting HTTP response: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRevocationResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading HTTP body: %w", err)
+	}
+
+	crl, err := ParseCRL(body)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing HTTP body: %w", err)
+	}
+
+	return crl, nil
+}
+
+// ocspStatus checks cert against the OCSP responses of --ocsp-response-file,
+// or else against its OCSP responders. intermediates and ca are the PEM
+// encoded certificates used to find the issuer of cert.
+func (c *RevocationChecker) ocspStatus(ctx context.Context, cert *x509.Certificate, intermediates [][]byte, ca []byte) revocationStatus {
+	issuer := findIssuer(cert, intermediates, ca)
+
+	if len(c.ocspResponses) > 0 {
+		for _, data := range c.ocspResponses {
+			response, err := ocsp.ParseResponseForCert(data, cert, issuer)
+			if err != nil {
+				continue
+			}
+			return describeOCSPResponse(response)
+		}
+		return revocationStatus{status: "No OCSP response for the certificate"}
+	}
+
+	if len(ca) > 1 {
+		intermediates = append([][]byte{ca}, intermediates...)
+	}
+	if len(intermediates) < 1 {
+		return revocationStatus{status: "Cannot check OCSP, does not have a CA or intermediate certificate provided"}
+	}
+	if c.offline {
+		return revocationStatus{status: "Not checked, offline"}
+	}
+	if issuer == nil {
+		var err error
+		if issuer, err = pki.DecodeX509CertificateBytes(intermediates[len(intermediates)-1]); err != nil {
+			return revocationStatus{status: fmt.Sprintf("Cannot parse intermediate certificate: %s", err.Error())}
+		}
+	}
+
+	response, err := c.checkOCSPValidCert(ctx, cert, issuer)
+	if err != nil {
+		return revocationStatus{status: fmt.Sprintf("Cannot check OCSP: %s", err.Error())}
+	}
+
+	return describeOCSPResponse(response)
+}
+
+func describeOCSPResponse(response *ocsp.Response) revocationStatus {
+	status := revocationStatus{
+		status:         "valid",
+		thisUpdate:     response.ProducedAt,
+		nextUpdate:     response.NextUpdate,
+		thisUpdateName: "produced at",
+	}
+	switch response.Status {
+	case ocsp.Revoked:
+		status.status = "Marked as revoked"
+	case ocsp.Unknown:
+		status.status = "Unknown to the OCSP responder"
+	}
+	return status
+}
+
+// checkOCSPValidCert queries the OCSP responders of leafCert. It returns the
+// first response that marks the certificate as revoked, or else the last
+// response.
+func (c *RevocationChecker) checkOCSPValidCert(ctx context.Context, leafCert, issuerCert *x509.Certificate) (*ocsp.Response, error) {
+	if len(leafCert.OCSPServer) < 1 {
+		return nil, errors.New("No OCSP Server set")
+	}
+	buffer, err := ocsp.CreateRequest(leafCert, issuerCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return nil, fmt.Errorf("error creating OCSP request: %w", err)
+	}
+
+	var ocspResponse *ocsp.Response
+	for _, ocspServer := range leafCert.OCSPServer {
+		ocspUrl, err := url.Parse(ocspServer)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing OCSP URL: %w", err)
+		}
+		// The OCSP URL comes from the untrusted certificate under inspection, so
+		// restrict it to HTTP(S) rather than dereferencing arbitrary schemes.
+		if ocspUrl.Scheme != "http" && ocspUrl.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported OCSP URL scheme %q, only http and https are supported", ocspUrl.Scheme)
+		}
+
+		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, ocspServer, bytes.NewBuffer(buffer))
+		if err != nil {
+			return nil, fmt.Errorf("error creating HTTP request: %w", err)
+		}
+		httpRequest.Header.Add("Content-Type", "application/ocsp-request")
+		httpRequest.Header.Add("Accept", "application/ocsp-response")
+		httpRequest.Header.Add("Host", ocspUrl.Host)
+		httpResponse, err := c.client.Do(httpRequest) // #nosec G704 -- URL scheme restricted to http(s), redirects disabled, response size and timeout bounded
+		if err != nil {
+			return nil, fmt.Errorf("error making HTTP request: %w", err)
+		}
+		defer httpResponse.Body.Close()
+		output, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxRevocationResponseSize))
+		if err != nil {
+			return nil, fmt.Errorf("error reading HTTP body: %w", err)
+		}
+		ocspResponse, err = ocsp.ParseResponse(output, issuerCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading OCSP response: %w", err)
+		}
+
+		if ocspResponse.Status == ocsp.Revoked {
+			// one OCSP revoked it do not trust
+			return ocspResponse, nil
+		}
+	}
+
+	return ocspResponse, nil
+}
+
+// findIssuer returns the certificate in intermediates or ca that signed cert,
+// or nil if there is none.
+func findIssuer(cert *x509.Certificate, intermediates [][]byte, ca []byte) *x509.Certificate {
+	for _, certData := range append(append([][]byte{}, intermediates...), ca) {
+		candidates, err := pki.DecodeX509CertificateSetBytes(certData)
+		if err != nil {
+			continue
+		}
+		for _, candidate := range candidates {
+			if issuedBy(cert, candidate) {
+				return candidate
+			}
+		}
+	}
+	return nil
+}
+
+// ParseCRL parses a PEM or DER encoded CRL.
+func ParseCRL(data []byte) (*x509.RevocationList, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "X509 CRL" {
+			return nil, fmt.Errorf("unexpected PEM block type %q, expected \"X509 CRL\"", block.Type)
+		}
+		data = block.Bytes
+	}
+	return x509.ParseRevocationList(data)
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ocsp"
+)
+
+// newTestCRLIssuer returns a self-signed CA that is allowed to sign CRLs.
+func newTestCRLIssuer(t *testing.T, commonName string) *testKeyPair {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	certPEM, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKeyPair{cert: cert, certPEM: certPEM, key: key}
+}
+
+// newTestCRL returns a CRL of issuer that revokes the certificates with the
+// given serial numbers.
+func newTestCRL(t *testing.T, issuer *testKeyPair, thisUpdate, nextUpdate time.Time, revoked ...*big.Int) *x509.RevocationList {
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: thisUpdate,
+		NextUpdate: nextUpdate,
+	}
+	for _, serialNumber := range revoked {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   serialNumber,
+			RevocationTime: thisUpdate,
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, issuer.cert, issuer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crl
+}
+
+func TestCRLStatus(t *testing.T) {
+	ca := newTestCRLIssuer(t, "ca")
+	otherCA := newTestCRLIssuer(t, "other-ca")
+	leaf := newTestKeyPair(t, "leaf", false, ca)
+
+	withCRLEndpoint := *leaf.cert
+	withCRLEndpoint.CRLDistributionPoints = []string{"https://ca.example.com/ca.crl"}
+
+	withLDAPCRLEndpoint := *leaf.cert
+	withLDAPCRLEndpoint.CRLDistributionPoints = []string{"ldap://ldap.example.com/cn=ca?certificateRevocationList"}
+
+	thisUpdate := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+	nextUpdate := thisUpdate.Add(24 * time.Hour)
+	outdatedNextUpdate := thisUpdate.Add(time.Minute)
+
+	crlServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(newTestCRL(t, ca, thisUpdate, nextUpdate, leaf.cert.SerialNumber).Raw)
+	}))
+	defer crlServer.Close()
+
+	withHTTPCRLEndpoint := *leaf.cert
+	withHTTPCRLEndpoint.CRLDistributionPoints = []string{crlServer.URL + "/ca.crl"}
+	freshness := func(nextUpdate time.Time, suffix string) string {
+		return " (this update: " + thisUpdate.Format(time.RFC1123) + ", next update: " + nextUpdate.Format(time.RFC1123) + suffix + ")"
+	}
+
+	tests := map[string]struct {
+		cert    *x509.Certificate
+		offline bool
+		crls    []*x509.RevocationList
+		ca      []byte
+		want    string
+	}{
+		"Offline": {
+			cert:    &withCRLEndpoint,
+			offline: true,
+			want:    "Not checked, offline",
+		},
+		"No CRL endpoints and no CRL file": {
+			cert:    leaf.cert,
+			offline: true,
+			want:    "No CRL endpoints set",
+		},
+		"CRL file does not revoke the certificate": {
+			cert: &withCRLEndpoint,
+			crls: []*x509.RevocationList{newTestCRL(t, ca, thisUpdate, nextUpdate, big.NewInt(42))},
+			ca:   ca.certPEM,
+			want: "Valid" + freshness(nextUpdate, ""),
+		},
+		"CRL file revokes the certificate": {
+			cert: leaf.cert,
+			crls: []*x509.RevocationList{
+				newTestCRL(t, otherCA, thisUpdate, nextUpdate),
+				newTestCRL(t, ca, thisUpdate, nextUpdate, leaf.cert.SerialNumber),
+			},
+			want: "Revoked by the CRL file" + freshness(nextUpdate, ""),
+		},
+		"Outdated CRL file": {
+			cert: leaf.cert,
+			crls: []*x509.RevocationList{newTestCRL(t, ca, thisUpdate, outdatedNextUpdate)},
+			want: "Valid" + freshness(outdatedNextUpdate, ", outdated"),
+		},
+		"HTTP CRL endpoint revokes the certificate": {
+			cert: &withHTTPCRLEndpoint,
+			ca:   ca.certPEM,
+			want: "Revoked by " + crlServer.URL + "/ca.crl" + freshness(nextUpdate, ""),
+		},
+		"LDAP CRL endpoint is not supported": {
+			cert: &withLDAPCRLEndpoint,
+			want: "No CRL endpoints we support found",
+		},
+		"No CRL file of the issuer": {
+			cert: leaf.cert,
+			crls: []*x509.RevocationList{newTestCRL(t, otherCA, thisUpdate, nextUpdate)},
+			want: "No CRL file issued by the issuer of the certificate",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewRevocationChecker()
+			c.offline = test.offline
+			c.crls = test.crls
+
+			assert.Equal(t, test.want, c.crlStatus(t.Context(), test.cert, nil, test.ca).String())
+		})
+	}
+}
+
+func TestOCSPStatus(t *testing.T) {
+	ca := newTestKeyPair(t, "ca", true, nil)
+	leaf := newTestKeyPair(t, "leaf", false, ca)
+	otherLeaf := newTestKeyPair(t, "other-leaf", false, ca)
+
+	withOCSPServer := *leaf.cert
+	withOCSPServer.OCSPServer = []string{"http://ocsp.example.com"}
+
+	thisUpdate := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+	nextUpdate := thisUpdate.Add(24 * time.Hour)
+	ocspResponse := func(status int, serialNumber *big.Int) []byte {
+		response, err := ocsp.CreateResponse(ca.cert, ca.cert, ocsp.Response{
+			Status:       status,
+			SerialNumber: serialNumber,
+			ThisUpdate:   thisUpdate,
+			NextUpdate:   nextUpdate,
+			RevokedAt:    thisUpdate,
+		}, ca.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+
+	tests := map[string]struct {
+		offline       bool
+		ocspResponses [][]byte
+		want          string
+	}{
+		"Offline": {
+			offline: true,
+			want:    "Not checked, offline",
+		},
+		"Good OCSP response file": {
+			ocspResponses: [][]byte{ocspResponse(ocsp.Good, leaf.cert.SerialNumber)},
+			want:          "valid (produced at: ",
+		},
+		"Revoked OCSP response file": {
+			ocspResponses: [][]byte{
+				ocspResponse(ocsp.Good, otherLeaf.cert.SerialNumber),
+				ocspResponse(ocsp.Revoked, leaf.cert.SerialNumber),
+			},
+			want: "Marked as revoked (produced at: ",
+		},
+		"No OCSP response for the certificate": {
+			ocspResponses: [][]byte{ocspResponse(ocsp.Good, otherLeaf.cert.SerialNumber), []byte("not an OCSP response")},
+			want:          "No OCSP response for the certificate",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewRevocationChecker()
+			c.offline = test.offline
+			for _, response := range test.ocspResponses {
+				c.AddOCSPResponse(response)
+			}
+
+			got := c.ocspStatus(t.Context(), &withOCSPServer, nil, ca.certPEM).String()
+			assert.True(t, strings.HasPrefix(got, test.want), "got %q, want prefix %q", got, test.want)
+			if strings.Contains(test.want, "produced at") {
+				assert.True(t, strings.HasSuffix(got, ", next update: "+nextUpdate.Format(time.RFC1123)+")"), "got %q", got)
+			}
+		})
+	}
+}
+
+func TestRevocationOptionsToChecker(t *testing.T) {
+	ca := newTestCRLIssuer(t, "ca")
+	crl := newTestCRL(t, ca, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+	dir := t.TempDir()
+	pemCRLFile := filepath.Join(dir, "ca.crl.pem")
+	derCRLFile := filepath.Join(dir, "ca.crl")
+	invalidCRLFile := filepath.Join(dir, "invalid.crl")
+	for path, data := range map[string][]byte{
+		pemCRLFile:     pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl.Raw}),
+		derCRLFile:     crl.Raw,
+		invalidCRLFile: ca.certPEM,
+	} {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		options *RevocationOptions
+		expCRLs int
+		expErr  string
+	}{
+		"Default options": {
+			options: NewRevocationOptions(),
+		},
+		"PEM and DER CRL files": {
+			options: &RevocationOptions{Timeout: time.Second, CRLFiles: []string{pemCRLFile, derCRLFile}},
+			expCRLs: 2,
+		},
+		"Invalid CRL file": {
+			options: &RevocationOptions{Timeout: time.Second, CRLFiles: []string{invalidCRLFile}},
+			expErr:  `error when parsing CRL file "` + invalidCRLFile + `": unexpected PEM block type "CERTIFICATE", expected "X509 CRL"`,
+		},
+		"Missing OCSP response file": {
+			options: &RevocationOptions{Timeout: time.Second, OCSPResponseFiles: []string{filepath.Join(dir, "missing")}},
+			expErr:  "error when reading OCSP response file: open " + filepath.Join(dir, "missing") + ": no such file or directory",
+		},
+		"Proxy": {
+			options: &RevocationOptions{Timeout: time.Second, Proxy: "http://proxy.example.com:3128"},
+		},
+		"Invalid proxy": {
+			options: &RevocationOptions{Timeout: time.Second, Proxy: "proxy.example.com"},
+			expErr:  `invalid --revocation-proxy "proxy.example.com", expected a URL like http://proxy.example.com:3128`,
+		},
+		"Non-positive timeout": {
+			options: &RevocationOptions{},
+			expErr:  "--revocation-timeout must be positive, got 0s",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, err := test.options.ToChecker()
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Len(t, c.crls, test.expCRLs)
+				assert.Equal(t, test.options.Timeout, c.client.Timeout)
+			}
+		})
+	}
+}
+
+// Test_fetchCRL_doesNotFollowRedirects ensures that a CRL distribution
+// point cannot redirect the request to a different (e.g. internal) endpoint.
+func Test_fetchCRL_doesNotFollowRedirects(t *testing.T) {
+	var internalHit atomic.Bool
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHit.Store(true)
+	}))
+	defer internal.Close()
+
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirector.Close()
+
+	// The redirect response is not a valid CRL, so an error is expected
+	if _, err := NewRevocationChecker().FetchCRL(t.Context(), redirector.URL); err == nil {
+		t.Fatal("expected an error parsing the redirect response as a CRL, got nil")
+	}
+	if internalHit.Load() {
+		t.Error("FetchCRL followed a redirect to another endpoint; redirects must not be followed")
+	}
+}
+
+// Test_checkOCSPValidCert_rejectsNonHTTPScheme ensures the OCSP server URL
+// is restricted to HTTP(S) rather than being dereferenced with an arbitrary scheme.
+func Test_checkOCSPValidCert_rejectsNonHTTPScheme(t *testing.T) {
+	cert := MustParseCertificate(t, testCert)
+	cert.OCSPServer = []string{"ftp://attacker.example/ocsp"}
+
+	_, err := NewRevocationChecker().checkOCSPValidCert(t.Context(), cert, cert)
+	if err == nil {
+		t.Fatal("expected checkOCSPValidCert to reject a non-http(s) OCSP URL, got nil error")
+	}
+	if !strings.Contains(err.Error(), "unsupported OCSP URL scheme") {
+		t.Errorf("expected an unsupported-scheme error, got: %v", err)
+	}
+}
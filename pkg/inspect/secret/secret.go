@@ -23,7 +23,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/url"
 	"strings"
 	"text/template"
 	"time"
@@ -39,8 +38,10 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 	k8sclock "k8s.io/utils/clock"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
 )
 
 var clock k8sclock.Clock = k8sclock.RealClock{}
@@ -84,6 +85,29 @@ const debuggingTemplate = `Debugging:
 
 // Options is a struct to support status certificate command
 type Options struct {
+	// CertKey is the key of the Secret that holds the PEM encoded certificate
+	// chain
+	CertKey string
+	// KeyKey is the key of the Secret that holds the PEM encoded private key
+	KeyKey string
+	// KeystorePassword is the password of the keystores in the Secret. If
+	// empty, the password configured on the Certificate of the Secret is used.
+	KeystorePassword string
+	// Verbose adds the remaining extensions of the certificate to the output
+	Verbose bool
+
+	// ExpiringSoonThreshold is the remaining validity below which the
+	// certificate is reported as expiring soon by the exit code.
+	ExpiringSoonThreshold time.Duration
+
+	// Revocation holds the flags that configure the CRL and OCSP checks
+	Revocation *RevocationOptions
+
+	// PrintFlags holds the flags used to print the certificates in a
+	// structured format. If no output format is set, the human readable output
+	// is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -91,7 +115,12 @@ type Options struct {
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
-		IOStreams: ioStreams,
+		CertKey:               corev1.TLSCertKey,
+		KeyKey:                corev1.TLSPrivateKeyKey,
+		ExpiringSoonThreshold: cmcmdutil.DefaultExpiringSoonThreshold,
+		Revocation:            NewRevocationOptions(),
+		PrintFlags:            util.NewPrintFlags(),
+		IOStreams:             ioStreams,
 	}
 }
 
@@ -103,10 +132,56 @@ func NewCmdInspectSecret(setupCtx context.Context, ioStreams genericclioptions.I
 		Use:   "secret",
 		Short: "Get details about a kubernetes.io/tls typed secret",
 		Long: templates.LongDesc(`
-Get details about a kubernetes.io/tls typed secret`),
+Get details about a kubernetes.io/tls typed secret
+
+The verification section checks that tls.key is the private key of the leaf certificate, that the certificates in tls.crt are
+ordered from the leaf certificate up, each issued by the next one, without duplicates and without missing intermediates,
+and that the chain is anchored by a certificate in ca.crt.
+
+The keystores (keystore.p12, truststore.p12, keystore.jks and truststore.jks) and additional output formats
+(tls-combined.pem and key.der) in the secret are checked to contain the same certificates and private key as
+tls.crt, tls.key and ca.crt. Keystores are decrypted with the password configured in the Certificate of the secret,
+or with --keystore-password.
+
+Secrets that store the PEM encoded certificate chain and private key under other keys, e.g. Opaque secrets,
+can be inspected with --cert-key and --key-key.
+
+With --verbose, the remaining extensions of the certificate are shown too: the full subject, basic and name constraints,
+certificate policies, subject and authority key IDs, CA issuer URLs, otherName SANs, the signed certificate timestamps
+of Certificate Transparency logs and any extension that is not otherwise described.
+
+The CRL and OCSP checks download the CRLs and query the OCSP responders of the certificate, within --revocation-timeout
+and through --revocation-proxy or the proxy of the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+With --offline, no requests are made. CRLs and OCSP responses can also be supplied with --crl-file and
+--ocsp-response-file, e.g. in air-gapped clusters. The thisUpdate and nextUpdate of the CRL and the producedAt and
+nextUpdate of the OCSP response are shown with the result.
+
+With --output, every certificate in the chain is printed as structured data, including its subject, issuer, SANs, key usages, fingerprints, validity and the results of the trust, CRL and OCSP checks.
+
+The exit code reports the health of the certificate, so that scripts can gate on it:
+0 if the certificate is valid, 2 if it expires within --expiring-soon-threshold,
+3 if it is not valid yet, 4 if it has expired and 5 if the Secret could not be fetched.`),
 		Example: templates.Examples(build.WithTemplate(setupCtx, `
 # Query information about a secret with name 'my-crt' in namespace 'my-namespace'
 {{.BuildName}} inspect secret my-crt --namespace my-namespace
+
+# Print every certificate in the chain of secret 'my-crt' as JSON
+{{.BuildName}} inspect secret my-crt -o json
+
+# Show all extensions of the certificate in secret 'my-crt', e.g. to check its name constraints
+{{.BuildName}} inspect secret my-crt --verbose
+
+# Check the certificate in secret 'my-crt' against a local CRL without network access
+{{.BuildName}} inspect secret my-crt --offline --crl-file ca.crl
+
+# Query information about an Opaque secret that stores the certificate in 'cert.pem' and the key in 'key.pem'
+{{.BuildName}} inspect secret my-opaque-secret --cert-key cert.pem --key-key key.pem
+
+# Check the keystores in secret 'my-crt' with the given password
+{{.BuildName}} inspect secret my-crt --keystore-password changeit
+
+# Fail a CI job if the certificate in secret 'my-crt' expires within 30 days
+{{.BuildName}} inspect secret my-crt --expiring-soon-threshold=720h > /dev/null
 `)),
 		ValidArgsFunction: factory.ValidArgsListSecrets(&o.Factory),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -118,6 +193,14 @@ Get details about a kubernetes.io/tls typed secret`),
 		},
 	}
 
+	cmd.Flags().StringVar(&o.CertKey, "cert-key", o.CertKey, "Key of the Secret that holds the PEM encoded certificate chain.")
+	cmd.Flags().StringVar(&o.KeyKey, "key-key", o.KeyKey, "Key of the Secret that holds the PEM encoded private key.")
+	cmd.Flags().StringVar(&o.KeystorePassword, "keystore-password", o.KeystorePassword, "Password of the keystores in the Secret. Defaults to the password configured in the Certificate of the Secret.")
+	cmd.Flags().BoolVar(&o.Verbose, "verbose", o.Verbose, "Show the remaining extensions of the certificate, e.g. basic and name constraints, policies, key IDs and signed certificate timestamps.")
+	cmd.Flags().DurationVar(&o.ExpiringSoonThreshold, "expiring-soon-threshold", o.ExpiringSoonThreshold, "Remaining validity below which the certificate is reported as expiring soon by the exit code.")
+	o.Revocation.AddFlags(cmd)
+	o.PrintFlags.AddFlags(cmd)
+
 	o.Factory = factory.New(cmd)
 
 	return cmd
@@ -131,23 +214,33 @@ func (o *Options) Validate(args []string) error {
 	if len(args) > 1 {
 		return errors.New("only one argument can be passed in: the name of the Secret")
 	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // Run executes status certificate command
 func (o *Options) Run(ctx context.Context, args []string, stdout io.Writer) error {
+	revocation, err := o.Revocation.ToChecker()
+	if err != nil {
+		return err
+	}
+
 	secret, err := o.KubeClient.CoreV1().Secrets(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
 	if err != nil {
-		return fmt.Errorf("error when finding Secret %q: %w\n", args[0], err)
+		return cmcmdutil.SetFetchErrorExitCode(fmt.Errorf("error when finding Secret %q: %w\n", args[0], err))
 	}
 
-	certData := secret.Data[corev1.TLSCertKey]
+	certData := secret.Data[o.CertKey]
 	certs, err := splitPEMs(certData)
 	if err != nil {
 		return err
 	}
 	if len(certs) < 1 {
-		return errors.New("no PEM data found in secret")
+		return fmt.Errorf("no PEM data found in key %q of secret", o.CertKey)
 	}
 
 	intermediates := [][]byte(nil)
@@ -155,12 +248,71 @@ func (o *Options) Run(ctx context.Context, args []string, stdout io.Writer) erro
 		intermediates = certs[1:]
 	}
 
-	// we only want to inspect the leaf certificate
-	x509Cert, err := pki.DecodeX509CertificateBytes(certs[0])
+	chain := make([]*x509.Certificate, 0, len(certs))
+	for i, certData := range certs {
+		cert, err := pki.DecodeX509CertificateBytes(certData)
+		if err != nil {
+			return fmt.Errorf("error when parsing certificate %d of %q: %w", i, o.CertKey, err)
+		}
+		chain = append(chain, cert)
+	}
+	verification := VerifyChain(chain, secret.Data[o.KeyKey], secret.Data[cmmeta.TLSCAKey])
+	verification.Checks = append(verification.Checks, o.verifyAdditionalOutputs(ctx, secret, chain)...)
+
+	// we only want to describe the leaf certificate
+	x509Cert := chain[0]
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if err := o.printInspection(ctx, secret, certs, chain, verification, revocation, stdout); err != nil {
+			return err
+		}
+	} else {
+		desc, err := DescribeCertificate(ctx, x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey], revocation)
+		if err != nil {
+			return err
+		}
+		if o.Verbose {
+			extensions, err := describeExtensions(x509Cert)
+			if err != nil {
+				return err
+			}
+			desc += "\n\n" + extensions
+		}
+		fmt.Fprintf(stdout, "%s\n\n%s\n", desc, verification)
+	}
+
+	cmcmdutil.SetExitCodeValue(ExitCodeForCertificate(x509Cert, clock.Now(), o.ExpiringSoonThreshold))
+	return nil
+}
+
+// printInspection prints all certificates in secret in the requested
+// structured output format. chain are the parsed certificates certs.
+func (o *Options) printInspection(ctx context.Context, secret *corev1.Secret, certs [][]byte, chain []*x509.Certificate, verification *Verification, revocation *RevocationChecker, stdout io.Writer) error {
+	inspection, err := inspectionFromSecret(ctx, secret, certs, revocation)
 	if err != nil {
-		return fmt.Errorf("error when parsing 'tls.crt': %w", err)
+		return err
 	}
+	inspection.Verification = verification
+	if o.Verbose {
+		for i, cert := range chain {
+			if inspection.Certificates[i].Extensions, err = extensionsOf(cert); err != nil {
+				return err
+			}
+		}
+	}
+
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+	return util.PrintObject(printer, inspection, stdout)
+}
 
+// DescribeCertificate returns the human readable description of x509Cert,
+// including the results of the trust, CRL and OCSP checks. intermediates and
+// ca are the PEM encoded certificates used to check x509Cert, revocation
+// performs the CRL and OCSP checks.
+func DescribeCertificate(ctx context.Context, x509Cert *x509.Certificate, intermediates [][]byte, ca []byte, revocation *RevocationChecker) (string, error) {
 	var out []string
 
 	for _, describeFn := range []func(*x509.Certificate) (string, error){
@@ -172,20 +324,24 @@ func (o *Options) Run(ctx context.Context, args []string, stdout io.Writer) erro
 	} {
 		desc, err := describeFn(x509Cert)
 		if err != nil {
-			return err
+			return "", err
 		}
 		out = append(out, desc)
 	}
 
-	if desc, err := describeDebugging(ctx, x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey]); err != nil {
-		return err
+	if desc, err := describeDebugging(ctx, x509Cert, intermediates, ca, revocation); err != nil {
+		return "", err
 	} else {
 		out = append(out, desc)
 	}
 
-	fmt.Fprintln(stdout, strings.Join(out, "\n\n"))
+	return strings.Join(out, "\n\n"), nil
+}
 
-	return nil
+// ExitCodeForCertificate returns the exit code reporting the health of cert at
+// time now. A certificate that is not valid yet is reported as not ready.
+func ExitCodeForCertificate(cert *x509.Certificate, now time.Time, expiringSoonThreshold time.Duration) int {
+	return cmcmdutil.CertificateExitCode(!now.Before(cert.NotBefore), cert.NotAfter, now, expiringSoonThreshold)
 }
 
 func describeValidFor(cert *x509.Certificate) (string, error) {
@@ -310,7 +466,7 @@ func describeCertificate(cert *x509.Certificate) (string, error) {
 	return b.String(), err
 }
 
-func describeDebugging(ctx context.Context, cert *x509.Certificate, intermediates [][]byte, ca []byte) (string, error) {
+func describeDebugging(ctx context.Context, cert *x509.Certificate, intermediates [][]byte, ca []byte, revocation *RevocationChecker) (string, error) {
 	tmpl, err := template.New("debuggingTemplate").Parse(debuggingTemplate)
 	if err != nil {
 		return "", err
@@ -323,73 +479,42 @@ func describeDebugging(ctx context.Context, cert *x509.Certificate, intermediate
 		OCSPStatus            string
 	}{
 		TrustedByThisComputer: describeTrusted(cert, intermediates),
-		CRLStatus:             describeCRL(ctx, cert),
-		OCSPStatus:            describeOCSP(ctx, cert, intermediates, ca),
+		CRLStatus:             revocation.crlStatus(ctx, cert, intermediates, ca).String(),
+		OCSPStatus:            revocation.ocspStatus(ctx, cert, intermediates, ca).String(),
 	})
 
 	return b.String(), err
 }
 
-func describeCRL(ctx context.Context, cert *x509.Certificate) string {
-	if len(cert.CRLDistributionPoints) < 1 {
-		return "No CRL endpoints set"
-	}
-
-	hasChecked := false
-	for _, crlURL := range cert.CRLDistributionPoints {
-		u, err := url.Parse(crlURL)
-		if err != nil {
-			return fmt.Sprintf("Invalid CRL URL: %v", err)
-		}
-		if u.Scheme != "ldap" && u.Scheme != "https" {
-			continue
-		}
-
-		hasChecked = true
-		valid, err := checkCRLValidCert(ctx, cert, crlURL)
-		if err != nil {
-			return fmt.Sprintf("Cannot check CRL: %s", err.Error())
-		}
-		if !valid {
-			return fmt.Sprintf("Revoked by %s", crlURL)
-		}
-	}
-
-	if !hasChecked {
-		return "No CRL endpoints we support found"
+func describeTrusted(cert *x509.Certificate, intermediates [][]byte) string {
+	err := verifyTrusted(cert, intermediates)
+	var poolErr systemCertPoolError
+	switch {
+	case err == nil:
+		return "yes"
+	case errors.As(err, &poolErr):
+		return err.Error()
+	default:
+		return fmt.Sprintf("no: %s", err.Error())
 	}
-
-	return "Valid"
 }
 
-func describeOCSP(ctx context.Context, cert *x509.Certificate, intermediates [][]byte, ca []byte) string {
-	if len(ca) > 1 {
-		intermediates = append([][]byte{ca}, intermediates...)
-	}
-	if len(intermediates) < 1 {
-		return "Cannot check OCSP, does not have a CA or intermediate certificate provided"
-	}
-	issuerCert, err := pki.DecodeX509CertificateBytes(intermediates[len(intermediates)-1])
-	if err != nil {
-		return fmt.Sprintf("Cannot parse intermediate certificate: %s", err.Error())
-	}
-
-	valid, err := checkOCSPValidCert(ctx, cert, issuerCert)
-	if err != nil {
-		return fmt.Sprintf("Cannot check OCSP: %s", err.Error())
-	}
-
-	if !valid {
-		return "Marked as revoked"
-	}
+// systemCertPoolError is returned by verifyTrusted if the system CA store
+// cannot be loaded.
+type systemCertPoolError struct {
+	err error
+}
 
-	return "valid"
+func (e systemCertPoolError) Error() string {
+	return fmt.Sprintf("Error getting system CA store: %s", e.err.Error())
 }
 
-func describeTrusted(cert *x509.Certificate, intermediates [][]byte) string {
+// verifyTrusted returns an error if cert is not trusted by the system CA
+// store, with intermediates added to it.
+func verifyTrusted(cert *x509.Certificate, intermediates [][]byte) error {
 	systemPool, err := x509.SystemCertPool()
 	if err != nil {
-		return fmt.Sprintf("Error getting system CA store: %s", err.Error())
+		return systemCertPoolError{err: err}
 	}
 	for _, intermediate := range intermediates {
 		systemPool.AppendCertsFromPEM(intermediate)
@@ -398,8 +523,5 @@ func describeTrusted(cert *x509.Certificate, intermediates [][]byte) string {
 		Roots:       systemPool,
 		CurrentTime: clock.Now(),
 	})
-	if err == nil {
-		return "yes"
-	}
-	return fmt.Sprintf("no: %s", err.Error())
+	return err
 }
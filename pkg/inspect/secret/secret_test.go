@@ -17,6 +17,7 @@ limitations under the License.
 package secret
 
 import (
+	"crypto/ecdsa"
 	"crypto/x509"
 	"fmt"
 	"slices"
@@ -28,9 +29,13 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 )
 
 var (
+	testCertKey         *ecdsa.PrivateKey
+	testCACert          string
 	testCert            string
 	testCertSerial      string
 	testCertFingerprint string
@@ -68,12 +73,12 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	_, caCert, err := pki.SignCertificate(caX509Cert, caX509Cert, caKey.Public(), caKey)
+	caCertPEM, caCert, err := pki.SignCertificate(caX509Cert, caX509Cert, caKey.Public(), caKey)
 	if err != nil {
 		panic(err)
 	}
 
-	testCertKey, err := pki.GenerateECPrivateKey(256)
+	testCertKey, err = pki.GenerateECPrivateKey(256)
 	if err != nil {
 		panic(err)
 	}
@@ -110,6 +115,7 @@ func init() {
 		panic(err)
 	}
 
+	testCACert = string(caCertPEM)
 	testCert = string(testCertPEM)
 	testCertSerial = testCertGo.SerialNumber.String()
 	testCertFingerprint = fingerprintCert(testCertGo)
@@ -140,7 +146,7 @@ func Test_describeCRL(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeCRL(t.Context(), tt.cert); got != tt.want {
+			if got := NewRevocationChecker().crlStatus(t.Context(), tt.cert, nil, nil).String(); got != tt.want {
 				t.Errorf("describeCRL() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
@@ -206,7 +212,7 @@ func Test_describeDebugging(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := describeDebugging(t.Context(), tt.args.cert, tt.args.intermediates, tt.args.ca)
+			got, err := describeDebugging(t.Context(), tt.args.cert, tt.args.intermediates, tt.args.ca, NewRevocationChecker())
 
 			if len(tt.want) > 0 && !slices.Contains(tt.want, got) {
 				t.Errorf("describeDebugging() = %q, want one of %s", got, quotedSlice(tt.want))
@@ -297,7 +303,7 @@ func Test_describeOCSP(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeOCSP(t.Context(), tt.args.cert, tt.args.intermediates, tt.args.ca); got != tt.want {
+			if got := NewRevocationChecker().ocspStatus(t.Context(), tt.args.cert, tt.args.intermediates, tt.args.ca).String(); got != tt.want {
 				t.Errorf("describeOCSP() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
@@ -343,6 +349,43 @@ func Test_describeTrusted(t *testing.T) {
 	}
 }
 
+func Test_ExitCodeForCertificate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		cert *x509.Certificate
+		want int
+	}{
+		{
+			name: "Valid certificate",
+			cert: &x509.Certificate{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(30 * 24 * time.Hour)},
+			want: cmcmdutil.ExitCodeReady,
+		},
+		{
+			name: "Certificate expiring within the threshold",
+			cert: &x509.Certificate{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(24 * time.Hour)},
+			want: cmcmdutil.ExitCodeExpiringSoon,
+		},
+		{
+			name: "Certificate not valid yet",
+			cert: &x509.Certificate{NotBefore: now.Add(time.Hour), NotAfter: now.Add(30 * 24 * time.Hour)},
+			want: cmcmdutil.ExitCodeNotReady,
+		},
+		{
+			name: "Expired certificate",
+			cert: &x509.Certificate{NotBefore: now.Add(-2 * time.Hour), NotAfter: now.Add(-time.Hour)},
+			want: cmcmdutil.ExitCodeExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCodeForCertificate(tt.cert, now, 7*24*time.Hour); got != tt.want {
+				t.Errorf("ExitCodeForCertificate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_describeValidFor(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// SecretSourcePrefix is the prefix of the arguments of the inspect commands
+// that refer to a Secret instead of a file.
+const SecretSourcePrefix = "secret/"
+
+// ReadFile returns the content of the file at path, or of in if path is "-".
+func ReadFile(in io.Reader, path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(in)
+		if err != nil {
+			return nil, fmt.Errorf("error when reading stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- the user chooses the file to inspect
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %q: %w", path, err)
+	}
+	return data, nil
+}
+
+// ParseResourceSource parses source in the form <prefix>[namespace/]name, e.g.
+// secret/[namespace/]name with SecretSourcePrefix. found is false if source is
+// not prefixed with prefix, e.g. because it is a path. namespace is empty if
+// source does not contain one.
+func ParseResourceSource(source, prefix string) (namespace, name string, found bool, err error) {
+	ref, found := strings.CutPrefix(source, prefix)
+	if !found {
+		return "", "", false, nil
+	}
+
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return "", parts[0], true, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], true, nil
+	default:
+		return "", "", true, fmt.Errorf("invalid argument %q, expected %s[namespace/]name", source, prefix)
+	}
+}
+
+// DecodeCertificates decodes the certificates in data with DecodeBundle, and
+// parses them. source is where data was read from, used in errors.
+func DecodeCertificates(data []byte, source string) ([]*x509.Certificate, error) {
+	bundle, err := DecodeBundle(data, "")
+	if err != nil {
+		return nil, fmt.Errorf("error when decoding %q: %w", source, err)
+	}
+
+	certs := make([]*x509.Certificate, 0, len(bundle.Certificates))
+	for i, certData := range bundle.Certificates {
+		cert, err := pki.DecodeX509CertificateBytes(certData)
+		if err != nil {
+			return nil, fmt.Errorf("error when parsing certificate %d of %q: %w", i, source, err)
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResourceSource(t *testing.T) {
+	tests := map[string]struct {
+		source       string
+		expNamespace string
+		expName      string
+		expFound     bool
+		expErr       string
+	}{
+		"Path is not a resource": {
+			source:   "tls.crt",
+			expFound: false,
+		},
+		"Name only": {
+			source:   "secret/my-tls",
+			expName:  "my-tls",
+			expFound: true,
+		},
+		"Namespace and name": {
+			source:       "secret/my-namespace/my-tls",
+			expNamespace: "my-namespace",
+			expName:      "my-tls",
+			expFound:     true,
+		},
+		"Empty name": {
+			source:   "secret/",
+			expFound: true,
+			expErr:   `invalid argument "secret/", expected secret/[namespace/]name`,
+		},
+		"Too many parts": {
+			source:   "secret/a/b/c",
+			expFound: true,
+			expErr:   `invalid argument "secret/a/b/c", expected secret/[namespace/]name`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			namespace, name, found, err := ParseResourceSource(test.source, SecretSourcePrefix)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expNamespace, namespace)
+			assert.Equal(t, test.expName, name)
+			assert.Equal(t, test.expFound, found)
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	data, err := ReadFile(strings.NewReader("from stdin"), "-")
+	assert.NoError(t, err)
+	assert.Equal(t, "from stdin", string(data))
+
+	_, err = ReadFile(nil, "does-not-exist.crt")
+	assert.ErrorContains(t, err, `error when reading "does-not-exist.crt"`)
+}
+
+func TestDecodeCertificates(t *testing.T) {
+	certs, err := DecodeCertificates([]byte(testCertForFingerprinting), "tls.crt")
+	if assert.NoError(t, err) && assert.Len(t, certs, 1) {
+		assert.Equal(t, "cert-manager", certs[0].Subject.OrganizationalUnit[0])
+	}
+
+	_, err = DecodeCertificates([]byte("invalid"), "tls.crt")
+	assert.ErrorContains(t, err, `error when decoding "tls.crt"`)
+}
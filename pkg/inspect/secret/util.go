@@ -18,46 +18,27 @@ package secret
 
 import (
 	"bytes"
-	"context"
-	"crypto"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/pem"
-	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"strings"
-	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	"golang.org/x/crypto/ocsp"
 )
 
-const (
-	// revocationRequestTimeout bounds the total time spent contacting a single
-	// revocation endpoint (an OCSP responder or CRL distribution point)
-	revocationRequestTimeout = 10 * time.Second
-
-	// maxRevocationResponseSize caps how many bytes we read from a revocation endpoint.
-	maxRevocationResponseSize = 1 << 20 // 1 MiB
-)
-
-// revocationHTTPClient is used for all outbound OCSP and CRL requests.
-var revocationHTTPClient = &http.Client{
-	Timeout: revocationRequestTimeout,
-	CheckRedirect: func(req *http.Request, via []*http.Request) error {
-		return http.ErrUseLastResponse
-	},
-}
-
 func fingerprintCert(cert *x509.Certificate) string {
 	if cert == nil {
 		return ""
 	}
 	fingerprint := sha256.Sum256(cert.Raw)
 
+	return formatFingerprint(fingerprint[:])
+}
+
+// formatFingerprint returns fingerprint as upper case hex encoded bytes,
+// separated by colons.
+func formatFingerprint(fingerprint []byte) string {
 	var buf bytes.Buffer
 	for i, f := range fingerprint {
 		if i > 0 {
@@ -69,89 +50,6 @@ func fingerprintCert(cert *x509.Certificate) string {
 	return buf.String()
 }
 
-func checkOCSPValidCert(ctx context.Context, leafCert, issuerCert *x509.Certificate) (bool, error) {
-	if len(leafCert.OCSPServer) < 1 {
-		return false, errors.New("No OCSP Server set")
-	}
-	buffer, err := ocsp.CreateRequest(leafCert, issuerCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
-	if err != nil {
-		return false, fmt.Errorf("error creating OCSP request: %w", err)
-	}
-
-	for _, ocspServer := range leafCert.OCSPServer {
-		ocspUrl, err := url.Parse(ocspServer)
-		if err != nil {
-			return false, fmt.Errorf("error parsing OCSP URL: %w", err)
-		}
-		// The OCSP URL comes from the untrusted certificate under inspection, so
-		// restrict it to HTTP(S) rather than dereferencing arbitrary schemes.
-		if ocspUrl.Scheme != "http" && ocspUrl.Scheme != "https" {
-			return false, fmt.Errorf("unsupported OCSP URL scheme %q, only http and https are supported", ocspUrl.Scheme)
-		}
-
-		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, ocspServer, bytes.NewBuffer(buffer))
-		if err != nil {
-			return false, fmt.Errorf("error creating HTTP request: %w", err)
-		}
-		httpRequest.Header.Add("Content-Type", "application/ocsp-request")
-		httpRequest.Header.Add("Accept", "application/ocsp-response")
-		httpRequest.Header.Add("Host", ocspUrl.Host)
-		httpResponse, err := revocationHTTPClient.Do(httpRequest) // #nosec G704 -- URL scheme restricted to http(s), redirects disabled, response size and timeout bounded
-		if err != nil {
-			return false, fmt.Errorf("error making HTTP request: %w", err)
-		}
-		defer httpResponse.Body.Close()
-		output, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxRevocationResponseSize))
-		if err != nil {
-			return false, fmt.Errorf("error reading HTTP body: %w", err)
-		}
-		ocspResponse, err := ocsp.ParseResponse(output, issuerCert)
-		if err != nil {
-			return false, fmt.Errorf("error reading OCSP response: %w", err)
-		}
-
-		if ocspResponse.Status == ocsp.Revoked {
-			// one OCSP revoked it do not trust
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
-func checkCRLValidCert(ctx context.Context, cert *x509.Certificate, url string) (bool, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return false, fmt.Errorf("error creating HTTP request: %w", err)
-	}
-
-	resp, err := revocationHTTPClient.Do(req) // #nosec G704 -- CRL URL scheme validated by caller, redirects disabled, response size and timeout bounded
-	if err != nil {
-		return false, fmt.Errorf("error getting HTTP response: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRevocationResponseSize))
-	if err != nil {
-		return false, fmt.Errorf("error reading HTTP body: %w", err)
-	}
-
-	crl, err := x509.ParseRevocationList(body)
-	if err != nil {
-		return false, fmt.Errorf("error parsing HTTP body: %w", err)
-	}
-
-	// TODO: check CRL signature
-
-	for _, revoked := range crl.RevokedCertificateEntries {
-		if cert.SerialNumber.Cmp(revoked.SerialNumber) == 0 {
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
 func printSlice(in []string) string {
 	if len(in) < 1 {
 		return "<none>"
@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// CheckStatus is the outcome of a verification check.
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "Passed"
+	CheckFailed  CheckStatus = "Failed"
+	CheckSkipped CheckStatus = "Skipped"
+)
+
+// Names of the verification checks, in the order they are run.
+const (
+	checkKeyMatches     = "Private key matches certificate"
+	checkKeyType        = "Private key type"
+	checkChainOrder     = "Chain order"
+	checkChainDuplicate = "Chain duplicates"
+	checkChainComplete  = "Chain complete"
+	checkChainAnchored  = "Chain anchored by ca.crt"
+)
+
+// VerificationCheck is the result of a single consistency check of the
+// private key or the certificate chain.
+type VerificationCheck struct {
+	Name   string      `json:"name"`
+	Status CheckStatus `json:"status"`
+	// Message explains why the check failed or was skipped
+	Message string `json:"message,omitempty"`
+}
+
+// Verification is the result of the consistency checks of the private key,
+// the certificate chain and the CA certificates of a Secret.
+type Verification struct {
+	Checks []VerificationCheck `json:"checks"`
+}
+
+// Failed returns true if any of the checks failed.
+func (v *Verification) Failed() bool {
+	for _, check := range v.Checks {
+		if check.Status == CheckFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the verification as a section of the human readable output
+func (v *Verification) String() string {
+	var b strings.Builder
+	b.WriteString("Verification:")
+	for _, check := range v.Checks {
+		fmt.Fprintf(&b, "\n\t%s:\t%s", check.Name, check.Status)
+		if check.Message != "" {
+			fmt.Fprintf(&b, ": %s", check.Message)
+		}
+	}
+	return b.String()
+}
+
+// VerifyChain checks that keyData, the PEM encoded private key, belongs to the
+// leaf certificate of chain and that the chain is correctly ordered, complete
+// and anchored by ca. chain is the parsed content of 'tls.crt', leaf
+// certificate first. The checks of keyData and ca are skipped if they are
+// empty.
+func VerifyChain(chain []*x509.Certificate, keyData []byte, ca []byte) *Verification {
+	v := &Verification{}
+	add := func(name string, status CheckStatus, format string, args ...any) {
+		v.Checks = append(v.Checks, VerificationCheck{Name: name, Status: status, Message: fmt.Sprintf(format, args...)})
+	}
+
+	leaf := chain[0]
+	if len(keyData) == 0 {
+		add(checkKeyMatches, CheckSkipped, "no private key in tls.key")
+		add(checkKeyType, CheckSkipped, "no private key in tls.key")
+	} else if key, err := pki.DecodePrivateKeyBytes(keyData); err != nil {
+		add(checkKeyMatches, CheckFailed, "cannot decode tls.key: %s", err)
+		add(checkKeyType, CheckSkipped, "cannot decode tls.key")
+	} else {
+		switch matches, err := pki.PublicKeyMatchesCertificate(key.Public(), leaf); {
+		case err != nil:
+			add(checkKeyMatches, CheckFailed, "%s", err)
+		case !matches:
+			add(checkKeyMatches, CheckFailed, "tls.key is not the private key of the leaf certificate")
+		default:
+			add(checkKeyMatches, CheckPassed, "")
+		}
+
+		keyType, certType := PublicKeyType(key.Public()), PublicKeyType(leaf.PublicKey)
+		if keyType == certType {
+			add(checkKeyType, CheckPassed, "%s", keyType)
+		} else {
+			add(checkKeyType, CheckFailed, "tls.key is %s, the leaf certificate has a %s public key", keyType, certType)
+		}
+	}
+
+	var orderProblems, duplicates []string
+	for i := range chain {
+		for j := range i {
+			if bytes.Equal(chain[i].Raw, chain[j].Raw) {
+				duplicates = append(duplicates, fmt.Sprintf("certificate %d is a duplicate of certificate %d", i, j))
+				break
+			}
+		}
+		if i == len(chain)-1 {
+			break
+		}
+		if !issuedBy(chain[i], chain[i+1]) {
+			problem := fmt.Sprintf("certificate %d (%s) is not issued by the next certificate %d (%s)", i, describeName(chain[i]), i+1, describeName(chain[i+1]))
+			for j := range chain {
+				if j != i && j != i+1 && issuedBy(chain[i], chain[j]) {
+					problem += fmt.Sprintf(" but by certificate %d", j)
+					break
+				}
+			}
+			orderProblems = append(orderProblems, problem)
+		}
+	}
+	addProblems := func(name string, problems []string) {
+		if len(problems) == 0 {
+			add(name, CheckPassed, "")
+		} else {
+			add(name, CheckFailed, "%s", strings.Join(problems, "; "))
+		}
+	}
+	addProblems(checkChainOrder, orderProblems)
+	addProblems(checkChainDuplicate, duplicates)
+
+	caCerts, caErr := pki.DecodeX509CertificateSetBytes(ca)
+	last := chain[len(chain)-1]
+	anchor := -1
+	for i, caCert := range caCerts {
+		if bytes.Equal(caCert.Raw, last.Raw) || issuedBy(last, caCert) {
+			anchor = i
+			break
+		}
+	}
+
+	switch {
+	case isSelfSigned(last), anchor >= 0, issuedBySystemRoot(last):
+		add(checkChainComplete, CheckPassed, "")
+	default:
+		add(checkChainComplete, CheckFailed, "the issuer %q of the last certificate is neither in tls.crt, nor in ca.crt, nor a trusted root of this computer", last.Issuer.String())
+	}
+
+	switch {
+	case len(ca) == 0:
+		add(checkChainAnchored, CheckSkipped, "no ca.crt")
+	case caErr != nil:
+		add(checkChainAnchored, CheckFailed, "cannot decode ca.crt: %s", caErr)
+	case anchor < 0:
+		add(checkChainAnchored, CheckFailed, "the last certificate (%s) is not issued by a certificate in ca.crt", describeName(last))
+	default:
+		add(checkChainAnchored, CheckPassed, "")
+	}
+
+	return v
+}
+
+// issuedBy returns true if cert was signed by the key of issuer.
+func issuedBy(cert, issuer *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, issuer.RawSubject) && cert.CheckSignatureFrom(issuer) == nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// issuedBySystemRoot returns true if cert is signed by a root of the system
+// CA store.
+func issuedBySystemRoot(cert *x509.Certificate) bool {
+	systemPool, err := x509.SystemCertPool()
+	if err != nil {
+		return false
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:       systemPool,
+		CurrentTime: cert.NotBefore,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+func describeName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	return cert.Subject.String()
+}
+
+// PublicKeyType returns the algorithm and size of key, e.g. "ECDSA 256".
+func PublicKeyType(key crypto.PublicKey) string {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", k.N.BitLen())
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("ECDSA %d", k.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return fmt.Sprintf("unknown (%T)", key)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testKeyPair struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+	keyPEM  []byte
+}
+
+// newTestKeyPair returns a certificate with an ECDSA key, signed by issuer or
+// self-signed if issuer is nil.
+func newTestKeyPair(t *testing.T, commonName string, isCA bool, issuer *testKeyPair) *testKeyPair {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodePrivateKey(key, cmapi.PKCS8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template, err := pki.CertificateTemplateFromCertificate(gen.Certificate(commonName,
+		gen.SetCertificateCommonName(commonName),
+		gen.SetCertificateIsCA(isCA),
+		gen.SetCertificateNotBefore(metav1.Time{Time: time.Now().Add(-time.Hour)}),
+		gen.SetCertificateNotAfter(metav1.Time{Time: time.Now().Add(time.Hour)})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuerCert, issuerKey := template, crypto.Signer(key)
+	if issuer != nil {
+		issuerCert, issuerKey = issuer.cert, issuer.key
+	}
+	certPEM, cert, err := pki.SignCertificate(template, issuerCert, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testKeyPair{cert: cert, certPEM: certPEM, key: key, keyPEM: keyPEM}
+}
+
+func TestVerifyChain(t *testing.T) {
+	root := newTestKeyPair(t, "root", true, nil)
+	otherRoot := newTestKeyPair(t, "other-root", true, nil)
+	intermediate := newTestKeyPair(t, "intermediate", true, root)
+	leaf := newTestKeyPair(t, "leaf", false, intermediate)
+	otherLeaf := newTestKeyPair(t, "other-leaf", false, intermediate)
+
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKeyPEM, err := pki.EncodePrivateKey(rsaKey, cmapi.PKCS8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		chain       []*x509.Certificate
+		key         []byte
+		ca          []byte
+		expStatuses map[string]CheckStatus
+		expFailed   bool
+	}{
+		"Complete chain anchored by ca.crt": {
+			chain: []*x509.Certificate{leaf.cert, intermediate.cert},
+			key:   leaf.keyPEM,
+			ca:    root.certPEM,
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckPassed,
+				checkKeyType:        CheckPassed,
+				checkChainOrder:     CheckPassed,
+				checkChainDuplicate: CheckPassed,
+				checkChainComplete:  CheckPassed,
+				checkChainAnchored:  CheckPassed,
+			},
+		},
+		"Chain up to the root without key and ca.crt": {
+			chain: []*x509.Certificate{leaf.cert, intermediate.cert, root.cert},
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckSkipped,
+				checkKeyType:        CheckSkipped,
+				checkChainOrder:     CheckPassed,
+				checkChainDuplicate: CheckPassed,
+				checkChainComplete:  CheckPassed,
+				checkChainAnchored:  CheckSkipped,
+			},
+		},
+		"Missing intermediate": {
+			chain: []*x509.Certificate{leaf.cert},
+			key:   leaf.keyPEM,
+			ca:    root.certPEM,
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckPassed,
+				checkKeyType:        CheckPassed,
+				checkChainOrder:     CheckPassed,
+				checkChainDuplicate: CheckPassed,
+				checkChainComplete:  CheckFailed,
+				checkChainAnchored:  CheckFailed,
+			},
+			expFailed: true,
+		},
+		"Misordered chain": {
+			chain: []*x509.Certificate{intermediate.cert, leaf.cert},
+			key:   leaf.keyPEM,
+			ca:    root.certPEM,
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckFailed,
+				checkKeyType:        CheckPassed,
+				checkChainOrder:     CheckFailed,
+				checkChainDuplicate: CheckPassed,
+				checkChainComplete:  CheckFailed,
+				checkChainAnchored:  CheckFailed,
+			},
+			expFailed: true,
+		},
+		"Duplicated intermediate": {
+			chain: []*x509.Certificate{leaf.cert, intermediate.cert, intermediate.cert},
+			key:   leaf.keyPEM,
+			ca:    root.certPEM,
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckPassed,
+				checkKeyType:        CheckPassed,
+				checkChainOrder:     CheckFailed,
+				checkChainDuplicate: CheckFailed,
+				checkChainComplete:  CheckPassed,
+				checkChainAnchored:  CheckPassed,
+			},
+			expFailed: true,
+		},
+		"Private key of another certificate": {
+			chain: []*x509.Certificate{leaf.cert, intermediate.cert},
+			key:   otherLeaf.keyPEM,
+			ca:    root.certPEM,
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckFailed,
+				checkKeyType:        CheckPassed,
+				checkChainOrder:     CheckPassed,
+				checkChainDuplicate: CheckPassed,
+				checkChainComplete:  CheckPassed,
+				checkChainAnchored:  CheckPassed,
+			},
+			expFailed: true,
+		},
+		"Private key of another type": {
+			chain: []*x509.Certificate{leaf.cert, intermediate.cert},
+			key:   rsaKeyPEM,
+			ca:    root.certPEM,
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckFailed,
+				checkKeyType:        CheckFailed,
+				checkChainOrder:     CheckPassed,
+				checkChainDuplicate: CheckPassed,
+				checkChainComplete:  CheckPassed,
+				checkChainAnchored:  CheckPassed,
+			},
+			expFailed: true,
+		},
+		"ca.crt of another CA": {
+			chain: []*x509.Certificate{leaf.cert, intermediate.cert},
+			key:   leaf.keyPEM,
+			ca:    otherRoot.certPEM,
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckPassed,
+				checkKeyType:        CheckPassed,
+				checkChainOrder:     CheckPassed,
+				checkChainDuplicate: CheckPassed,
+				checkChainComplete:  CheckFailed,
+				checkChainAnchored:  CheckFailed,
+			},
+			expFailed: true,
+		},
+		"Invalid private key": {
+			chain: []*x509.Certificate{leaf.cert, intermediate.cert},
+			key:   []byte("not a key"),
+			ca:    append(append([]byte{}, intermediate.certPEM...), root.certPEM...),
+			expStatuses: map[string]CheckStatus{
+				checkKeyMatches:     CheckFailed,
+				checkKeyType:        CheckSkipped,
+				checkChainOrder:     CheckPassed,
+				checkChainDuplicate: CheckPassed,
+				checkChainComplete:  CheckPassed,
+				checkChainAnchored:  CheckPassed,
+			},
+			expFailed: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := VerifyChain(test.chain, test.key, test.ca)
+
+			statuses := map[string]CheckStatus{}
+			for _, check := range v.Checks {
+				statuses[check.Name] = check.Status
+				if check.Status != CheckPassed {
+					assert.NotEmpty(t, check.Message, "check %q", check.Name)
+				}
+			}
+			assert.Equal(t, test.expStatuses, statuses)
+			assert.Equal(t, test.expFailed, v.Failed())
+		})
+	}
+}
+
+func TestVerificationString(t *testing.T) {
+	v := &Verification{Checks: []VerificationCheck{
+		{Name: checkKeyMatches, Status: CheckPassed},
+		{Name: checkChainOrder, Status: CheckFailed, Message: "certificate 0 (a) is not issued by the next certificate 1 (b)"},
+		{Name: checkChainAnchored, Status: CheckSkipped, Message: "no ca.crt"},
+	}}
+
+	assert.Equal(t, "Verification:\n"+
+		"\tPrivate key matches certificate:\tPassed\n"+
+		"\tChain order:\tFailed: certificate 0 (a) is not issued by the next certificate 1 (b)\n"+
+		"\tChain anchored by ca.crt:\tSkipped: no ca.crt", v.String())
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// FilterOptions holds the flags to filter the selected Certificates by their
+// live state. A Certificate is only renewed if it matches all filters that
+// are set.
+type FilterOptions struct {
+	// ExpiringWithin selects Certificates whose certificate expires within
+	// the duration. Certificates that have not been issued yet never match.
+	ExpiringWithin time.Duration
+	// Issuer selects Certificates referencing the issuer with this name
+	Issuer string
+	// IssuerKind selects Certificates referencing an issuer of this kind,
+	// e.g. Issuer or ClusterIssuer
+	IssuerKind string
+	// NotReady selects Certificates that are not Ready
+	NotReady bool
+	// FailedIssuance selects Certificates with failed issuance attempts
+	FailedIssuance bool
+	// IssuedBefore selects Certificates whose certificate was issued before
+	// this time, formatted as RFC 3339 or as a date (2006-01-02)
+	IssuedBefore string
+	// IssuerDN selects Certificates whose issued certificate was issued by
+	// the CA with this distinguished name, in the RFC 2253 form, e.g.
+	// CN=My CA,O=Example
+	IssuerDN string
+	// AuthorityKeyID selects Certificates whose issued certificate has this
+	// authority key identifier, hex encoded with optional colons
+	AuthorityKeyID string
+	// FieldSelector selects Certificates by the values of their name,
+	// namespace and spec fields, e.g. spec.issuerRef.name=my-ca. Nested fields
+	// are separated by dots, list values are joined by commas, which have to
+	// be escaped as '\,' in the selector.
+	FieldSelector string
+}
+
+// AddFlags adds the filter flags to cmd.
+func (o *FilterOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&o.ExpiringWithin, "expiring-within", o.ExpiringWithin, "Only renew Certificates whose certificate expires within the duration, must include unit, e.g. 720h")
+	cmd.Flags().StringVar(&o.Issuer, "issuer", o.Issuer, "Only renew Certificates referencing the issuer with this name.")
+	cmd.Flags().StringVar(&o.IssuerKind, "issuer-kind", o.IssuerKind, "Only renew Certificates referencing an issuer of this kind, e.g. Issuer or ClusterIssuer.")
+	cmd.Flags().BoolVar(&o.NotReady, "not-ready", o.NotReady, "Only renew Certificates that are not Ready.")
+	cmd.Flags().BoolVar(&o.FailedIssuance, "failed-issuance", o.FailedIssuance, "Only renew Certificates with failed issuance attempts (status.failedIssuanceAttempts).")
+	cmd.Flags().StringVar(&o.IssuedBefore, "issued-before", o.IssuedBefore, "Only renew Certificates whose certificate was issued before this time, formatted as RFC 3339 (e.g. 2026-01-02T15:04:05Z) or as a date (e.g. 2026-01-02).")
+	cmd.Flags().StringVar(&o.IssuerDN, "issuer-dn", o.IssuerDN, "Only renew Certificates whose certificate stored in the Secret was issued by the CA with this distinguished name, in the RFC 2253 form (e.g. 'CN=My CA,O=Example').")
+	cmd.Flags().StringVar(&o.AuthorityKeyID, "authority-key-id", o.AuthorityKeyID, "Only renew Certificates whose certificate stored in the Secret has this authority key identifier, hex encoded with optional colons (e.g. 0A:1B:2C).")
+	cmd.Flags().StringVar(&o.FieldSelector, "field-selector", o.FieldSelector, "Selector (field query) on the metadata.name, metadata.namespace and spec fields of the Certificates, supports '=', '==', and '!='. List values are joined by commas, escaped as '\\,'.(e.g. --field-selector spec.issuerRef.name=my-ca,spec.isCA=false)")
+}
+
+// ToFilter returns a Filter configured by the options. The time, authority key
+// identifier and field selector are parsed. A nil FilterOptions returns a
+// Filter matching all Certificates.
+func (o *FilterOptions) ToFilter() (*Filter, error) {
+	if o == nil {
+		return &Filter{}, nil
+	}
+
+	if o.ExpiringWithin < 0 {
+		return nil, fmt.Errorf("--expiring-within must not be negative, got %s", o.ExpiringWithin)
+	}
+
+	f := &Filter{options: *o}
+
+	if o.IssuedBefore != "" {
+		var err error
+		if f.issuedBefore, err = time.Parse(time.RFC3339, o.IssuedBefore); err != nil {
+			if f.issuedBefore, err = time.Parse(time.DateOnly, o.IssuedBefore); err != nil {
+				return nil, fmt.Errorf("invalid --issued-before %q, expected a time formatted as RFC 3339 (e.g. 2026-01-02T15:04:05Z) or a date (e.g. 2026-01-02)", o.IssuedBefore)
+			}
+		}
+	}
+
+	if o.AuthorityKeyID != "" {
+		var err error
+		if f.authorityKeyID, err = hex.DecodeString(strings.ReplaceAll(o.AuthorityKeyID, ":", "")); err != nil || len(f.authorityKeyID) == 0 {
+			return nil, fmt.Errorf("invalid --authority-key-id %q, expected a hex encoded key identifier (e.g. 0A:1B:2C)", o.AuthorityKeyID)
+		}
+	}
+
+	if o.FieldSelector != "" {
+		var err error
+		if f.fieldSelector, err = fields.ParseSelector(o.FieldSelector); err != nil {
+			return nil, fmt.Errorf("invalid --field-selector %q: %w", o.FieldSelector, err)
+		}
+		for _, requirement := range f.fieldSelector.Requirements() {
+			if requirement.Field != "metadata.name" && requirement.Field != "metadata.namespace" && !strings.HasPrefix(requirement.Field, "spec.") {
+				return nil, fmt.Errorf("invalid --field-selector %q: only metadata.name, metadata.namespace and spec fields are supported, got %q", o.FieldSelector, requirement.Field)
+			}
+		}
+	}
+
+	return f, nil
+}
+
+// Filter selects Certificates by their live state.
+type Filter struct {
+	options        FilterOptions
+	issuedBefore   time.Time
+	authorityKeyID []byte
+	fieldSelector  fields.Selector
+}
+
+// NeedsCertificate returns true if the filter matches on the issuing CA of the
+// certificate stored in the Secret of a Certificate, which then has to be
+// passed to Matches.
+func (f *Filter) NeedsCertificate() bool {
+	return f.options.IssuerDN != "" || len(f.authorityKeyID) > 0
+}
+
+// Matches returns true if crt matches all filters that are set. Otherwise it
+// returns false and the reason crt does not match. cert is the certificate
+// stored in the Secret of crt, nil if there is none; it is only used if
+// NeedsCertificate returns true. now is the time the expiry of the
+// certificate is compared against.
+func (f *Filter) Matches(crt *cmapi.Certificate, cert *x509.Certificate, now time.Time) (bool, string) {
+	if f.options.ExpiringWithin > 0 {
+		if crt.Status.NotAfter == nil {
+			return false, "not issued yet"
+		}
+		if crt.Status.NotAfter.After(now.Add(f.options.ExpiringWithin)) {
+			return false, fmt.Sprintf("expires at %s, not within %s", crt.Status.NotAfter.UTC().Format(time.RFC3339), f.options.ExpiringWithin)
+		}
+	}
+
+	if f.options.Issuer != "" && crt.Spec.IssuerRef.Name != f.options.Issuer {
+		return false, fmt.Sprintf("references issuer %q, not %q", crt.Spec.IssuerRef.Name, f.options.Issuer)
+	}
+
+	if f.options.IssuerKind != "" {
+		issuerKind := crt.Spec.IssuerRef.Kind
+		if issuerKind == "" {
+			issuerKind = "Issuer"
+		}
+		if !strings.EqualFold(issuerKind, f.options.IssuerKind) {
+			return false, fmt.Sprintf("references an issuer of kind %q, not %q", issuerKind, f.options.IssuerKind)
+		}
+	}
+
+	if f.options.NotReady && apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return false, "is Ready"
+	}
+
+	if f.options.FailedIssuance && (crt.Status.FailedIssuanceAttempts == nil || *crt.Status.FailedIssuanceAttempts == 0) {
+		return false, "has no failed issuance attempts"
+	}
+
+	if !f.issuedBefore.IsZero() {
+		if crt.Status.NotBefore == nil {
+			return false, "not issued yet"
+		}
+		if !crt.Status.NotBefore.Time.Before(f.issuedBefore) {
+			return false, fmt.Sprintf("issued at %s, not before %s", crt.Status.NotBefore.UTC().Format(time.RFC3339), f.options.IssuedBefore)
+		}
+	}
+
+	if f.NeedsCertificate() && cert == nil {
+		return false, fmt.Sprintf("Secret %q contains no valid certificate", crt.Spec.SecretName)
+	}
+
+	if f.options.IssuerDN != "" && cert.Issuer.String() != f.options.IssuerDN {
+		return false, fmt.Sprintf("issued by %q, not %q", cert.Issuer.String(), f.options.IssuerDN)
+	}
+
+	if len(f.authorityKeyID) > 0 && !bytes.Equal(cert.AuthorityKeyId, f.authorityKeyID) {
+		return false, fmt.Sprintf("has authority key identifier %s, not %s", formatKeyID(cert.AuthorityKeyId), formatKeyID(f.authorityKeyID))
+	}
+
+	if f.fieldSelector != nil && !f.fieldSelector.Matches(certificateFields(crt)) {
+		return false, fmt.Sprintf("does not match --field-selector %q", f.options.FieldSelector)
+	}
+
+	return true, ""
+}
+
+// formatKeyID returns id as colon separated upper case hex, as printed by the
+// inspect secret command, or <none> if id is empty.
+func formatKeyID(id []byte) string {
+	if len(id) == 0 {
+		return "<none>"
+	}
+	parts := make([]string, len(id))
+	for i, b := range id {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// certificateFields returns the name, namespace and the scalar spec fields of
+// crt, keyed by their path, e.g. spec.issuerRef.name. Lists of scalars are
+// joined by commas, lists of objects are left out.
+func certificateFields(crt *cmapi.Certificate) fields.Set {
+	set := fields.Set{
+		"metadata.name":      crt.Name,
+		"metadata.namespace": crt.Namespace,
+	}
+
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&crt.Spec)
+	if err != nil {
+		return set
+	}
+	flattenFields(set, "spec", spec)
+	return set
+}
+
+func flattenFields(set fields.Set, path string, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			flattenFields(set, path+"."+key, item)
+		}
+	case []any:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			switch item.(type) {
+			case map[string]any, []any:
+				return
+			}
+			items = append(items, fmt.Sprint(item))
+		}
+		set[path] = strings.Join(items, ",")
+	case nil:
+	default:
+		set[path] = fmt.Sprint(value)
+	}
+}
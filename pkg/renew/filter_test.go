@@ -0,0 +1,331 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+func TestFilterOptionsToFilter(t *testing.T) {
+	tests := map[string]struct {
+		options *FilterOptions
+		expErr  string
+	}{
+		"No filters": {
+			options: &FilterOptions{},
+		},
+		"Nil options": {
+			options: nil,
+		},
+		"All filters": {
+			options: &FilterOptions{
+				ExpiringWithin: 720 * time.Hour,
+				Issuer:         "intermediate-ca",
+				IssuerKind:     "ClusterIssuer",
+				NotReady:       true,
+				FailedIssuance: true,
+				IssuedBefore:   "2026-10-01T00:00:00Z",
+				IssuerDN:       "CN=Example Intermediate CA,O=Example",
+				AuthorityKeyID: "0A:1B:2C",
+				FieldSelector:  "metadata.name!=my-crt,spec.isCA=false",
+			},
+		},
+		"Issued before a date": {
+			options: &FilterOptions{IssuedBefore: "2026-10-01"},
+		},
+		"Negative expiring within": {
+			options: &FilterOptions{ExpiringWithin: -time.Hour},
+			expErr:  "--expiring-within must not be negative, got -1h0m0s",
+		},
+		"Invalid issued before": {
+			options: &FilterOptions{IssuedBefore: "yesterday"},
+			expErr:  `invalid --issued-before "yesterday", expected a time formatted as RFC 3339 (e.g. 2026-01-02T15:04:05Z) or a date (e.g. 2026-01-02)`,
+		},
+		"Authority key ID without colons": {
+			options: &FilterOptions{AuthorityKeyID: "0a1b2c"},
+		},
+		"Invalid authority key ID": {
+			options: &FilterOptions{AuthorityKeyID: "0A:1B:2"},
+			expErr:  `invalid --authority-key-id "0A:1B:2", expected a hex encoded key identifier (e.g. 0A:1B:2C)`,
+		},
+		"Field selector on status": {
+			options: &FilterOptions{FieldSelector: "status.revision=1"},
+			expErr:  `invalid --field-selector "status.revision=1": only metadata.name, metadata.namespace and spec fields are supported, got "status.revision"`,
+		},
+		"Invalid field selector": {
+			options: &FilterOptions{FieldSelector: "spec.isCA"},
+			expErr:  `invalid --field-selector "spec.isCA": invalid selector: 'spec.isCA'; can't understand 'spec.isCA'`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.options.ToFilter()
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	ready := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}
+
+	crt := gen.Certificate("my-crt",
+		gen.SetCertificateNamespace("my-namespace"),
+		gen.SetCertificateDNSNames("example.com", "www.example.com"),
+		gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "intermediate-ca", Kind: "ClusterIssuer"}),
+		gen.SetCertificateNotBefore(metav1.NewTime(now.Add(-60*24*time.Hour))),
+		gen.SetCertificateNotAfter(metav1.NewTime(now.Add(30*24*time.Hour))),
+		gen.SetCertificateSecretName("my-crt-tls"),
+		gen.SetCertificateStatusCondition(ready))
+	cert := &x509.Certificate{
+		Issuer:         pkix.Name{CommonName: "Example Intermediate CA", Organization: []string{"Example"}},
+		AuthorityKeyId: []byte{0x0a, 0x1b, 0x2c},
+	}
+
+	tests := map[string]struct {
+		options *FilterOptions
+		crt     *cmapi.Certificate
+		// cert is the certificate stored in the Secret of crt
+		cert      *x509.Certificate
+		expMatch  bool
+		expReason string
+	}{
+		"No filters": {
+			options:  &FilterOptions{},
+			crt:      crt,
+			expMatch: true,
+		},
+		"Nil options": {
+			options:  nil,
+			crt:      crt,
+			expMatch: true,
+		},
+		"Expiring within": {
+			options:  &FilterOptions{ExpiringWithin: 720 * time.Hour},
+			crt:      crt,
+			expMatch: true,
+		},
+		"Not expiring within": {
+			options:   &FilterOptions{ExpiringWithin: 24 * time.Hour},
+			crt:       crt,
+			expMatch:  false,
+			expReason: "expires at 2026-11-15T00:00:00Z, not within 24h0m0s",
+		},
+		"Expiring within never matches before the first issuance": {
+			options:   &FilterOptions{ExpiringWithin: 720 * time.Hour},
+			crt:       gen.Certificate("my-crt"),
+			expMatch:  false,
+			expReason: "not issued yet",
+		},
+		"Issuer name and kind": {
+			options:  &FilterOptions{Issuer: "intermediate-ca", IssuerKind: "clusterissuer"},
+			crt:      crt,
+			expMatch: true,
+		},
+		"Other issuer": {
+			options:   &FilterOptions{Issuer: "other-ca"},
+			crt:       crt,
+			expMatch:  false,
+			expReason: "references issuer \"intermediate-ca\", not \"other-ca\"",
+		},
+		"Issuer kind defaults to Issuer": {
+			options:  &FilterOptions{IssuerKind: "Issuer"},
+			crt:      gen.Certificate("my-crt", gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "my-ca"})),
+			expMatch: true,
+		},
+		"Not ready excludes Ready Certificates": {
+			options:   &FilterOptions{NotReady: true},
+			crt:       crt,
+			expMatch:  false,
+			expReason: "is Ready",
+		},
+		"Not ready": {
+			options: &FilterOptions{NotReady: true},
+			crt: gen.CertificateFrom(crt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse,
+			})),
+			expMatch: true,
+		},
+		"Failed issuance": {
+			options:  &FilterOptions{FailedIssuance: true},
+			crt:      gen.CertificateFrom(crt, gen.SetCertificateIssuanceAttempts(ptr.To(2))),
+			expMatch: true,
+		},
+		"No failed issuance": {
+			options:   &FilterOptions{FailedIssuance: true},
+			crt:       crt,
+			expMatch:  false,
+			expReason: "has no failed issuance attempts",
+		},
+		"Issued before": {
+			options:  &FilterOptions{IssuedBefore: "2026-10-01"},
+			crt:      crt,
+			expMatch: true,
+		},
+		"Issued after": {
+			options:   &FilterOptions{IssuedBefore: "2026-08-01T00:00:00Z"},
+			crt:       crt,
+			expMatch:  false,
+			expReason: "issued at 2026-08-17T00:00:00Z, not before 2026-08-01T00:00:00Z",
+		},
+		"Field selector on a nested spec field and a list": {
+			options:  &FilterOptions{FieldSelector: "spec.issuerRef.kind=ClusterIssuer,spec.dnsNames=example.com\\,www.example.com"},
+			crt:      crt,
+			expMatch: true,
+		},
+		"Field selector not matching": {
+			options:   &FilterOptions{FieldSelector: "metadata.namespace!=my-namespace"},
+			crt:       crt,
+			expMatch:  false,
+			expReason: "does not match --field-selector \"metadata.namespace!=my-namespace\"",
+		},
+		"Issuer DN and authority key ID": {
+			options:  &FilterOptions{IssuerDN: "CN=Example Intermediate CA,O=Example", AuthorityKeyID: "0a:1b:2c"},
+			crt:      crt,
+			cert:     cert,
+			expMatch: true,
+		},
+		"Other issuer DN": {
+			options:   &FilterOptions{IssuerDN: "CN=Example Root CA,O=Example"},
+			crt:       crt,
+			cert:      cert,
+			expMatch:  false,
+			expReason: `issued by "CN=Example Intermediate CA,O=Example", not "CN=Example Root CA,O=Example"`,
+		},
+		"Other authority key ID": {
+			options:   &FilterOptions{AuthorityKeyID: "0A:1B:2D"},
+			crt:       crt,
+			cert:      cert,
+			expMatch:  false,
+			expReason: "has authority key identifier 0A:1B:2C, not 0A:1B:2D",
+		},
+		"Issuer DN without a certificate in the Secret": {
+			options:   &FilterOptions{IssuerDN: "CN=Example Intermediate CA,O=Example"},
+			crt:       crt,
+			expMatch:  false,
+			expReason: `Secret "my-crt-tls" contains no valid certificate`,
+		},
+		"Field selector on an unset field": {
+			options:  &FilterOptions{FieldSelector: "spec.commonName!=example.com"},
+			crt:      crt,
+			expMatch: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter, err := test.options.ToFilter()
+			if err != nil {
+				t.Fatal(err)
+			}
+			match, reason := filter.Matches(test.crt, test.cert, now)
+			assert.Equal(t, test.expMatch, match)
+			assert.Equal(t, test.expReason, reason)
+		})
+	}
+}
+
+func TestRunFilter(t *testing.T) {
+	intermediatePEM, intermediateCert := newTestCertificatePEM(t, "Example Intermediate CA")
+	otherPEM, _ := newTestCertificatePEM(t, "Example Other CA")
+
+	tests := map[string]struct {
+		options   *FilterOptions
+		expOutput string
+		expErrOut string
+	}{
+		"Issuer reference": {
+			options: &FilterOptions{Issuer: "intermediate-ca"},
+			expOutput: "Manually triggered issuance of Certificate my-namespace/crt-1\n\n" +
+				"Renewal report: 1 renewed, 2 skipped, 0 failed\n" +
+				"  Renewed  my-namespace/crt-1\n" +
+				"  Skipped  my-namespace/crt-2: not matching the filters: references issuer \"other-ca\", not \"intermediate-ca\"\n" +
+				"  Skipped  my-namespace/crt-3: not matching the filters: references issuer \"other-ca\", not \"intermediate-ca\"\n",
+			expErrOut: "Skipped 2 Certificates not matching the filters\n",
+		},
+		"Issuer DN of the certificate in the Secret": {
+			options: &FilterOptions{IssuerDN: intermediateCert.Issuer.String()},
+			expOutput: "Manually triggered issuance of Certificate my-namespace/crt-1\n\n" +
+				"Renewal report: 1 renewed, 2 skipped, 0 failed\n" +
+				"  Renewed  my-namespace/crt-1\n" +
+				"  Skipped  my-namespace/crt-2: not matching the filters: issued by \"CN=Example Other CA\", not \"CN=Example Intermediate CA\"\n" +
+				"  Skipped  my-namespace/crt-3: not matching the filters: Secret \"crt-3-tls\" contains no valid certificate\n",
+			expErrOut: "Skipped 2 Certificates not matching the filters\n",
+		},
+		"No Certificate matching the filters": {
+			options: &FilterOptions{Issuer: "missing-ca"},
+			expOutput: "\nRenewal report: 0 renewed, 3 skipped, 0 failed\n" +
+				"  Skipped  my-namespace/crt-1: not matching the filters: references issuer \"intermediate-ca\", not \"missing-ca\"\n" +
+				"  Skipped  my-namespace/crt-2: not matching the filters: references issuer \"other-ca\", not \"missing-ca\"\n" +
+				"  Skipped  my-namespace/crt-3: not matching the filters: references issuer \"other-ca\", not \"missing-ca\"\n",
+			expErrOut: "Skipped 3 Certificates not matching the filters\n" +
+				"No Certificates matched the filters\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+
+			cmClient := cmfake.NewClientset(
+				gen.Certificate("crt-1", gen.SetCertificateNamespace("my-namespace"), gen.SetCertificateSecretName("crt-1-tls"),
+					gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "intermediate-ca", Kind: "ClusterIssuer"})),
+				gen.Certificate("crt-2", gen.SetCertificateNamespace("my-namespace"), gen.SetCertificateSecretName("crt-2-tls"),
+					gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "other-ca", Kind: "ClusterIssuer"})),
+				gen.Certificate("crt-3", gen.SetCertificateNamespace("my-namespace"), gen.SetCertificateSecretName("crt-3-tls"),
+					gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "other-ca", Kind: "ClusterIssuer"})),
+			)
+			kubeClient := kubefake.NewClientset(
+				gen.Secret("crt-1-tls", gen.SetSecretNamespace("my-namespace"), gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: intermediatePEM})),
+				gen.Secret("crt-2-tls", gen.SetSecretNamespace("my-namespace"), gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: otherPEM})),
+			)
+
+			o := NewOptions(ioStreams)
+			o.Filter = test.options
+			o.ContinueOnError = true
+			o.Factory = &factory.Factory{
+				Namespace:  "my-namespace",
+				CMClient:   cmClient,
+				KubeClient: kubeClient,
+			}
+
+			if err := o.Run(t.Context(), []string{"crt-1", "crt-2", "crt-3"}); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expOutput, out.String())
+			assert.Equal(t, test.expErrOut, errOut.String())
+		})
+	}
+}
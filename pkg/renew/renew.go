@@ -18,9 +18,12 @@ package renew
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -28,14 +31,18 @@ import (
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/templates"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
 )
 
 // Options is a struct to support renew command
@@ -43,26 +50,90 @@ type Options struct {
 	LabelSelector string
 	All           bool
 	AllNamespaces bool
+	// DryRunStrategy is the --dry-run strategy. With the client strategy, the
+	// Certificates that would be renewed are only printed; with the server
+	// strategy, the status updates are sent with DryRun: All.
+	DryRunStrategy cmdutil.DryRunStrategy
+	// Wait is set to block until the renewed Certificates have been issued,
+	// or their issuance failed.
+	Wait bool
+	// Timeout is the time to wait for the renewed Certificates of a wave to
+	// be issued if Wait is set or BatchSize is positive.
+	Timeout time.Duration
+	// BatchSize is the number of Certificates renewed per wave. A wave only
+	// starts once the Certificates of the previous wave have been issued.
+	// Zero renews all Certificates in a single wave.
+	BatchSize int
+	// BatchInterval is the time to pause between two waves.
+	BatchInterval time.Duration
+	// MaxInFlight is the maximum number of Certificates whose issuance is in
+	// progress at the same time. Zero means no limit.
+	MaxInFlight int
+	// HaltOnFailureRatio is the ratio of failed to finished renewals above
+	// which no further wave is started.
+	HaltOnFailureRatio float64
+	// ContinueOnError is set to keep renewing the remaining Certificates
+	// after a Certificate could not be fetched or renewed.
+	ContinueOnError bool
+	// Filter holds the filters on the live state of the selected
+	// Certificates.
+	Filter *FilterOptions
+
+	// PrintFlags holds the flags used to print the renewal report in a
+	// structured format. If no output format is set, the report is only
+	// printed with ContinueOnError.
+	PrintFlags *genericclioptions.PrintFlags
 
 	genericclioptions.IOStreams
 	*factory.Factory
+
+	// buildName is the name of the command, used to suggest commands in the
+	// hints of the diagnosis of a failed issuance.
+	buildName string
 }
 
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
-		IOStreams: ioStreams,
+		Timeout:            5 * time.Minute,
+		HaltOnFailureRatio: 1,
+		Filter:             &FilterOptions{},
+		PrintFlags:         util.NewPrintFlags(),
+		IOStreams:          ioStreams,
 	}
 }
 
 // NewCmdRenew returns a cobra command for renewing Certificates
 func NewCmdRenew(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
 	o := NewOptions(ioStreams)
+	o.buildName = build.Name(setupCtx)
 	cmd := &cobra.Command{
 		Use:   "renew",
 		Short: "Mark a Certificate for manual renewal",
 		Long: templates.LongDesc(`
-Mark cert-manager Certificate resources for manual renewal.`),
+Mark cert-manager Certificate resources for manual renewal.
+
+With --dry-run=client, the Certificates that would be marked for renewal are printed without changing them.
+With --dry-run=server, the status updates are validated by the API server without being persisted.
+
+With --wait, the command blocks until a new revision of every renewed Certificate is Ready, or its issuance
+failed, and prints the serial numbers and expiry dates of the previous and the new certificates.
+
+With --batch-size, the Certificates are renewed in waves to avoid hitting the rate limits of the CA. A wave
+only starts once the Certificates of the previous wave have been issued, or their issuance failed, and
+--batch-interval has passed. No further wave is started once the ratio of failed renewals exceeds
+--halt-on-failure-ratio.
+
+With --continue-on-error, the remaining Certificates are still renewed after a Certificate could not be
+fetched or renewed. Without it, no further renewal is started after an error, but with --wait the issuances
+already in progress are still waited for. A report of the renewed, skipped and failed Certificates is printed
+at the end, and the command exits with a non-zero exit code if any renewal failed. The report can be printed
+as JSON or YAML with --output.
+
+The selected Certificates can be filtered by their live state, e.g. with --expiring-within, --issuer,
+--not-ready or --field-selector, or by the CA that issued the certificate stored in their Secret with
+--issuer-dn or --authority-key-id. Only the Certificates matching all filters are renewed, the others are
+reported as skipped with the reason they do not match.`),
 		Example: templates.Examples(build.WithTemplate(setupCtx, `
 # Renew the Certificates named 'my-app' and 'vault' in the current context namespace.
 {{.BuildName}} renew my-app vault
@@ -71,9 +142,35 @@ Mark cert-manager Certificate resources for manual renewal.`),
 {{.BuildName}} renew --namespace kube-system --all
 
 # Renew all Certificates in all namespaces, provided those Certificates have the label 'app=my-service'
-{{.BuildName}} renew --all-namespaces -l app=my-service`)),
+{{.BuildName}} renew --all-namespaces -l app=my-service
+
+# List the Certificates in all namespaces that would be marked for manual renewal, without renewing them
+{{.BuildName}} renew --all-namespaces --all --dry-run=client
+
+# Renew the Certificate named 'my-app' and wait up to 10 minutes for the new certificate to be issued
+{{.BuildName}} renew my-app --wait --timeout 10m
+
+# Renew all Certificates in all namespaces in waves of 20, with at most 5 issuances in progress at the same
+# time, and stop once more than 10% of the renewals failed
+{{.BuildName}} renew --all-namespaces --all --batch-size 20 --batch-interval 1m --max-in-flight 5 --halt-on-failure-ratio 0.1
+
+# Renew all Certificates in the 'kube-system' namespace, even if some of them fail, and print the report as JSON
+{{.BuildName}} renew --namespace kube-system --all --continue-on-error -o json
+
+# Renew all Certificates in all namespaces issued by the ClusterIssuer 'intermediate-ca' before 2026-10-01
+{{.BuildName}} renew --all-namespaces --all --issuer intermediate-ca --issuer-kind ClusterIssuer --issued-before 2026-10-01
+
+# Renew all Certificates in the current namespace expiring within 30 days that are not Ready
+{{.BuildName}} renew --all --expiring-within 720h --not-ready
+
+# Renew all Certificates in all namespaces whose certificate was issued by a rotated intermediate CA
+{{.BuildName}} renew --all-namespaces --all --issuer-dn 'CN=Example Intermediate CA,O=Example'`)),
 		ValidArgsFunction: factory.ValidArgsListCertificates(&o.Factory),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if o.DryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd); err != nil {
+				return err
+			}
 			return o.Validate(cmd, args)
 		},
 		//nolint:contextcheck // False positive
@@ -85,6 +182,16 @@ Mark cert-manager Certificate resources for manual renewal.`),
 	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, mark Certificates across namespaces for manual renewal. Namespace in current context is ignored even if specified with --namespace.")
 	cmd.Flags().BoolVar(&o.All, "all", o.All, "Renew all Certificates in the given Namespace, or all namespaces with --all-namespaces enabled.")
+	cmd.Flags().BoolVar(&o.Wait, "wait", o.Wait, "If present, wait until the renewed Certificates have been issued, or their issuance failed.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "Time to wait for the renewed Certificates of a wave to be issued if --wait or --batch-size is present, must include unit, e.g. 10m or 1h")
+	cmd.Flags().IntVar(&o.BatchSize, "batch-size", o.BatchSize, "Number of Certificates renewed per wave. Every wave waits until its Certificates have been issued. If 0, all Certificates are renewed at once.")
+	cmd.Flags().DurationVar(&o.BatchInterval, "batch-interval", o.BatchInterval, "Time to pause between two waves, must include unit, e.g. 30s or 5m")
+	cmd.Flags().IntVar(&o.MaxInFlight, "max-in-flight", o.MaxInFlight, "Maximum number of Certificates whose issuance is in progress at the same time. If 0, there is no limit.")
+	cmd.Flags().Float64Var(&o.HaltOnFailureRatio, "halt-on-failure-ratio", o.HaltOnFailureRatio, "Ratio of failed to finished renewals, between 0 and 1, above which no further wave is started.")
+	cmd.Flags().BoolVar(&o.ContinueOnError, "continue-on-error", o.ContinueOnError, "If present, keep renewing the remaining Certificates after a Certificate could not be fetched or renewed, and print a report at the end.")
+	o.Filter.AddFlags(cmd)
+	o.PrintFlags.AddFlags(cmd)
+	cmdutil.AddDryRunFlag(cmd)
 
 	o.Factory = factory.New(cmd)
 
@@ -126,6 +233,40 @@ func (o *Options) Validate(cmd *cobra.Command, args []string) error {
 		return errors.New("cannot specify Certificate names in conjunction with --all-namespaces flag")
 	}
 
+	if o.Wait && o.DryRunStrategy != cmdutil.DryRunNone {
+		return errors.New("cannot specify --wait in conjunction with --dry-run, no Certificate is issued")
+	}
+
+	if o.waits() && o.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive, got %s", o.Timeout)
+	}
+
+	if o.BatchSize < 0 {
+		return fmt.Errorf("--batch-size must not be negative, got %d", o.BatchSize)
+	}
+
+	if o.BatchInterval < 0 {
+		return fmt.Errorf("--batch-interval must not be negative, got %s", o.BatchInterval)
+	}
+
+	if o.MaxInFlight < 0 {
+		return fmt.Errorf("--max-in-flight must not be negative, got %d", o.MaxInFlight)
+	}
+
+	if o.HaltOnFailureRatio < 0 || o.HaltOnFailureRatio > 1 {
+		return fmt.Errorf("--halt-on-failure-ratio must be between 0 and 1, got %v", o.HaltOnFailureRatio)
+	}
+
+	if !o.waits() && o.MaxInFlight > 0 {
+		return errors.New("cannot specify --max-in-flight without --wait or --batch-size")
+	}
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -152,6 +293,10 @@ func (o *Options) Complete(f cmdutil.Factory) error {
 
 // Run executes renew command
 func (o *Options) Run(ctx context.Context, args []string) error {
+	filter, err := o.Filter.ToFilter()
+	if err != nil {
+		return err
+	}
 
 	nss := []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: o.Namespace}}}
 
@@ -169,7 +314,13 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		nss = nsList.Items
 	}
 
-	var crts []cmapi.Certificate
+	report := newRenewalReport()
+	now := time.Now()
+
+	var (
+		crts     []cmapi.Certificate
+		filtered int
+	)
 	for _, ns := range nss {
 		switch {
 		case o.All, len(o.LabelSelector) > 0:
@@ -180,45 +331,166 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 				return err
 			}
 
-			crts = append(crts, crtsList.Items...)
+			for _, crt := range crtsList.Items {
+				if !o.matchesFilter(ctx, filter, &crt, now, report) { // #nosec G601 -- Pointer does not outlive function scope
+					filtered++
+					continue
+				}
+				report.add(crt.Namespace, crt.Name, RenewalPending, "")
+				crts = append(crts, crt)
+			}
 
 		default:
 			for _, crtName := range args {
 				crt, err := o.CMClient.CertmanagerV1().Certificates(ns.Name).Get(ctx, crtName, metav1.GetOptions{})
 				if err != nil {
-					return err
+					if !o.ContinueOnError {
+						return err
+					}
+					report.add(ns.Name, crtName, RenewalFailed, err.Error())
+					fmt.Fprintln(o.ErrOut, err)
+					continue
 				}
 
+				if !o.matchesFilter(ctx, filter, crt, now, report) {
+					filtered++
+					continue
+				}
+				report.add(crt.Namespace, crt.Name, RenewalPending, "")
 				crts = append(crts, *crt)
 			}
 		}
 	}
 
-	if len(crts) == 0 {
-		if o.AllNamespaces {
+	if filtered > 0 {
+		fmt.Fprintf(o.ErrOut, "Skipped %d Certificates not matching the filters\n", filtered)
+	}
+
+	if len(crts) == 0 && report.Failed == 0 {
+		switch {
+		case filtered > 0:
+			fmt.Fprintln(o.ErrOut, "No Certificates matched the filters")
+		case o.AllNamespaces:
 			fmt.Fprintln(o.ErrOut, "No Certificates found")
-		} else {
+		default:
 			fmt.Fprintf(o.ErrOut, "No Certificates found in %s namespace.\n", o.Namespace)
 		}
 
-		return nil
+		return o.printReport(report)
+	}
+
+	if o.waits() && o.DryRunStrategy == cmdutil.DryRunNone {
+		err = o.renewInWaves(ctx, crts, report)
+	} else {
+		err = o.renewAll(ctx, crts, report)
+	}
+	report.skipPending("not renewed, the renewal was aborted after a previous error")
+
+	if printErr := o.printReport(report); printErr != nil {
+		return printErr
+	}
+	if err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("the renewal of %d of %d Certificates failed", report.Failed, len(report.Certificates)-filtered)
+	}
+	return nil
+}
+
+// matchesFilter returns true if crt matches filter. Otherwise crt is recorded
+// in report as skipped, with the reason it does not match. The certificate
+// stored in the Secret of crt is only fetched if the filter needs it.
+func (o *Options) matchesFilter(ctx context.Context, filter *Filter, crt *cmapi.Certificate, now time.Time, report *RenewalReport) bool {
+	var cert *x509.Certificate
+	if filter.NeedsCertificate() {
+		cert = o.issuedCertificate(ctx, crt)
+	}
+
+	matches, reason := filter.Matches(crt, cert, now)
+	if !matches {
+		report.add(crt.Namespace, crt.Name, RenewalSkipped, "not matching the filters: "+reason)
+	}
+	return matches
+}
+
+// renewAll triggers the issuance of crts without waiting for them to be
+// issued, and records the outcomes in report. With a dry run strategy, the
+// Certificates are listed in the waves they would be renewed in.
+func (o *Options) renewAll(ctx context.Context, crts []cmapi.Certificate, report *RenewalReport) error {
+	for i, wave := range o.waves(crts) {
+		if o.BatchSize > 0 {
+			fmt.Fprintf(o.logOut(), "Wave %d with %d Certificates:\n", i+1, len(wave))
+		}
+		for _, crt := range wave {
+			if err := o.renewCertificate(ctx, &crt); /* #nosec G601 -- Pointer does not outlive function scope */ err != nil {
+				report.failed(&crt, err) // #nosec G601 -- Pointer does not outlive function scope
+				if !o.ContinueOnError {
+					return err
+				}
+				fmt.Fprintln(o.ErrOut, err)
+				continue
+			}
+			report.renewed(&crt) // #nosec G601 -- Pointer does not outlive function scope
+		}
 	}
 
-	for _, crt := range crts {
-		if err := o.renewCertificate(ctx, &crt); /* #nosec G601 -- Pointer does not outlive function scope */ err != nil {
+	return nil
+}
+
+// printReport prints report in the structured output format, or, if none is
+// set, as text with ContinueOnError. Otherwise nothing is printed.
+func (o *Options) printReport(report *RenewalReport) error {
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
 			return err
 		}
+		return util.PrintObject(printer, report, o.Out)
 	}
 
+	if o.ContinueOnError {
+		fmt.Fprintf(o.Out, "\n%s", report)
+	}
 	return nil
 }
 
+// logOut returns the writer to print progress messages to. Progress messages
+// must not end up in between structured output.
+func (o *Options) logOut() io.Writer {
+	if util.IsStructuredOutput(o.PrintFlags) {
+		return o.ErrOut
+	}
+	return o.Out
+}
+
+// renewCertificate sets the Issuing condition of crt to trigger its issuance.
+// If the status update conflicts with a concurrent change, crt is refetched
+// and the update is retried.
 func (o *Options) renewCertificate(ctx context.Context, crt *cmapi.Certificate) error {
-	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "ManuallyTriggered", "Certificate re-issuance manually triggered")
-	_, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+	if o.DryRunStrategy == cmdutil.DryRunClient {
+		fmt.Fprintf(o.logOut(), "Would set condition Issuing=True (reason \"ManuallyTriggered\") on Certificate %s/%s%s\n",
+			crt.Namespace, crt.Name, cmcmdutil.DryRunSuffix(o.DryRunStrategy))
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "ManuallyTriggered", "Certificate re-issuance manually triggered")
+		_, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, cmcmdutil.DryRunUpdateOptions(o.DryRunStrategy))
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+
+		current, getErr := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Get(ctx, crt.Name, metav1.GetOptions{})
+		if getErr != nil {
+			return getErr
+		}
+		*crt = *current
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to trigger issuance of Certificate %s/%s: %v", crt.Namespace, crt.Name, err)
 	}
-	fmt.Fprintf(o.Out, "Manually triggered issuance of Certificate %s/%s\n", crt.Namespace, crt.Name)
+	fmt.Fprintf(o.logOut(), "Manually triggered issuance of Certificate %s/%s%s\n", crt.Namespace, crt.Name, cmcmdutil.DryRunSuffix(o.DryRunStrategy))
 	return nil
 }
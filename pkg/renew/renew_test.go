@@ -17,9 +17,22 @@ limitations under the License.
 package renew
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	coretesting "k8s.io/client-go/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
 )
 
 type stringFlag struct {
@@ -114,6 +127,66 @@ func TestValidate(t *testing.T) {
 			},
 			expErr: false,
 		},
+		"If --wait specified with --dry-run, error": {
+			options: &Options{
+				All:            true,
+				Wait:           true,
+				Timeout:        time.Minute,
+				DryRunStrategy: cmdutil.DryRunClient,
+			},
+			expErr: true,
+		},
+		"If --wait specified with a non-positive --timeout, error": {
+			options: &Options{
+				All:  true,
+				Wait: true,
+			},
+			expErr: true,
+		},
+		"If --wait specified with --timeout, don't error": {
+			options: &Options{
+				All:     true,
+				Wait:    true,
+				Timeout: time.Minute,
+			},
+			expErr: false,
+		},
+		"If --batch-size specified, don't error": {
+			options: &Options{
+				All:                true,
+				BatchSize:          10,
+				MaxInFlight:        5,
+				Timeout:            time.Minute,
+				HaltOnFailureRatio: 0.1,
+			},
+			expErr: false,
+		},
+		"If --batch-size is negative, error": {
+			options: &Options{
+				All:                true,
+				BatchSize:          -1,
+				Timeout:            time.Minute,
+				HaltOnFailureRatio: 1,
+			},
+			expErr: true,
+		},
+		"If --halt-on-failure-ratio is greater than 1, error": {
+			options: &Options{
+				All:                true,
+				BatchSize:          10,
+				Timeout:            time.Minute,
+				HaltOnFailureRatio: 1.5,
+			},
+			expErr: true,
+		},
+		"If --max-in-flight specified without --wait or --batch-size, error": {
+			options: &Options{
+				All:                true,
+				MaxInFlight:        5,
+				HaltOnFailureRatio: 1,
+			},
+			expErr: true,
+		},
 		"If --label-selector specified with --namespace, don't error": {
 			options: &Options{
 				LabelSelector: "foo=bar",
@@ -150,3 +223,151 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRunDryRun(t *testing.T) {
+	tests := map[string]struct {
+		dryRunStrategy cmdutil.DryRunStrategy
+		expOutput      string
+		expUpdate      bool
+		expDryRun      []string
+	}{
+		"No dry run updates the Certificate": {
+			dryRunStrategy: cmdutil.DryRunNone,
+			expOutput:      "Manually triggered issuance of Certificate my-namespace/my-crt\n",
+			expUpdate:      true,
+		},
+		"Client dry run only prints the change": {
+			dryRunStrategy: cmdutil.DryRunClient,
+			expOutput:      "Would set condition Issuing=True (reason \"ManuallyTriggered\") on Certificate my-namespace/my-crt (dry run)\n",
+			expUpdate:      false,
+		},
+		"Server dry run sends the update with DryRun: All": {
+			dryRunStrategy: cmdutil.DryRunServer,
+			expOutput:      "Manually triggered issuance of Certificate my-namespace/my-crt (server dry run)\n",
+			expUpdate:      true,
+			expDryRun:      []string{metav1.DryRunAll},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmClient := cmfake.NewClientset(gen.Certificate("my-crt", gen.SetCertificateNamespace("my-namespace")))
+
+			o := NewOptions(ioStreams)
+			o.DryRunStrategy = test.dryRunStrategy
+			o.Factory = &factory.Factory{
+				Namespace: "my-namespace",
+				CMClient:  cmClient,
+			}
+
+			if err := o.Run(t.Context(), []string{"my-crt"}); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expOutput, out.String())
+
+			var updates []coretesting.UpdateActionImpl
+			for _, action := range cmClient.Actions() {
+				if update, ok := action.(coretesting.UpdateActionImpl); ok {
+					updates = append(updates, update)
+				}
+			}
+			if !test.expUpdate {
+				assert.Empty(t, updates)
+				return
+			}
+			if assert.Len(t, updates, 1) {
+				assert.Equal(t, "status", updates[0].GetSubresource())
+				assert.Equal(t, test.expDryRun, updates[0].UpdateOptions.DryRun)
+				assert.IsType(t, &cmapi.Certificate{}, updates[0].GetObject())
+			}
+		})
+	}
+}
+
+func TestRunContinueOnError(t *testing.T) {
+	tests := map[string]struct {
+		args            []string
+		continueOnError bool
+		outputFormat    string
+		expOutput       []string
+		expErr          string
+	}{
+		"Continue on error renews the remaining Certificates and prints the report": {
+			args:            []string{"crt-1", "crt-2", "crt-3", "crt-4"},
+			continueOnError: true,
+			expOutput: []string{
+				"Manually triggered issuance of Certificate my-namespace/crt-4\n",
+				"Renewal report: 2 renewed, 0 skipped, 2 failed\n" +
+					"  Renewed  my-namespace/crt-1\n" +
+					"  Failed   my-namespace/crt-2: certificates.cert-manager.io \"crt-2\" not found\n" +
+					"  Failed   my-namespace/crt-3: failed to trigger issuance of Certificate my-namespace/crt-3: Internal error occurred: etcd is unavailable\n" +
+					"  Renewed  my-namespace/crt-4\n",
+			},
+			expErr: "the renewal of 2 of 4 Certificates failed",
+		},
+		"Without continue on error, the Certificates after the failure are skipped": {
+			args:         []string{"crt-1", "crt-3", "crt-4"},
+			outputFormat: "json",
+			expOutput: []string{
+				`"kind": "RenewalReport"`,
+				`"renewed": 1`,
+				`"skipped": 1`,
+				`"failed": 1`,
+				`"reason": "not renewed, the renewal was aborted after a previous error",
+            "result": "Skipped"`,
+			},
+			expErr: "failed to trigger issuance of Certificate my-namespace/crt-3: Internal error occurred: etcd is unavailable",
+		},
+		"Without continue on error, a missing Certificate aborts before renewing": {
+			args:   []string{"crt-1", "crt-2"},
+			expErr: `certificates.cert-manager.io "crt-2" not found`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			var crts []runtime.Object
+			for _, name := range []string{"crt-1", "crt-3", "crt-4"} {
+				crts = append(crts, gen.Certificate(name, gen.SetCertificateNamespace("my-namespace")))
+			}
+			cmClient := cmfake.NewClientset(crts...)
+
+			// The first status update of crt-1 conflicts with a concurrent
+			// change, all status updates of crt-3 fail.
+			conflicted := false
+			cmClient.PrependReactor("update", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+				crt := action.(coretesting.UpdateAction).GetObject().(*cmapi.Certificate)
+				switch {
+				case crt.Name == "crt-1" && !conflicted:
+					conflicted = true
+					return true, nil, apierrors.NewConflict(cmapi.Resource("certificates"), crt.Name, errors.New("the object has been modified"))
+				case crt.Name == "crt-3":
+					return true, nil, apierrors.NewInternalError(errors.New("etcd is unavailable"))
+				}
+				return false, nil, nil
+			})
+
+			o := NewOptions(ioStreams)
+			o.ContinueOnError = test.continueOnError
+			*o.PrintFlags.OutputFormat = test.outputFormat
+			o.Factory = &factory.Factory{
+				Namespace: "my-namespace",
+				CMClient:  cmClient,
+			}
+
+			err := o.Run(t.Context(), test.args)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, expOutput := range test.expOutput {
+				assert.Contains(t, out.String(), expOutput)
+			}
+			if len(test.expOutput) == 0 {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"fmt"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// RenewalResult is the outcome of the renewal of a Certificate.
+type RenewalResult string
+
+const (
+	// RenewalPending is the result of a Certificate whose renewal has not
+	// finished yet. No Certificate is pending once the command has finished.
+	RenewalPending RenewalResult = "Pending"
+	// RenewalRenewed is the result of a Certificate whose issuance has been
+	// triggered, or, if the command waits for the issuance, that has been
+	// issued.
+	RenewalRenewed RenewalResult = "Renewed"
+	// RenewalSkipped is the result of a Certificate that was selected, but
+	// not renewed because it does not match the filters, or because the
+	// command was aborted or halted before.
+	RenewalSkipped RenewalResult = "Skipped"
+	// RenewalFailed is the result of a Certificate that could not be fetched
+	// or renewed, or whose issuance failed.
+	RenewalFailed RenewalResult = "Failed"
+)
+
+// CertificateRenewal is the outcome of the renewal of a single Certificate.
+type CertificateRenewal struct {
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	Result    RenewalResult `json:"result"`
+	// Reason explains why the Certificate was skipped or failed
+	Reason string `json:"reason,omitempty"`
+}
+
+// RenewalReport lists the outcome of the renewal of every selected
+// Certificate, printed at the end of the renew command with
+// --continue-on-error or a structured output format.
+type RenewalReport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Renewed int `json:"renewed"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+	// Certificates are the outcomes of all selected Certificates, in the
+	// order they were selected in
+	Certificates []CertificateRenewal `json:"certificates"`
+
+	// index maps namespace/name to the position in Certificates
+	index map[string]int
+}
+
+func newRenewalReport() *RenewalReport {
+	return &RenewalReport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       "RenewalReport",
+		},
+		Certificates: []CertificateRenewal{},
+		index:        map[string]int{},
+	}
+}
+
+// add adds a Certificate to the report with the given result.
+func (r *RenewalReport) add(namespace, name string, result RenewalResult, reason string) {
+	r.index[namespace+"/"+name] = len(r.Certificates)
+	r.Certificates = append(r.Certificates, CertificateRenewal{Namespace: namespace, Name: name, Result: RenewalPending})
+	r.set(namespace, name, result, reason)
+}
+
+// set sets the result of a Certificate already in the report.
+func (r *RenewalReport) set(namespace, name string, result RenewalResult, reason string) {
+	renewal := &r.Certificates[r.index[namespace+"/"+name]]
+	r.count(renewal.Result, -1)
+	renewal.Result, renewal.Reason = result, reason
+	r.count(result, 1)
+}
+
+func (r *RenewalReport) count(result RenewalResult, delta int) {
+	switch result {
+	case RenewalRenewed:
+		r.Renewed += delta
+	case RenewalSkipped:
+		r.Skipped += delta
+	case RenewalFailed:
+		r.Failed += delta
+	}
+}
+
+// renewed records crt as renewed.
+func (r *RenewalReport) renewed(crt *cmapi.Certificate) {
+	r.set(crt.Namespace, crt.Name, RenewalRenewed, "")
+}
+
+// failed records crt as failed with err as reason.
+func (r *RenewalReport) failed(crt *cmapi.Certificate, err error) {
+	r.set(crt.Namespace, crt.Name, RenewalFailed, err.Error())
+}
+
+// skipPending records all Certificates that are still pending as skipped.
+func (r *RenewalReport) skipPending(reason string) {
+	for _, renewal := range r.Certificates {
+		if renewal.Result == RenewalPending {
+			r.set(renewal.Namespace, renewal.Name, RenewalSkipped, reason)
+		}
+	}
+}
+
+// progress returns the number of succeeded, failed and pending renewals.
+// Skipped Certificates are not counted.
+func (r *RenewalReport) progress() progress {
+	return progress{
+		succeeded: r.Renewed,
+		failed:    r.Failed,
+		pending:   len(r.Certificates) - r.Renewed - r.Skipped - r.Failed,
+	}
+}
+
+// String returns the report as a summary line, followed by one line for every
+// Certificate.
+func (r *RenewalReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Renewal report: %d renewed, %d skipped, %d failed\n", r.Renewed, r.Skipped, r.Failed)
+	for _, renewal := range r.Certificates {
+		fmt.Fprintf(&b, "  %-8s %s/%s", renewal.Result, renewal.Namespace, renewal.Name)
+		if renewal.Reason != "" {
+			fmt.Fprintf(&b, ": %s", renewal.Reason)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	statuscertificate "github.com/cert-manager/cmctl/v2/pkg/status/certificate"
+)
+
+// pollInterval is the interval the Certificates whose issuance is in progress
+// are polled at.
+const pollInterval = time.Second
+
+// renewal is the renewal of a single Certificate whose issuance is in
+// progress.
+type renewal struct {
+	crt *cmapi.Certificate
+	// prevRevision is the revision of the Certificate before its issuance
+	// was triggered
+	prevRevision int
+	// prevCert is the certificate stored in the Secret before the issuance
+	// was triggered, nil if there was none
+	prevCert *x509.Certificate
+}
+
+// progress counts the Certificates selected for renewal by the outcome of
+// their renewal.
+type progress struct {
+	succeeded, failed, pending int
+}
+
+func (p progress) String() string {
+	return fmt.Sprintf("%d succeeded, %d failed, %d pending", p.succeeded, p.failed, p.pending)
+}
+
+// failureRatio returns the ratio of failed to finished renewals.
+func (p progress) failureRatio() float64 {
+	finished := p.succeeded + p.failed
+	if finished == 0 {
+		return 0
+	}
+	return float64(p.failed) / float64(finished)
+}
+
+// waits returns true if the command has to wait for the renewed Certificates
+// to be issued, which is always the case when renewing in batches.
+func (o *Options) waits() bool {
+	return o.Wait || o.BatchSize > 0
+}
+
+// waves splits crts into waves of BatchSize Certificates. All Certificates
+// are in a single wave if BatchSize is zero.
+func (o *Options) waves(crts []cmapi.Certificate) [][]cmapi.Certificate {
+	if o.BatchSize <= 0 {
+		return [][]cmapi.Certificate{crts}
+	}
+
+	waves := make([][]cmapi.Certificate, 0, (len(crts)+o.BatchSize-1)/o.BatchSize)
+	for len(crts) > o.BatchSize {
+		waves = append(waves, crts[:o.BatchSize])
+		crts = crts[o.BatchSize:]
+	}
+	return append(waves, crts)
+}
+
+// renewInWaves renews crts wave by wave and records the outcomes in report.
+// A wave only starts once all Certificates of the previous wave have been
+// issued or their issuance failed, and BatchInterval has passed. No further
+// wave is started once the ratio of failed renewals exceeds
+// HaltOnFailureRatio.
+func (o *Options) renewInWaves(ctx context.Context, crts []cmapi.Certificate, report *RenewalReport) error {
+	waves := o.waves(crts)
+
+	for i, wave := range waves {
+		if i > 0 && o.BatchInterval > 0 {
+			fmt.Fprintf(o.logOut(), "Waiting %s before starting wave %d/%d\n", o.BatchInterval, i+1, len(waves))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(o.BatchInterval):
+			}
+		}
+
+		if len(waves) > 1 {
+			fmt.Fprintf(o.logOut(), "Starting wave %d/%d with %d Certificates\n", i+1, len(waves), len(wave))
+		}
+		if err := o.renewWave(ctx, wave, report); err != nil {
+			return err
+		}
+		if len(waves) > 1 {
+			fmt.Fprintf(o.logOut(), "Finished wave %d/%d: %s\n", i+1, len(waves), report.progress())
+		}
+
+		if p := report.progress(); i < len(waves)-1 && p.failureRatio() > o.HaltOnFailureRatio {
+			err := fmt.Errorf("halted after wave %d/%d, the ratio of failed renewals %.2f exceeds --halt-on-failure-ratio %.2f: %s",
+				i+1, len(waves), p.failureRatio(), o.HaltOnFailureRatio, p)
+			report.skipPending(err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renewWave triggers the issuance of the Certificates of a wave, with at most
+// MaxInFlight issuances in progress at the same time, and waits until all of
+// them have been issued or their issuance failed. The Certificates still
+// being issued when Timeout has passed since the start of the wave are
+// counted as failed. Every failure is printed with the diagnosis of the
+// Certificate and its related resources, e.g. the failure reason of the
+// CertificateRequest or Challenges. If the issuance of a Certificate cannot
+// be triggered and ContinueOnError is not set, no further issuance is
+// triggered, but the Certificates already being issued are still waited for,
+// so that their outcome is recorded in report, before the error is returned.
+func (o *Options) renewWave(ctx context.Context, wave []cmapi.Certificate, report *RenewalReport) error {
+	waveCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	fail := func(crt *cmapi.Certificate, err error) {
+		report.failed(crt, err)
+		fmt.Fprintln(o.ErrOut, o.withDiagnosis(ctx, crt, err))
+	}
+
+	queue := wave
+	var (
+		inFlight []*renewal
+		abortErr error
+	)
+	for len(queue) > 0 || len(inFlight) > 0 {
+		for len(queue) > 0 && (o.MaxInFlight <= 0 || len(inFlight) < o.MaxInFlight) {
+			crt := &queue[0]
+			queue = queue[1:]
+
+			// The previous certificate is collected before triggering the
+			// issuance, so that it cannot have been replaced yet.
+			prevCert := o.issuedCertificate(ctx, crt)
+			if err := o.renewCertificate(ctx, crt); err != nil {
+				if !o.ContinueOnError {
+					report.failed(crt, err)
+					abortErr = err
+					queue = nil
+					break
+				}
+				fail(crt, err)
+				continue
+			}
+			inFlight = append(inFlight, &renewal{crt: crt, prevRevision: certificateRevision(crt), prevCert: prevCert})
+		}
+
+		remaining := inFlight[:0]
+		for _, r := range inFlight {
+			finished, err := o.pollIssuance(waveCtx, r)
+			switch {
+			case err != nil:
+				fail(r.crt, err)
+			case finished:
+				report.renewed(r.crt)
+				o.printIssued(ctx, r)
+			default:
+				remaining = append(remaining, r)
+			}
+		}
+		inFlight = remaining
+		if len(queue) == 0 && len(inFlight) == 0 {
+			break
+		}
+
+		select {
+		case <-waveCtx.Done():
+			if ctx.Err() != nil {
+				for _, r := range inFlight {
+					report.failed(r.crt, fmt.Errorf("issuance was triggered, but the command was interrupted before it finished: %w", ctx.Err()))
+				}
+				return ctx.Err()
+			}
+			for _, r := range inFlight {
+				fail(r.crt, fmt.Errorf("timed out after %s waiting for Certificate %s/%s to be issued", o.Timeout, r.crt.Namespace, r.crt.Name))
+			}
+			for i := range queue {
+				fail(&queue[i], fmt.Errorf("timed out after %s before the renewal of Certificate %s/%s was started", o.Timeout, queue[i].Namespace, queue[i].Name))
+			}
+			return abortErr
+		case <-time.After(pollInterval):
+		}
+	}
+
+	return abortErr
+}
+
+// pollIssuance gets the current state of the Certificate of r and returns
+// true if its issuance has finished. Errors getting the Certificate are
+// ignored, it is polled again later.
+func (o *Options) pollIssuance(ctx context.Context, r *renewal) (bool, error) {
+	crt, err := o.CMClient.CertmanagerV1().Certificates(r.crt.Namespace).Get(ctx, r.crt.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, nil //nolint: nilerr // Retry and keep polling until the wave times out
+	}
+	r.crt = crt
+	return issuanceFinished(crt, r.prevRevision)
+}
+
+// printIssued prints the serial numbers and expiry dates of the previous and
+// the newly issued certificate of r.
+func (o *Options) printIssued(ctx context.Context, r *renewal) {
+	cert := o.issuedCertificate(ctx, r.crt)
+	fmt.Fprintf(o.logOut(), "Certificate %s/%s has been issued\n", r.crt.Namespace, r.crt.Name)
+	fmt.Fprintf(o.logOut(), "  Serial Number: %s -> %s\n", serialNumber(r.prevCert), serialNumber(cert))
+	fmt.Fprintf(o.logOut(), "  Not After:     %s -> %s\n", notAfter(r.prevCert), notAfter(cert))
+}
+
+// issuanceFinished returns true if the issuance of crt, triggered while it was
+// at revision prevRevision, has finished: a new revision is Ready and no
+// issuance is in progress. It returns an error if the issuance failed.
+func issuanceFinished(crt *cmapi.Certificate, prevRevision int) (bool, error) {
+	issuing := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	if issuing != nil && issuing.Status == cmmeta.ConditionTrue {
+		return false, nil
+	}
+	if issuing != nil && issuing.Reason == "Failed" {
+		return false, fmt.Errorf("issuance of Certificate %s/%s failed: %s", crt.Namespace, crt.Name, issuing.Message)
+	}
+
+	return certificateRevision(crt) > prevRevision &&
+		apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}), nil
+}
+
+// withDiagnosis appends the known problems found in crt and its related
+// resources to err. If the resources cannot be collected, err is returned
+// unchanged.
+func (o *Options) withDiagnosis(ctx context.Context, crt *cmapi.Certificate, err error) error {
+	statusOptions := &statuscertificate.Options{Factory: o.Factory}
+	data, dataErr := statusOptions.GetResourcesForCertificate(ctx, o.KubeClient, crt)
+	if dataErr != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w\n%s", err, statuscertificate.FindingsToString(statuscertificate.Diagnose(data, time.Now(), o.buildName)))
+}
+
+// issuedCertificate returns the certificate currently stored in the Secret of
+// crt, or nil if the Secret does not exist or contains no valid certificate.
+func (o *Options) issuedCertificate(ctx context.Context, crt *cmapi.Certificate) *x509.Certificate {
+	secret, err := o.KubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil
+	}
+	return cert
+}
+
+func certificateRevision(crt *cmapi.Certificate) int {
+	if crt.Status.Revision == nil {
+		return 0
+	}
+	return *crt.Status.Revision
+}
+
+func serialNumber(cert *x509.Certificate) string {
+	if cert == nil {
+		return "<none>"
+	}
+	return hex.EncodeToString(cert.SerialNumber.Bytes())
+}
+
+func notAfter(cert *x509.Certificate) string {
+	if cert == nil {
+		return "<none>"
+	}
+	return cert.NotAfter.Format(time.RFC3339)
+}
@@ -0,0 +1,449 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+)
+
+func TestIssuanceFinished(t *testing.T) {
+	issuing := cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue, Reason: "ManuallyTriggered"}
+	failed := cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "The certificate request has failed to complete and will be retried: issuer is not ready"}
+	ready := cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}
+
+	tests := map[string]struct {
+		crt         *cmapi.Certificate
+		expFinished bool
+		expErr      string
+	}{
+		"Issuance in progress": {
+			crt: gen.Certificate("my-crt", gen.SetCertificateRevision(1),
+				gen.SetCertificateStatusCondition(issuing), gen.SetCertificateStatusCondition(ready)),
+		},
+		"Ready, but not yet at a new revision": {
+			crt: gen.Certificate("my-crt", gen.SetCertificateRevision(1), gen.SetCertificateStatusCondition(ready)),
+		},
+		"New revision is Ready": {
+			crt:         gen.Certificate("my-crt", gen.SetCertificateRevision(2), gen.SetCertificateStatusCondition(ready)),
+			expFinished: true,
+		},
+		"Issuance failed": {
+			crt: gen.Certificate("my-crt", gen.SetCertificateNamespace("my-namespace"), gen.SetCertificateRevision(1),
+				gen.SetCertificateStatusCondition(failed), gen.SetCertificateStatusCondition(ready)),
+			expErr: "issuance of Certificate my-namespace/my-crt failed: The certificate request has failed to complete and will be retried: issuer is not ready",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			finished, err := issuanceFinished(test.crt, 1)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expFinished, finished)
+		})
+	}
+}
+
+// newTestCertificatePEM returns a self-signed certificate for commonName.
+func newTestCertificatePEM(t *testing.T, commonName string) ([]byte, *x509.Certificate) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.CertificateTemplateFromCertificate(gen.Certificate(commonName, gen.SetCertificateCommonName(commonName)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, cert
+}
+
+func TestRunWait(t *testing.T) {
+	prevPEM, prevCert := newTestCertificatePEM(t, "example.com")
+	newPEM, newCert := newTestCertificatePEM(t, "example.com")
+
+	baseCrt := gen.Certificate("my-crt",
+		gen.SetCertificateNamespace("my-namespace"),
+		gen.SetCertificateUID("my-crt-uid"),
+		gen.SetCertificateSecretName("my-crt-tls"),
+		gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "my-issuer", Kind: "Issuer"}),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}))
+
+	tests := map[string]struct {
+		// issuedCrt is the Certificate returned once the issuance has
+		// finished, nil if it never finishes
+		issuedCrt *cmapi.Certificate
+		// failedReq is the CertificateRequest of a failed issuance
+		failedReq *cmapi.CertificateRequest
+		expOutput string
+		expErrOut []string
+		expErr    string
+	}{
+		"Issuance succeeded": {
+			issuedCrt: gen.CertificateFrom(baseCrt, gen.SetCertificateRevision(2)),
+			expOutput: "Manually triggered issuance of Certificate my-namespace/my-crt\n" +
+				"Certificate my-namespace/my-crt has been issued\n" +
+				"  Serial Number: " + hex.EncodeToString(prevCert.SerialNumber.Bytes()) + " -> " + hex.EncodeToString(newCert.SerialNumber.Bytes()) + "\n" +
+				"  Not After:     " + prevCert.NotAfter.Format(time.RFC3339) + " -> " + newCert.NotAfter.Format(time.RFC3339) + "\n",
+		},
+		"Issuance failed": {
+			issuedCrt: gen.CertificateFrom(baseCrt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+				Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed",
+				Message: "The certificate request has failed to complete and will be retried: issuer is not ready",
+			})),
+			failedReq: gen.CertificateRequest("my-crt-2",
+				gen.SetCertificateRequestNamespace("my-namespace"),
+				gen.SetCertificateRequestRevision("2"),
+				gen.AddCertificateRequestOwnerReferences(gen.CertificateRef("my-crt", "my-crt-uid")),
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse,
+					Reason: cmapi.CertificateRequestReasonFailed, Message: "issuer is not ready",
+				})),
+			expErrOut: []string{
+				"issuance of Certificate my-namespace/my-crt failed: The certificate request has failed to complete and will be retried: issuer is not ready",
+				`RequestFailed: The CertificateRequest "my-crt-2" failed: issuer is not ready`,
+			},
+			expErr: "the renewal of 1 of 1 Certificates failed",
+		},
+		"Timeout": {
+			expErrOut: []string{"timed out after 10ms waiting for Certificate my-namespace/my-crt to be issued"},
+			expErr:    "the renewal of 1 of 1 Certificates failed",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, errOut := genericclioptions.NewTestIOStreams()
+
+			cmObjects := []runtime.Object{baseCrt.DeepCopy()}
+			if test.failedReq != nil {
+				cmObjects = append(cmObjects, test.failedReq)
+			}
+			cmClient := cmfake.NewClientset(cmObjects...)
+			kubeClient := kubefake.NewClientset(gen.Secret("my-crt-tls",
+				gen.SetSecretNamespace("my-namespace"),
+				gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: prevPEM})))
+
+			if test.issuedCrt != nil {
+				// The issuance finishes as soon as the Certificate is polled
+				// for the first time after triggering it.
+				gets := 0
+				cmClient.PrependReactor("get", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+					if gets++; gets == 1 {
+						return false, nil, nil
+					}
+					if test.failedReq == nil {
+						secret := gen.Secret("my-crt-tls",
+							gen.SetSecretNamespace("my-namespace"),
+							gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: newPEM}))
+						if err := kubeClient.Tracker().Update(corev1.SchemeGroupVersion.WithResource("secrets"), secret, "my-namespace"); err != nil {
+							return true, nil, err
+						}
+					}
+					return true, test.issuedCrt.DeepCopy(), nil
+				})
+			}
+
+			o := NewOptions(ioStreams)
+			o.Wait = true
+			o.Timeout = 10 * time.Millisecond
+			if test.issuedCrt != nil {
+				o.Timeout = time.Minute
+			}
+			o.Factory = &factory.Factory{
+				Namespace:  "my-namespace",
+				CMClient:   cmClient,
+				KubeClient: kubeClient,
+			}
+
+			err := o.Run(t.Context(), []string{"my-crt"})
+			for _, expErrOut := range test.expErrOut {
+				assert.Contains(t, errOut.String(), expErrOut)
+			}
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expOutput, out.String())
+		})
+	}
+}
+
+func TestWaves(t *testing.T) {
+	crts := []cmapi.Certificate{*gen.Certificate("crt-1"), *gen.Certificate("crt-2"), *gen.Certificate("crt-3")}
+
+	tests := map[string]struct {
+		batchSize int
+		expWaves  [][]string
+	}{
+		"No batch size renews all Certificates at once": {
+			batchSize: 0,
+			expWaves:  [][]string{{"crt-1", "crt-2", "crt-3"}},
+		},
+		"Last wave is smaller": {
+			batchSize: 2,
+			expWaves:  [][]string{{"crt-1", "crt-2"}, {"crt-3"}},
+		},
+		"Batch size of one": {
+			batchSize: 1,
+			expWaves:  [][]string{{"crt-1"}, {"crt-2"}, {"crt-3"}},
+		},
+		"Batch size larger than the number of Certificates": {
+			batchSize: 10,
+			expWaves:  [][]string{{"crt-1", "crt-2", "crt-3"}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &Options{BatchSize: test.batchSize}
+
+			var waves [][]string
+			for _, wave := range o.waves(crts) {
+				var names []string
+				for _, crt := range wave {
+					names = append(names, crt.Name)
+				}
+				waves = append(waves, names)
+			}
+			assert.Equal(t, test.expWaves, waves)
+		})
+	}
+}
+
+func TestRunInWaves(t *testing.T) {
+	tests := map[string]struct {
+		batchSize          int
+		maxInFlight        int
+		haltOnFailureRatio float64
+		// failing are the names of the Certificates whose issuance fails
+		failing       []string
+		expOutput     []string
+		expNotRenewed []string
+		expErr        string
+	}{
+		"All Certificates are renewed in waves": {
+			batchSize:          2,
+			haltOnFailureRatio: 1,
+			expOutput: []string{
+				"Starting wave 1/2 with 2 Certificates\n",
+				"Finished wave 1/2: 2 succeeded, 0 failed, 1 pending\n",
+				"Starting wave 2/2 with 1 Certificates\n",
+				"Finished wave 2/2: 3 succeeded, 0 failed, 0 pending\n",
+			},
+		},
+		"Failure ratio exceeded halts before the next wave": {
+			batchSize:          2,
+			haltOnFailureRatio: 0.4,
+			failing:            []string{"crt-1"},
+			expOutput:          []string{"Finished wave 1/2: 1 succeeded, 1 failed, 1 pending\n"},
+			expNotRenewed:      []string{"crt-3"},
+			expErr:             "halted after wave 1/2, the ratio of failed renewals 0.50 exceeds --halt-on-failure-ratio 0.40: 1 succeeded, 1 failed, 1 pending",
+		},
+		"Failure ratio not exceeded continues with the next wave": {
+			batchSize:          2,
+			haltOnFailureRatio: 0.5,
+			failing:            []string{"crt-1"},
+			expOutput:          []string{"Finished wave 2/2: 2 succeeded, 1 failed, 0 pending\n"},
+			expErr:             "the renewal of 1 of 3 Certificates failed",
+		},
+		"Max in flight triggers the next issuance once the previous finished": {
+			maxInFlight:        1,
+			haltOnFailureRatio: 1,
+			expOutput: []string{
+				"Manually triggered issuance of Certificate my-namespace/crt-1\n" +
+					"Certificate my-namespace/crt-1 has been issued\n" +
+					"  Serial Number: <none> -> <none>\n" +
+					"  Not After:     <none> -> <none>\n" +
+					"Manually triggered issuance of Certificate my-namespace/crt-2\n",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			var crts []runtime.Object
+			for _, name := range []string{"crt-1", "crt-2", "crt-3"} {
+				crts = append(crts, gen.Certificate(name,
+					gen.SetCertificateNamespace("my-namespace"),
+					gen.SetCertificateRevision(1),
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue})))
+			}
+			cmClient := cmfake.NewClientset(crts...)
+
+			// The issuance of a triggered Certificate finishes as soon as it
+			// is polled.
+			cmClient.PrependReactor("get", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+				get := action.(coretesting.GetAction)
+				obj, err := cmClient.Tracker().Get(cmapi.SchemeGroupVersion.WithResource("certificates"), get.GetNamespace(), get.GetName())
+				if err != nil {
+					return false, nil, nil
+				}
+				crt := obj.(*cmapi.Certificate)
+				if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}) {
+					return false, nil, nil
+				}
+				if slices.Contains(test.failing, crt.Name) {
+					return true, gen.CertificateFrom(crt, gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+						Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "issuer is not ready",
+					})), nil
+				}
+				return true, gen.CertificateFrom(crt, gen.SetCertificateRevision(2), gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Issued",
+				})), nil
+			})
+
+			o := NewOptions(ioStreams)
+			o.Wait = true
+			o.Timeout = time.Minute
+			o.BatchSize = test.batchSize
+			o.MaxInFlight = test.maxInFlight
+			o.HaltOnFailureRatio = test.haltOnFailureRatio
+			o.Factory = &factory.Factory{
+				Namespace:  "my-namespace",
+				CMClient:   cmClient,
+				KubeClient: kubefake.NewClientset(),
+			}
+
+			err := o.Run(t.Context(), []string{"crt-1", "crt-2", "crt-3"})
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, expOutput := range test.expOutput {
+				assert.Contains(t, out.String(), expOutput)
+			}
+			for _, name := range test.expNotRenewed {
+				assert.NotContains(t, out.String(), "Manually triggered issuance of Certificate my-namespace/"+name)
+			}
+		})
+	}
+}
+
+func TestProgressFailureRatio(t *testing.T) {
+	tests := map[string]struct {
+		progress progress
+		expRatio float64
+	}{
+		"Nothing finished": {
+			progress: progress{pending: 3},
+			expRatio: 0,
+		},
+		"Pending renewals are not counted": {
+			progress: progress{succeeded: 3, failed: 1, pending: 6},
+			expRatio: 0.25,
+		},
+		"All failed": {
+			progress: progress{failed: 2},
+			expRatio: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expRatio, test.progress.failureRatio())
+		})
+	}
+}
+
+func TestRunWaitAbortWaitsForInFlight(t *testing.T) {
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+	var crts []runtime.Object
+	for _, name := range []string{"crt-1", "crt-3", "crt-4"} {
+		crts = append(crts, gen.Certificate(name,
+			gen.SetCertificateNamespace("my-namespace"),
+			gen.SetCertificateRevision(1),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue})))
+	}
+	cmClient := cmfake.NewClientset(crts...)
+
+	// Triggering the issuance of crt-3 fails, while the issuance of crt-1,
+	// triggered before in the same wave, is still in progress. It finishes
+	// as soon as it is polled.
+	cmClient.PrependReactor("update", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+		if action.(coretesting.UpdateAction).GetObject().(*cmapi.Certificate).Name == "crt-3" {
+			return true, nil, apierrors.NewInternalError(errors.New("etcd is unavailable"))
+		}
+		return false, nil, nil
+	})
+	cmClient.PrependReactor("get", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+		get := action.(coretesting.GetAction)
+		obj, err := cmClient.Tracker().Get(cmapi.SchemeGroupVersion.WithResource("certificates"), get.GetNamespace(), get.GetName())
+		if err != nil {
+			return false, nil, nil
+		}
+		crt := obj.(*cmapi.Certificate)
+		if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}) {
+			return false, nil, nil
+		}
+		return true, gen.CertificateFrom(crt, gen.SetCertificateRevision(2), gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Issued",
+		})), nil
+	})
+
+	o := NewOptions(ioStreams)
+	o.Wait = true
+	o.Timeout = time.Minute
+	*o.PrintFlags.OutputFormat = "json"
+	o.Factory = &factory.Factory{
+		Namespace:  "my-namespace",
+		CMClient:   cmClient,
+		KubeClient: kubefake.NewClientset(),
+	}
+
+	err := o.Run(t.Context(), []string{"crt-1", "crt-3", "crt-4"})
+	assert.EqualError(t, err, "failed to trigger issuance of Certificate my-namespace/crt-3: Internal error occurred: etcd is unavailable")
+
+	var report RenewalReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []CertificateRenewal{
+		{Namespace: "my-namespace", Name: "crt-1", Result: RenewalRenewed},
+		{Namespace: "my-namespace", Name: "crt-3", Result: RenewalFailed, Reason: "failed to trigger issuance of Certificate my-namespace/crt-3: Internal error occurred: etcd is unavailable"},
+		{Namespace: "my-namespace", Name: "crt-4", Result: RenewalSkipped, Reason: "not renewed, the renewal was aborted after a previous error"},
+	}, report.Certificates)
+}
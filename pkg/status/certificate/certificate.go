@@ -20,11 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 	"github.com/spf13/cobra"
@@ -35,9 +38,11 @@ import (
 	"k8s.io/client-go/tools/reference"
 	"k8s.io/kubectl/pkg/util/templates"
 
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 	"github.com/cert-manager/cmctl/v2/pkg/build"
 	"github.com/cert-manager/cmctl/v2/pkg/convert"
 	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
 )
 
 var (
@@ -48,8 +53,41 @@ var (
 
 // Options is a struct to support status certificate command
 type Options struct {
+	LabelSelector string
+	All           bool
+	AllNamespaces bool
+
+	// Watch is set to keep watching the Certificate and its related resources,
+	// printing the status whenever it changes.
+	Watch bool
+
+	// Explain is set to diagnose known problems of the Certificate and its
+	// related resources, and print them with hints on how to fix them.
+	Explain bool
+
+	// History is set to list all CertificateRequests of the Certificate,
+	// ordered by revision.
+	History bool
+
+	// Graph is the format the graph of the Certificate and its related
+	// resources is printed in, instead of the status. Empty if no graph was
+	// requested.
+	Graph string
+
+	// ExpiringSoonThreshold is the remaining validity below which a Ready
+	// Certificate is reported as expiring soon by the exit code.
+	ExpiringSoonThreshold time.Duration
+
+	// PrintFlags holds the flags used to print the status in a structured
+	// format. If no output format is set, the human readable output is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
 	genericclioptions.IOStreams
 	*factory.Factory
+
+	// buildName is the name of the command, used to suggest commands in the
+	// hints of the diagnosis.
+	buildName string
 }
 
 // Data is a struct containing the information to build a CertificateStatus
@@ -70,27 +108,80 @@ type Data struct {
 	OrderError   error
 	Challenges   []*cmacme.Challenge
 	ChallengeErr error
+	// Requests are all CertificateRequests owned by the Certificate, only
+	// collected if the history was requested
+	Requests      []*cmapi.CertificateRequest
+	RequestsError error
+	// Error is set if the related resources of the Certificate could not be
+	// fetched, only Certificate is set in that case
+	Error error
 }
 
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
-		IOStreams: ioStreams,
+		PrintFlags:            util.NewPrintFlags(),
+		IOStreams:             ioStreams,
+		ExpiringSoonThreshold: cmcmdutil.DefaultExpiringSoonThreshold,
 	}
 }
 
 // NewCmdStatusCert returns a cobra command for status certificate
 func NewCmdStatusCert(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
 	o := NewOptions(ioStreams)
+	o.buildName = build.Name(setupCtx)
 
 	cmd := &cobra.Command{
-		Use:   "certificate",
-		Short: "Get details about the current status of a cert-manager Certificate resource",
+		Use:     "certificate",
+		Aliases: []string{"certificates"},
+		Short:   "Get details about the current status of a cert-manager Certificate resource",
 		Long: templates.LongDesc(`
-Get details about the current status of a cert-manager Certificate resource, including information on related resources like CertificateRequest or Order.`),
+Get details about the current status of a cert-manager Certificate resource, including information on related resources like CertificateRequest or Order.
+
+The certificate and private key in the Secret are compared with the spec of the Certificate, and every mismatch is listed as drift, e.g. when the Secret is stale after the spec was edited.
+
+When multiple Certificates are selected with --all or a label selector, a summary table with one line per Certificate is printed instead.
+
+With --explain, known problems like an issuer that is not ready, a denied CertificateRequest or a failing ACME challenge self check are detected and listed, most severe first, with hints on how to fix them.
+
+With --history, every CertificateRequest of the Certificate that is still retained (see spec.revisionHistoryLimit) is listed by revision, with its approval, its outcome and the serial number and validity of the issued certificate.
+
+With --graph, the graph of the Certificate and its related resources is printed in the Graphviz DOT or the Mermaid format instead of the status, with each resource coloured by its readiness.
+
+The exit code reports the health of the selected Certificates, so that scripts can gate on it:
+0 if the Certificate is Ready, 2 if it is Ready but expires within --expiring-soon-threshold,
+3 if it is not Ready, 4 if it has expired and 5 if the resources could not be fetched.
+When multiple Certificates are selected, the exit code of the least healthy one is returned.`),
 		Example: templates.Examples(build.WithTemplate(setupCtx, `
 # Query status of Certificate with name 'my-crt' in namespace 'my-namespace'
 {{.BuildName}} status certificate my-crt --namespace my-namespace
+
+# Print the status of Certificate with name 'my-crt' as JSON
+{{.BuildName}} status certificate my-crt -o json
+
+# Print the Ready condition message of Certificate with name 'my-crt'
+{{.BuildName}} status certificate my-crt -o jsonpath='{.conditions[?(@.type=="Ready")].message}'
+
+# Diagnose why Certificate with name 'my-crt' is not Ready
+{{.BuildName}} status certificate my-crt --explain
+
+# List the past issuances of Certificate with name 'my-crt'
+{{.BuildName}} status certificate my-crt --history
+
+# Render the graph of Certificate with name 'my-crt' and its related resources as an SVG image
+{{.BuildName}} status certificate my-crt --graph=dot | dot -Tsvg > my-crt.svg
+
+# Watch the status of Certificate with name 'my-crt' until its issuance completes
+{{.BuildName}} status certificate my-crt --watch
+
+# Fail a CI job if Certificate with name 'my-crt' is not Ready or expires within 30 days
+{{.BuildName}} status certificate my-crt --expiring-soon-threshold=720h
+
+# Print a summary of all Certificates in all namespaces
+{{.BuildName}} status certificates --all --all-namespaces
+
+# Print a summary of the Certificates with the label 'app=my-service' in the current context namespace
+{{.BuildName}} status certificates -l app=my-service
 `)),
 		ValidArgsFunction: factory.ValidArgsListCertificates(&o.Factory),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -102,6 +193,16 @@ Get details about the current status of a cert-manager Certificate resource, inc
 		},
 	}
 
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, list the requested Certificates across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().BoolVar(&o.All, "all", o.All, "Print a summary of all Certificates in the given Namespace, or all namespaces with --all-namespaces enabled.")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", o.Watch, "After printing the status, watch the Certificate and its related resources and print the status again whenever it changes, until the Certificate is Ready or its issuance failed.")
+	cmd.Flags().BoolVar(&o.Explain, "explain", o.Explain, "Diagnose known problems of the Certificate and its related resources, and print them ranked by severity with hints on how to fix them.")
+	cmd.Flags().BoolVar(&o.History, "history", o.History, "List every retained CertificateRequest of the Certificate by revision, with its approval, outcome and issued certificate.")
+	cmd.Flags().StringVar(&o.Graph, "graph", o.Graph, fmt.Sprintf("Print the graph of the Certificate and its related resources instead of the status. One of: %s.", strings.Join(graphFormats, "|")))
+	cmd.Flags().DurationVar(&o.ExpiringSoonThreshold, "expiring-soon-threshold", o.ExpiringSoonThreshold, "Remaining validity below which a Ready Certificate is reported as expiring soon by the exit code.")
+	o.PrintFlags.AddFlags(cmd)
+
 	o.Factory = factory.New(cmd)
 
 	return cmd
@@ -109,24 +210,109 @@ Get details about the current status of a cert-manager Certificate resource, inc
 
 // Validate validates the provided options
 func (o *Options) Validate(args []string) error {
-	if len(args) < 1 {
-		return errors.New("the name of the Certificate has to be provided as argument")
-	}
-	if len(args) > 1 {
+	switch {
+	case o.All && len(o.LabelSelector) > 0:
+		return errors.New("cannot specify the --all flag in conjunction with a label selector")
+	case len(args) > 0 && (o.All || len(o.LabelSelector) > 0):
+		return errors.New("cannot specify a Certificate name in conjunction with the --all flag or a label selector")
+	case len(args) > 0 && o.AllNamespaces:
+		return errors.New("cannot specify a Certificate name in conjunction with the --all-namespaces flag")
+	case len(args) > 1:
 		return errors.New("only one argument can be passed in: the name of the Certificate")
+	case len(args) < 1 && !o.isMultiObject():
+		return errors.New("the name of the Certificate has to be provided as argument, or use the --all flag or a label selector to select multiple Certificates")
+	case o.Watch && o.isMultiObject():
+		return errors.New("the --watch flag can only be used with a single Certificate")
+	case o.Explain && o.isMultiObject():
+		return errors.New("the --explain flag can only be used with a single Certificate")
+	case o.History && o.isMultiObject():
+		return errors.New("the --history flag can only be used with a single Certificate")
+	case o.Graph != "" && !slices.Contains(graphFormats, o.Graph):
+		return fmt.Errorf("invalid --graph format %q, must be one of: %s", o.Graph, strings.Join(graphFormats, "|"))
+	case o.Graph != "" && (o.isMultiObject() || o.Watch || o.Explain || o.History):
+		return errors.New("the --graph flag can only be used with a single Certificate, and not in conjunction with the --watch, --explain or --history flags")
+	case o.Graph != "" && util.IsStructuredOutput(o.PrintFlags):
+		return errors.New("the --graph flag cannot be used in conjunction with the --output flag")
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// isMultiObject returns true if multiple Certificates are selected, in which
+// case a summary of each Certificate is printed.
+func (o *Options) isMultiObject() bool {
+	return o.All || len(o.LabelSelector) > 0
+}
+
 // Run executes status certificate command
 func (o *Options) Run(ctx context.Context, args []string) error {
+	if o.isMultiObject() {
+		return o.runSummary(ctx)
+	}
+
+	if o.Watch {
+		return o.runWatch(ctx, args[0])
+	}
+
 	data, err := o.GetResources(ctx, args[0])
 	if err != nil {
+		return cmcmdutil.SetFetchErrorExitCode(err)
+	}
+
+	if o.Graph != "" {
+		fmt.Fprint(o.Out, graphFromResources(data).render(o.Graph))
+	} else if err := o.printStatus(o.statusFromResources(data)); err != nil {
 		return err
 	}
 
-	// Build status of Certificate with data gathered
+	cmcmdutil.SetExitCodeValue(exitCodeFromResources(data, clock.Now(), o.ExpiringSoonThreshold))
+	return nil
+}
+
+// exitCodeFromResources returns the exit code reporting the health of the
+// Certificate in data at time now, see cmcmdutil.CertificateExitCode.
+func exitCodeFromResources(data *Data, now time.Time, expiringSoonThreshold time.Duration) int {
+	crt := data.Certificate
+
+	ready := false
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
+		ready = cond.Status == cmmeta.ConditionTrue
+	}
+
+	var notAfter time.Time
+	if crt.Status.NotAfter != nil {
+		notAfter = crt.Status.NotAfter.Time
+	}
+
+	return cmcmdutil.CertificateExitCode(ready, notAfter, now, expiringSoonThreshold)
+}
+
+// statusFromResources builds the status of the Certificate from data, with
+// the diagnosis and the history added if they were requested.
+func (o *Options) statusFromResources(data *Data) *CertificateStatus {
 	status := StatusFromResources(data)
+	if o.Explain {
+		status.Findings = Diagnose(data, clock.Now(), o.buildName)
+	}
+	if o.History {
+		status.History = HistoryFromRequests(data.Requests, data.RequestsError)
+	}
+	return status
+}
+
+// printStatus prints status in the requested output format.
+func (o *Options) printStatus(status *CertificateStatus) error {
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		return util.PrintObject(printer, status.Output(), o.Out)
+	}
 
 	fmt.Fprint(o.Out, status.String())
 
@@ -148,6 +334,19 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 		return nil, fmt.Errorf("error when getting Certificate resource: %v", err)
 	}
 
+	return o.GetResourcesForCertificate(ctx, clientSet, crt)
+}
+
+// GetResourcesForCertificate collects all related resources of crt in a Data
+// struct. clientSet is used to get the Secret and the events.
+func (o *Options) GetResourcesForCertificate(ctx context.Context, clientSet kubernetes.Interface, crt *cmapi.Certificate) (*Data, error) {
+	return o.getResourcesForCertificate(ctx, clientSet, util.NewOwnedResources(o.CMClient), crt)
+}
+
+// getResourcesForCertificate is GetResourcesForCertificate, with the
+// CertificateRequests, Orders and Challenges found in owned, so that they are
+// only listed once for all Certificates of a namespace.
+func (o *Options) getResourcesForCertificate(ctx context.Context, clientSet kubernetes.Interface, owned *util.OwnedResources, crt *cmapi.Certificate) (*Data, error) {
 	crtRef, err := reference.GetReference(scheme, crt)
 	if err != nil {
 		return nil, err
@@ -191,7 +390,7 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 
 	// TODO: What about timing issues? When I query condition it's not ready yet, but then looking for cr it's finished and deleted
 	// Try find the CertificateRequest that is owned by crt and has the correct revision
-	req, reqErr := findMatchingCR(o.CMClient, ctx, crt)
+	req, reqErr := findMatchingCR(ctx, owned, crt)
 	if reqErr != nil {
 		reqErr = fmt.Errorf("error when finding CertificateRequest: %w\n", reqErr)
 	} else if req == nil {
@@ -221,7 +420,7 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 	// Nothing to output about Order and Challenge if no CR or not ACME Issuer
 	if req != nil && issuer != nil && issuer.GetSpec().ACME != nil {
 		// Get Order
-		order, orderErr = findMatchingOrder(o.CMClient, ctx, req)
+		order, orderErr = util.FindMatchingOrder(ctx, owned, req)
 		if orderErr != nil {
 			orderErr = fmt.Errorf("error when finding Order: %w\n", orderErr)
 		} else if order == nil {
@@ -229,7 +428,7 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 		}
 
 		if order != nil {
-			challenges, challengeErr = findMatchingChallenges(o.CMClient, ctx, order)
+			challenges, challengeErr = owned.Challenges(ctx, order)
 			if challengeErr != nil {
 				challengeErr = fmt.Errorf("error when finding Challenges: %w\n", challengeErr)
 			} else if len(challenges) == 0 {
@@ -238,6 +437,14 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 		}
 	}
 
+	var (
+		requests    []*cmapi.CertificateRequest
+		requestsErr error
+	)
+	if o.History {
+		requests, requestsErr = owned.CertificateRequests(ctx, crt)
+	}
+
 	return &Data{
 		Certificate:  crt,
 		CrtEvents:    crtEvents,
@@ -255,6 +462,9 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 		OrderError:   orderErr,
 		Challenges:   challenges,
 		ChallengeErr: challengeErr,
+
+		Requests:      requests,
+		RequestsError: requestsErr,
 	}, nil
 }
 
@@ -265,6 +475,7 @@ func StatusFromResources(data *Data) *CertificateStatus {
 		withEvents(data.CrtEvents).
 		withGenericIssuer(data.Issuer, data.IssuerKind, data.IssuerEvents, data.IssuerError).
 		withSecret(data.Secret, data.SecretEvents, data.SecretError).
+		withDrift(data.Certificate, data.Secret).
 		withCR(data.Req, data.ReqEvents, data.ReqError).
 		withOrder(data.Order, data.OrderError).
 		withChallenges(data.Challenges, data.ChallengeErr)
@@ -280,23 +491,14 @@ func formatStringSlice(strs []string) string {
 	return result.String()
 }
 
-// formatTimeString returns the time as a string
-// If nil, return "<none>"
-func formatTimeString(t *metav1.Time) string {
-	if t == nil {
-		return "<none>"
-	}
-	return t.Time.Format(time.RFC3339)
-}
-
-// findMatchingCR tries to find a CertificateRequest that is owned by crt and has the correct revision annotated from reqs.
+// findMatchingCR tries to find a CertificateRequest that is owned by crt and has the correct revision annotated.
 // If none found returns nil
 // If one found returns the CR
 // If multiple found or error occurs when listing CRs, returns error
-func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
-	reqs, err := cmClient.CertmanagerV1().CertificateRequests(crt.Namespace).List(ctx, metav1.ListOptions{})
+func findMatchingCR(ctx context.Context, owned *util.OwnedResources, crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
+	reqs, err := owned.CertificateRequests(ctx, crt)
 	if err != nil {
-		return nil, fmt.Errorf("error when listing CertificateRequest resources: %w", err)
+		return nil, err
 	}
 
 	possibleMatches := []*cmapi.CertificateRequest{}
@@ -308,10 +510,9 @@ func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi
 	if crt.Status.Revision != nil {
 		nextRevision = *crt.Status.Revision + 1
 	}
-	for _, req := range reqs.Items {
-		if predicate.CertificateRequestRevision(nextRevision)(&req) && /* #nosec G601 -- Pointer does not outlive function scope */
-			predicate.ResourceOwnedBy[*cmapi.CertificateRequest](crt)(&req) /* #nosec G601 -- Pointer does not outlive function scope */ {
-			possibleMatches = append(possibleMatches, req.DeepCopy())
+	for _, req := range reqs {
+		if predicate.CertificateRequestRevision(nextRevision)(req) {
+			possibleMatches = append(possibleMatches, req)
 		}
 	}
 
@@ -325,33 +526,6 @@ func findMatchingCR(cmClient cmclient.Interface, ctx context.Context, crt *cmapi
 	}
 }
 
-// findMatchingOrder tries to find an Order that is owned by req.
-// If none found returns nil
-// If one found returns the Order
-// If multiple found or error occurs when listing Orders, returns error
-func findMatchingOrder(cmClient cmclient.Interface, ctx context.Context, req *cmapi.CertificateRequest) (*cmacme.Order, error) {
-	orders, err := cmClient.AcmeV1().Orders(req.Namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	possibleMatches := []*cmacme.Order{}
-	for _, order := range orders.Items {
-		if predicate.ResourceOwnedBy[*cmacme.Order](req)(&order) /* #nosec G601 -- Pointer does not outlive function scope */ {
-			possibleMatches = append(possibleMatches, order.DeepCopy())
-		}
-	}
-
-	switch {
-	case len(possibleMatches) < 1:
-		return nil, nil
-	case len(possibleMatches) == 1:
-		return possibleMatches[0], nil
-	default:
-		return nil, fmt.Errorf("found multiple orders owned by CertificateRequest %s", req.Name)
-	}
-}
-
 func getGenericIssuer(cmClient cmclient.Interface, ctx context.Context, crt *cmapi.Certificate) (cmapi.GenericIssuer, string, error) {
 	issuerKind := crt.Spec.IssuerRef.Kind
 	if issuerKind == "" {
@@ -366,33 +540,15 @@ func getGenericIssuer(cmClient cmclient.Interface, ctx context.Context, crt *cma
 	case issuerKind == "Issuer":
 		issuer, issuerErr := cmClient.CertmanagerV1().Issuers(crt.Namespace).Get(ctx, crt.Spec.IssuerRef.Name, metav1.GetOptions{})
 		if issuerErr != nil {
-			issuerErr = fmt.Errorf("error when getting Issuer: %v\n", issuerErr)
+			issuerErr = fmt.Errorf("error when getting Issuer: %w\n", issuerErr)
 		}
 		return issuer, issuerKind, issuerErr
 	default:
 		// ClusterIssuer
 		clusterIssuer, issuerErr := cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, crt.Spec.IssuerRef.Name, metav1.GetOptions{})
 		if issuerErr != nil {
-			issuerErr = fmt.Errorf("error when getting ClusterIssuer: %v\n", issuerErr)
+			issuerErr = fmt.Errorf("error when getting ClusterIssuer: %w\n", issuerErr)
 		}
 		return clusterIssuer, issuerKind, issuerErr
 	}
 }
-
-// findMatchingChallenges tries to find Challenges that are owned by order.
-// If none found returns empty slice.
-func findMatchingChallenges(cmClient cmclient.Interface, ctx context.Context, order *cmacme.Order) ([]*cmacme.Challenge, error) {
-	challenges, err := cmClient.AcmeV1().Challenges(order.Namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	possibleMatches := []*cmacme.Challenge{}
-	for _, challenge := range challenges.Items {
-		if predicate.ResourceOwnedBy[*cmacme.Challenge](order)(&challenge) /* #nosec G601 -- Pointer does not outlive function scope */ {
-			possibleMatches = append(possibleMatches, challenge.DeepCopy())
-		}
-	}
-
-	return possibleMatches, nil
-}
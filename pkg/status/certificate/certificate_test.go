@@ -17,8 +17,15 @@ limitations under the License.
 package certificate
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"math/big"
 	"strings"
 	"testing"
@@ -27,10 +34,23 @@ import (
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
 )
 
 func TestFormatStringSlice(t *testing.T) {
@@ -297,9 +317,16 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 		"Correct information extracted from Secret resource": {
 			inputData: &Data{
 				Certificate: gen.Certificate("test-crt",
-					gen.SetCertificateNamespace(ns)),
+					gen.SetCertificateNamespace(ns),
+					gen.SetCertificateCommonName("test"),
+					gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "ca", Kind: "Issuer"})),
 				Secret: gen.Secret("existing-tls-secret",
 					gen.SetSecretNamespace(ns),
+					gen.SetSecretAnnotations(map[string]string{
+						cmapi.CertificateNameKey:      "test-crt",
+						cmapi.IssuerNameAnnotationKey: "ca",
+						cmapi.IssuerKindAnnotationKey: "Issuer",
+					}),
 					gen.SetSecretData(map[string][]byte{"tls.crt": tlsCrt})),
 				SecretError:  nil,
 				SecretEvents: dummyEventList,
@@ -483,3 +510,852 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 		})
 	}
 }
+
+func TestCertificateStatusOutput(t *testing.T) {
+	ns := "ns1"
+
+	tests := map[string]struct {
+		inputData    *Data
+		outputFormat string
+		expOutput    string
+	}{
+		"Structured YAML output contains the related resources and their errors": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace(ns),
+					gen.SetCertificateDNSNames("example.com")),
+				IssuerError: errors.New("error when getting Issuer: issuers.cert-manager.io \"test-issuer\" not found\n"),
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestNamespace(ns),
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Message: "example"})),
+				SecretError: errors.New("error when finding Secret \"test-secret\": secrets \"test-secret\" not found\n"),
+			},
+			outputFormat: "yaml",
+			expOutput: `apiVersion: status.cmctl.cert-manager.io/v1alpha1
+certificateRequest:
+  conditions:
+  - message: example
+    status: "True"
+    type: Ready
+  name: test-req
+  namespace: ns1
+creationTime: null
+dnsNames:
+- example.com
+issuer:
+  error: 'error when getting Issuer: issuers.cert-manager.io "test-issuer" not found'
+kind: CertificateStatus
+name: test-crt
+namespace: ns1
+secret:
+  error: 'error when finding Secret "test-secret": secrets "test-secret" not found'
+`,
+		},
+		"JSONPath output can select a single field": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace(ns),
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady,
+						Status: cmmeta.ConditionFalse, Message: "Issuing certificate as Secret does not exist"})),
+			},
+			outputFormat: `jsonpath={.conditions[?(@.type=="Ready")].message}`,
+			expOutput:    "Issuing certificate as Secret does not exist",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			printFlags := util.NewPrintFlags()
+			*printFlags.OutputFormat = test.outputFormat
+			printer, err := printFlags.ToPrinter()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err := util.PrintObject(printer, StatusFromResources(test.inputData).Output(), &buf); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expOutput, buf.String())
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		options *Options
+		args    []string
+		expErr  bool
+	}{
+		"A single Certificate name is valid": {
+			options: &Options{},
+			args:    []string{"abc"},
+		},
+		"No Certificate name and no selection flags errors": {
+			options: &Options{},
+			expErr:  true,
+		},
+		"Multiple Certificate names errors": {
+			options: &Options{},
+			args:    []string{"abc", "def"},
+			expErr:  true,
+		},
+		"--all with --all-namespaces is valid": {
+			options: &Options{All: true, AllNamespaces: true},
+		},
+		"Label selector is valid": {
+			options: &Options{LabelSelector: "foo=bar"},
+		},
+		"Certificate name with --all errors": {
+			options: &Options{All: true},
+			args:    []string{"abc"},
+			expErr:  true,
+		},
+		"Certificate name with --all-namespaces errors": {
+			options: &Options{AllNamespaces: true},
+			args:    []string{"abc"},
+			expErr:  true,
+		},
+		"--all with a label selector errors": {
+			options: &Options{All: true, LabelSelector: "foo=bar"},
+			expErr:  true,
+		},
+		"--all-namespaces without --all or a label selector errors": {
+			options: &Options{AllNamespaces: true},
+			expErr:  true,
+		},
+		"--explain with a Certificate name is valid": {
+			options: &Options{Explain: true},
+			args:    []string{"abc"},
+		},
+		"--explain with --all errors": {
+			options: &Options{All: true, Explain: true},
+			expErr:  true,
+		},
+		"--history with a label selector errors": {
+			options: &Options{LabelSelector: "foo=bar", History: true},
+			expErr:  true,
+		},
+		"--graph=mermaid with a Certificate name is valid": {
+			options: &Options{Graph: "mermaid"},
+			args:    []string{"abc"},
+		},
+		"Unknown --graph format errors": {
+			options: &Options{Graph: "svg"},
+			args:    []string{"abc"},
+			expErr:  true,
+		},
+		"--graph with --watch errors": {
+			options: &Options{Graph: "dot", Watch: true},
+			args:    []string{"abc"},
+			expErr:  true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.options.Validate(test.args)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestSummaryFromResources(t *testing.T) {
+	timestamp, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		inputData *Data
+		expOutput *CertificateSummary
+	}{
+		"Ready Certificate with issued CertificateRequest": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace("ns1"),
+					gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"}),
+					gen.SetCertificateNotAfter(metav1.Time{Time: timestamp}),
+					gen.SetCertificateRenewalTime(metav1.Time{Time: timestamp}),
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue})),
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Reason: "Issued"})),
+			},
+			expOutput: &CertificateSummary{
+				Namespace:   "ns1",
+				Name:        "test-crt",
+				Ready:       cmmeta.ConditionTrue,
+				Issuer:      "ClusterIssuer/letsencrypt",
+				NotAfter:    &metav1.Time{Time: timestamp},
+				RenewalTime: &metav1.Time{Time: timestamp},
+				Request:     "Issued",
+			},
+		},
+		"Not ready Certificate with failing challenges": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace("ns1"),
+					gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "letsencrypt"}),
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse})),
+				Req: gen.CertificateRequest("test-req",
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}),
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Pending"})),
+				Challenges: []*cmacme.Challenge{
+					{Status: cmacme.ChallengeStatus{State: cmacme.Invalid}},
+					{Status: cmacme.ChallengeStatus{State: cmacme.Pending, Processing: true, Reason: "Waiting for DNS-01 challenge propagation"}},
+					{Status: cmacme.ChallengeStatus{State: cmacme.Valid}},
+				},
+			},
+			expOutput: &CertificateSummary{
+				Namespace:         "ns1",
+				Name:              "test-crt",
+				Ready:             cmmeta.ConditionFalse,
+				Issuer:            "Issuer/letsencrypt",
+				Request:           "Pending",
+				FailingChallenges: 2,
+			},
+		},
+		"Certificate without conditions or CertificateRequest": {
+			inputData: &Data{
+				Certificate: gen.Certificate("test-crt",
+					gen.SetCertificateNamespace("ns1"),
+					gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "ca", Kind: "Issuer"})),
+				ReqError: errors.New("No CertificateRequest found for this Certificate\n"),
+			},
+			expOutput: &CertificateSummary{
+				Namespace: "ns1",
+				Name:      "test-crt",
+				Ready:     cmmeta.ConditionUnknown,
+				Issuer:    "Issuer/ca",
+				Request:   "<none>",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expOutput, SummaryFromResources(test.inputData))
+		})
+	}
+}
+
+func TestExitCodeFromResources(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue})
+	notReady := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse})
+
+	tests := map[string]struct {
+		crt     *cmapi.Certificate
+		expCode int
+	}{
+		"Ready Certificate": {
+			crt:     gen.Certificate("test-crt", ready, gen.SetCertificateNotAfter(metav1.Time{Time: now.Add(30 * 24 * time.Hour)})),
+			expCode: cmcmdutil.ExitCodeReady,
+		},
+		"Ready Certificate expiring within the threshold": {
+			crt:     gen.Certificate("test-crt", ready, gen.SetCertificateNotAfter(metav1.Time{Time: now.Add(24 * time.Hour)})),
+			expCode: cmcmdutil.ExitCodeExpiringSoon,
+		},
+		"Not ready Certificate": {
+			crt:     gen.Certificate("test-crt", notReady, gen.SetCertificateNotAfter(metav1.Time{Time: now.Add(30 * 24 * time.Hour)})),
+			expCode: cmcmdutil.ExitCodeNotReady,
+		},
+		"Certificate without conditions or issued certificate": {
+			crt:     gen.Certificate("test-crt"),
+			expCode: cmcmdutil.ExitCodeNotReady,
+		},
+		"Expired Certificate": {
+			crt:     gen.Certificate("test-crt", notReady, gen.SetCertificateNotAfter(metav1.Time{Time: now.Add(-time.Hour)})),
+			expCode: cmcmdutil.ExitCodeExpired,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expCode, exitCodeFromResources(&Data{Certificate: test.crt}, now, 7*24*time.Hour))
+		})
+	}
+}
+
+func TestPrintSummaries(t *testing.T) {
+	timestamp, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summaries := []*CertificateSummary{
+		{Namespace: "ns1", Name: "crt-1", Ready: cmmeta.ConditionTrue, Issuer: "Issuer/ca",
+			NotAfter: &metav1.Time{Time: timestamp}, RenewalTime: &metav1.Time{Time: timestamp}, Request: "Issued"},
+		{Namespace: "ns2", Name: "crt-2", Ready: cmmeta.ConditionFalse, Issuer: "ClusterIssuer/letsencrypt",
+			Request: "Pending", FailingChallenges: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := printSummaries(&buf, summaries, true); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `NAMESPACE  NAME   READY  ISSUER                     NOT AFTER             RENEWAL TIME          REQUEST  FAILING CHALLENGES
+ns1        crt-1  True   Issuer/ca                  2020-09-16T09:26:18Z  2020-09-16T09:26:18Z  Issued   0
+ns2        crt-2  False  ClusterIssuer/letsencrypt  <none>                <none>                Pending  1
+`, buf.String())
+}
+
+func TestGetSummaryData(t *testing.T) {
+	ns := gen.SetCertificateNamespace("ns1")
+	cmClient := cmfake.NewClientset(
+		gen.Certificate("crt-1", ns, gen.SetCertificateUID("uid-1")),
+		gen.Certificate("crt-2", gen.SetCertificateNamespace("ns2"), gen.SetCertificateUID("uid-2")),
+		gen.Certificate("crt-3", ns, gen.SetCertificateUID("uid-3")),
+		gen.CertificateRequest("req-1", gen.SetCertificateRequestNamespace("ns1"),
+			gen.SetCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: "1"}),
+			gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(
+				gen.Certificate("crt-1", ns, gen.SetCertificateUID("uid-1")), cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind)))),
+	)
+	listedRequests := 0
+	cmClient.PrependReactor("list", "certificaterequests", func(coretesting.Action) (bool, runtime.Object, error) {
+		listedRequests++
+		return false, nil, nil
+	})
+
+	// Searching the events of crt-2 in ns2 fails.
+	kubeClient := kubefake.NewClientset()
+	kubeClient.PrependReactor("list", "events", func(action coretesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "ns2" {
+			return true, nil, errors.New("connection refused")
+		}
+		return false, nil, nil
+	})
+
+	o := NewOptions(genericclioptions.IOStreams{})
+	o.AllNamespaces = true
+	o.Factory = &factory.Factory{CMClient: cmClient}
+
+	allData, exitCode, err := o.getSummaryData(t.Context(), kubeClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, listedRequests, "CertificateRequests should be listed once per namespace")
+	assert.Equal(t, cmcmdutil.ExitCodeFetchError, exitCode)
+	byName := map[string]*Data{}
+	for _, data := range allData {
+		byName[data.Certificate.Name] = data
+	}
+	if assert.Len(t, byName, 3) {
+		if assert.NotNil(t, byName["crt-1"].Req) {
+			assert.Equal(t, "req-1", byName["crt-1"].Req.Name)
+		}
+		assert.NoError(t, byName["crt-1"].Error)
+		assert.EqualError(t, byName["crt-2"].Error, "connection refused")
+		assert.Equal(t, "<unknown>", SummaryFromResources(byName["crt-2"]).Request)
+		assert.NoError(t, byName["crt-3"].Error)
+		assert.Nil(t, byName["crt-3"].Req)
+	}
+}
+
+func TestTransitionsBetween(t *testing.T) {
+	timestamp, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prev := stateFromResources(&Data{
+		Certificate: gen.Certificate("test-crt",
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, Reason: "DoesNotExist"}),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue})),
+		Req: gen.CertificateRequest("test-crt-1"),
+		Challenges: []*cmacme.Challenge{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-crt-1-0"}, Status: cmacme.ChallengeStatus{State: cmacme.Pending}},
+		},
+	})
+	cur := stateFromResources(&Data{
+		Certificate: gen.Certificate("test-crt",
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Reason: "Ready"})),
+		Req: gen.CertificateRequest("test-crt-1",
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Reason: "Issued"})),
+		Secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-secret"}},
+	})
+
+	assert.Equal(t, []Transition{
+		{Time: timestamp, Resource: "Certificate Issuing condition", From: "True"},
+		{Time: timestamp, Resource: "Certificate Ready condition", From: "False (DoesNotExist)", To: "True (Ready)"},
+		{Time: timestamp, Resource: "CertificateRequest test-crt-1", From: "WaitingForApproval", To: "Issued"},
+		{Time: timestamp, Resource: "Challenge test-crt-1-0", From: "pending"},
+		{Time: timestamp, Resource: "Secret test-secret", To: "exists"},
+	}, transitionsBetween(prev, cur, timestamp))
+
+	assert.Empty(t, transitionsBetween(cur, cur, timestamp))
+}
+
+func TestFinalState(t *testing.T) {
+	timestamp, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		crt      *cmapi.Certificate
+		expState string
+		expFinal bool
+	}{
+		"Issuance in progress is not final": {
+			crt: gen.Certificate("test-crt",
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue})),
+			expFinal: false,
+		},
+		"Ready Certificate is final": {
+			crt: gen.Certificate("test-crt",
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"})),
+			expState: "Ready: Certificate is up to date and has not expired",
+			expFinal: true,
+		},
+		"Failed issuance is final even if the Certificate is still Ready": {
+			crt: gen.Certificate("test-crt",
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "The certificate request has failed to complete and will be retried"})),
+			expState: "Issuance failed: The certificate request has failed to complete and will be retried",
+			expFinal: true,
+		},
+		"Last failure time is final": {
+			crt: gen.Certificate("test-crt",
+				gen.SetCertificateLastFailureTime(metav1.Time{Time: timestamp})),
+			expState: "Issuance failed at 2020-09-16T09:26:18Z",
+			expFinal: true,
+		},
+		"Not ready Certificate is not final": {
+			crt: gen.Certificate("test-crt",
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse})),
+			expFinal: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state, final := finalState(test.crt)
+			assert.Equal(t, test.expState, state)
+			assert.Equal(t, test.expFinal, final)
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tests := map[string]struct {
+		crt      *cmapi.Certificate
+		expFinal string
+	}{
+		"Watch of a Ready Certificate returns right away": {
+			crt: gen.Certificate("test-crt", gen.SetCertificateNamespace("ns1"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"})),
+			expFinal: "Final state: Ready: Certificate is up to date and has not expired\nTimeline: <none>\n",
+		},
+		"Watch of a Certificate whose issuance failed returns right away": {
+			crt: gen.Certificate("test-crt", gen.SetCertificateNamespace("ns1"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "The certificate request has failed to complete and will be retried"})),
+			expFinal: "Final state: Issuance failed: The certificate request has failed to complete and will be retried\nTimeline: <none>\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := NewOptions(ioStreams)
+			o.Factory = &factory.Factory{Namespace: "ns1", CMClient: cmfake.NewClientset(test.crt)}
+
+			ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+			defer cancel()
+			if err := o.watch(ctx, kubefake.NewClientset(), "test-crt"); err != nil {
+				t.Fatal(err)
+			}
+			assert.NoError(t, ctx.Err(), "the watch should return before the timeout")
+			assert.True(t, strings.HasSuffix(out.String(), test.expFinal), "unexpected output:\n%s", out.String())
+		})
+	}
+}
+
+func TestRelatedFilter(t *testing.T) {
+	crt := gen.Certificate("test-crt", gen.SetCertificateNamespace("ns1"), gen.SetCertificateUID("crt-uid"))
+	req := gen.CertificateRequest("test-req", gen.SetCertificateRequestNamespace("ns1"),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))))
+	req.UID = "req-uid"
+	order := gen.Order("test-order", gen.SetOrderNamespace("ns1"))
+	order.UID = "order-uid"
+	order.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(req, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))}
+	challenge := gen.Challenge("test-challenge", gen.SetChallengeNamespace("ns1"))
+	challenge.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(order, cmacme.SchemeGroupVersion.WithKind(cmacme.OrderKind))}
+
+	otherCrt := gen.Certificate("other-crt", gen.SetCertificateNamespace("ns1"), gen.SetCertificateUID("other-uid"))
+	otherReq := gen.CertificateRequest("other-req", gen.SetCertificateRequestNamespace("ns1"),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(otherCrt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))))
+	orphanOrder := gen.Order("orphan-order", gen.SetOrderNamespace("ns1"))
+	orphanOrder.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(otherReq, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))}
+
+	requests := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	orders := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, obj := range []any{req, otherReq} {
+		if err := requests.Add(obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := orders.Add(order); err != nil {
+		t.Fatal(err)
+	}
+
+	filter := &relatedFilter{crt: crt, requests: requests, orders: orders}
+
+	tests := map[string]struct {
+		obj        any
+		expRelated bool
+	}{
+		"The Certificate is related":                                 {obj: crt, expRelated: true},
+		"Another Certificate is not related":                         {obj: otherCrt, expRelated: false},
+		"A CertificateRequest owned by the Certificate is related":   {obj: req, expRelated: true},
+		"A CertificateRequest of another Certificate is not related": {obj: otherReq, expRelated: false},
+		"An Order owned by a related CertificateRequest is related":  {obj: order, expRelated: true},
+		"An Order of another CertificateRequest is not related":      {obj: orphanOrder, expRelated: false},
+		"A Challenge owned by a related Order is related":            {obj: challenge, expRelated: true},
+		"A deleted Challenge owned by a related Order is related": {
+			obj:        cache.DeletedFinalStateUnknown{Key: "ns1/test-challenge", Obj: challenge},
+			expRelated: true,
+		},
+		"The Secret is related": {obj: &corev1.Secret{}, expRelated: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expRelated, filter.isRelated(test.obj))
+		})
+	}
+}
+
+func TestPrintTimeline(t *testing.T) {
+	timestamp, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	printTimeline(buf, "Ready: Certificate is up to date and has not expired", []Transition{
+		{Time: timestamp, Resource: "CertificateRequest test-crt-1", To: "Pending"},
+		{Time: timestamp, Resource: "CertificateRequest test-crt-1", From: "Pending", To: "Issued"},
+	})
+	assert.Equal(t, `
+Final state: Ready: Certificate is up to date and has not expired
+Timeline:
+- 2020-09-16T09:26:18Z  CertificateRequest test-crt-1: <none> -> Pending
+- 2020-09-16T09:26:18Z  CertificateRequest test-crt-1: Pending -> Issued
+`, buf.String())
+}
+
+func TestDiagnose(t *testing.T) {
+	now := time.Date(2020, 9, 16, 12, 0, 0, 0, time.UTC)
+	lastFailure := metav1.NewTime(time.Date(2020, 9, 16, 9, 0, 0, 0, time.UTC))
+
+	crt := gen.Certificate("test-crt",
+		gen.SetCertificateNamespace("ns1"),
+		gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"}))
+	readyIssuer := gen.ClusterIssuer("letsencrypt",
+		gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}))
+	approvedReq := gen.CertificateRequest("test-crt-1",
+		gen.SetCertificateRequestNamespace("ns1"),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}))
+
+	tests := map[string]struct {
+		data       *Data
+		expReasons []string
+		expMessage string
+	}{
+		"Ready Certificate has no findings": {
+			data:       &Data{Certificate: crt, Issuer: readyIssuer, IssuerKind: "ClusterIssuer", Req: approvedReq},
+			expReasons: []string{},
+		},
+		"Missing issuer is reported": {
+			data: &Data{
+				Certificate: crt,
+				IssuerError: fmt.Errorf("error when getting ClusterIssuer: %w", apierrors.NewNotFound(schema.GroupResource{Group: "cert-manager.io", Resource: "clusterissuers"}, "letsencrypt")),
+			},
+			expReasons: []string{"IssuerNotFound"},
+			expMessage: `The ClusterIssuer "letsencrypt" referenced by the Certificate does not exist in the cluster.`,
+		},
+		"Issuer not ready is reported with its condition": {
+			data: &Data{
+				Certificate: crt,
+				Issuer: gen.ClusterIssuer("letsencrypt",
+					gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionFalse, Reason: "ErrRegisterACMEAccount", Message: "Failed to register ACME account"})),
+				IssuerKind: "ClusterIssuer",
+			},
+			expReasons: []string{"IssuerNotReady"},
+			expMessage: `The ClusterIssuer "letsencrypt" is not ready: Failed to register ACME account (ErrRegisterACMEAccount).`,
+		},
+		"Secret owned by another Certificate is reported": {
+			data: &Data{
+				Certificate: crt,
+				Issuer:      readyIssuer,
+				Secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name: "test-tls", Namespace: "ns1",
+					Annotations: map[string]string{cmapi.CertificateNameKey: "other-crt"},
+				}},
+			},
+			expReasons: []string{"SecretOwnedByOtherCertificate"},
+			expMessage: `The Secret "test-tls" is also used by the Certificate "other-crt", both Certificates keep overwriting each other's certificate.`,
+		},
+		"Denied CertificateRequest is ranked before the backoff": {
+			data: &Data{
+				Certificate: gen.CertificateFrom(crt,
+					gen.SetCertificateLastFailureTime(lastFailure),
+					gen.SetCertificateIssuanceAttempts(ptr.To(1))),
+				Issuer: readyIssuer,
+				Req: gen.CertificateRequest("test-crt-1",
+					gen.SetCertificateRequestNamespace("ns1"),
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Reason: "policy.cert-manager.io", Message: "No policy approved this request"})),
+			},
+			expReasons: []string{"RequestDenied", "IssuanceBackoff"},
+			expMessage: `The CertificateRequest "test-crt-1" was denied: No policy approved this request (policy.cert-manager.io).`,
+		},
+		"CertificateRequest waiting for approval is reported": {
+			data: &Data{
+				Certificate: crt,
+				Issuer:      readyIssuer,
+				Req:         gen.CertificateRequest("test-crt-1", gen.SetCertificateRequestNamespace("ns1")),
+			},
+			expReasons: []string{"RequestNotApproved"},
+		},
+		"DNS01 self check failing is reported": {
+			data: &Data{
+				Certificate: crt,
+				Issuer:      readyIssuer,
+				Req:         approvedReq,
+				Order:       gen.Order("test-crt-1-1", gen.SetOrderState(cmacme.Pending)),
+				Challenges: []*cmacme.Challenge{gen.Challenge("test-crt-1-1-1",
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+					gen.SetChallengeDNSName("*.example.com"),
+					gen.SetChallengePresented(true),
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeReason("Waiting for DNS-01 challenge propagation: DNS record for \"example.com\" not yet propagated"))},
+			},
+			expReasons: []string{"DNS01PropagationCheckFailing"},
+		},
+		"Failed Order and Challenge are reported": {
+			data: &Data{
+				Certificate: crt,
+				Issuer:      readyIssuer,
+				Req:         approvedReq,
+				Order:       gen.Order("test-crt-1-1", gen.SetOrderState(cmacme.Invalid), gen.SetOrderReason("authorization failed")),
+				Challenges: []*cmacme.Challenge{gen.Challenge("test-crt-1-1-1",
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+					gen.SetChallengeState(cmacme.Invalid),
+					gen.SetChallengeReason("Invalid response from http://example.com/.well-known/acme-challenge/token: 404"))},
+			},
+			expReasons: []string{"OrderFailed", "ChallengeFailed"},
+		},
+		"Backoff after failed issuance reports the next retry time": {
+			data: &Data{
+				Certificate: gen.CertificateFrom(crt,
+					gen.SetCertificateLastFailureTime(lastFailure),
+					gen.SetCertificateIssuanceAttempts(ptr.To(3)),
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, Reason: "Failed", Message: "The certificate request has failed to complete and will be retried"})),
+				Issuer: readyIssuer,
+			},
+			expReasons: []string{"IssuanceBackoff"},
+			expMessage: "Issuance failed 3 time(s), most recently at 2020-09-16T09:00:00Z (The certificate request has failed to complete and will be retried). cert-manager backs off and retries at 2020-09-16T13:00:00Z, in 60m.",
+		},
+		"Backoff is not reported while issuing": {
+			data: &Data{
+				Certificate: gen.CertificateFrom(crt,
+					gen.SetCertificateLastFailureTime(lastFailure),
+					gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue})),
+				Issuer: readyIssuer,
+			},
+			expReasons: []string{},
+		},
+		"Expired certificate is reported": {
+			data: &Data{
+				Certificate: gen.CertificateFrom(crt, gen.SetCertificateNotAfter(metav1.NewTime(now.Add(-time.Hour)))),
+				Issuer:      readyIssuer,
+			},
+			expReasons: []string{"CertificateExpired"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			findings := Diagnose(test.data, now, "cmctl")
+
+			reasons := []string{}
+			for _, f := range findings {
+				reasons = append(reasons, f.Reason)
+			}
+			assert.Equal(t, test.expReasons, reasons)
+
+			if test.expMessage != "" {
+				assert.Equal(t, test.expMessage, findings[0].Message)
+			}
+		})
+	}
+}
+
+func TestIssuanceBackoff(t *testing.T) {
+	assert.Equal(t, time.Hour, issuanceBackoff(1))
+	assert.Equal(t, 4*time.Hour, issuanceBackoff(3))
+	assert.Equal(t, 32*time.Hour, issuanceBackoff(6))
+	assert.Equal(t, 32*time.Hour, issuanceBackoff(100))
+}
+
+func TestFindingsToString(t *testing.T) {
+	assert.Equal(t, "Diagnosis: no known problems found\n", FindingsToString([]Finding{}))
+	assert.Equal(t, `Diagnosis:
+  1. [Error] IssuerNotReady: The Issuer "ca" is not ready.
+     Hint: Fix the Issuer.
+  2. [Info] RequestPending: Waiting for the issuer.
+`, FindingsToString([]Finding{
+		{Severity: SeverityError, Reason: "IssuerNotReady", Message: `The Issuer "ca" is not ready.`, Remediation: "Fix the Issuer."},
+		{Severity: SeverityInfo, Reason: "RequestPending", Message: "Waiting for the issuer."},
+	}))
+}
+
+func TestHistoryFromRequests(t *testing.T) {
+	created := func(hour int) metav1.Time {
+		return metav1.NewTime(time.Date(2020, 9, 16, hour, 0, 0, 0, time.UTC))
+	}
+	notBefore := time.Date(2020, 9, 16, 10, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(90 * 24 * time.Hour)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(0x1234), NotBefore: notBefore, NotAfter: notAfter}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuedCrt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	failed := gen.CertificateRequest("test-crt-1",
+		gen.SetCertificateRequestRevision("1"),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: cmapi.CertificateRequestReasonFailed, Message: "Failed to wait for order resource to become ready"}))
+	failed.CreationTimestamp = created(9)
+
+	issued := gen.CertificateRequest("test-crt-2",
+		gen.SetCertificateRequestRevision("2"),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Reason: cmapi.CertificateRequestReasonIssued}),
+		gen.SetCertificateRequestCertificate(issuedCrt))
+	issued.CreationTimestamp = created(10)
+
+	denied := gen.CertificateRequest("test-crt-10",
+		gen.SetCertificateRequestRevision("10"),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Message: "manually denied"}))
+	denied.CreationTimestamp = created(11)
+
+	pending := gen.CertificateRequest("test-crt-x")
+	pending.CreationTimestamp = created(8)
+
+	tests := map[string]struct {
+		reqs      []*cmapi.CertificateRequest
+		err       error
+		expOutput string
+	}{
+		"Error when listing CertificateRequests is printed": {
+			err:       errors.New("error when listing CertificateRequest resources: forbidden"),
+			expOutput: "History: error when listing CertificateRequest resources: forbidden\n",
+		},
+		"No CertificateRequests": {
+			expOutput: "History: no CertificateRequests found for this Certificate\n",
+		},
+		"CertificateRequests are ordered by revision": {
+			reqs: []*cmapi.CertificateRequest{denied, issued, pending, failed},
+			expOutput: `History:
+  Revision <none>: test-crt-x, Created at: 2020-09-16T08:00:00Z, Approval: Pending, Outcome: Pending
+  Revision 1: test-crt-1, Created at: 2020-09-16T09:00:00Z, Approval: Approved, Outcome: Failed
+    Failure: Failed to wait for order resource to become ready
+  Revision 2: test-crt-2, Created at: 2020-09-16T10:00:00Z, Approval: Approved, Outcome: Issued
+    Serial Number: 1234, Not Before: 2020-09-16T10:00:00Z, Not After: 2020-12-15T10:00:00Z
+  Revision 10: test-crt-10, Created at: 2020-09-16T11:00:00Z, Approval: Denied, Outcome: Denied
+    Failure: manually denied
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expOutput, HistoryFromRequests(test.reqs, test.err).String())
+		})
+	}
+}
+
+func TestGraphFromResources(t *testing.T) {
+	data := &Data{
+		Certificate: gen.Certificate("test-crt",
+			gen.SetCertificateSecretName("test-tls"),
+			gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"}),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse}),
+			gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue})),
+		Issuer: gen.ClusterIssuer("letsencrypt",
+			gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue})),
+		SecretError: errors.New("not found"),
+		Req: gen.CertificateRequest("test-crt-1",
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: cmapi.CertificateRequestReasonPending})),
+		Order: gen.Order("test-crt-1-1", gen.SetOrderState(cmacme.Pending)),
+		Challenges: []*cmacme.Challenge{
+			gen.Challenge("test-crt-1-1-1", gen.SetChallengeState(cmacme.Valid)),
+			gen.Challenge("test-crt-1-1-2", gen.SetChallengeState(cmacme.Invalid)),
+		},
+	}
+
+	tests := map[string]struct {
+		format    string
+		expOutput string
+	}{
+		"DOT": {
+			format: "dot",
+			expOutput: `digraph certificate {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled", fontname="sans-serif"];
+  certificate [label="Certificate\ntest-crt\nPending", fillcolor="#f9e2af"];
+  issuer [label="ClusterIssuer\nletsencrypt\nReady", fillcolor="#a6e3a1"];
+  secret [label="Secret\ntest-tls\nFailed", fillcolor="#f38ba8"];
+  certificaterequest [label="CertificateRequest\ntest-crt-1\nPending", fillcolor="#f9e2af"];
+  order [label="Order\ntest-crt-1-1\nPending", fillcolor="#f9e2af"];
+  challenge0 [label="Challenge\ntest-crt-1-1-1\nReady", fillcolor="#a6e3a1"];
+  challenge1 [label="Challenge\ntest-crt-1-1-2\nFailed", fillcolor="#f38ba8"];
+  certificate -> issuer;
+  certificate -> secret;
+  certificate -> certificaterequest;
+  certificaterequest -> order;
+  order -> challenge0;
+  order -> challenge1;
+}
+`,
+		},
+		"Mermaid": {
+			format: "mermaid",
+			expOutput: `flowchart LR
+  certificate["Certificate<br/>test-crt<br/>Pending"]:::pending
+  issuer["ClusterIssuer<br/>letsencrypt<br/>Ready"]:::ready
+  secret["Secret<br/>test-tls<br/>Failed"]:::failed
+  certificaterequest["CertificateRequest<br/>test-crt-1<br/>Pending"]:::pending
+  order["Order<br/>test-crt-1-1<br/>Pending"]:::pending
+  challenge0["Challenge<br/>test-crt-1-1-1<br/>Ready"]:::ready
+  challenge1["Challenge<br/>test-crt-1-1-2<br/>Failed"]:::failed
+  certificate --> issuer
+  certificate --> secret
+  certificate --> certificaterequest
+  certificaterequest --> order
+  order --> challenge0
+  order --> challenge1
+  classDef ready fill:#a6e3a1
+  classDef pending fill:#f9e2af
+  classDef failed fill:#f38ba8
+  classDef unknown fill:#cdd6f4
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expOutput, graphFromResources(data).render(test.format))
+		})
+	}
+}
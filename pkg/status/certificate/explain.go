@@ -0,0 +1,384 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// Severity ranks how likely a Finding is to be the reason a Certificate is not
+// Ready.
+type Severity string
+
+const (
+	// SeverityError is used for problems that block issuance until they are
+	// fixed.
+	SeverityError Severity = "Error"
+	// SeverityWarning is used for problems that delay issuance, but may
+	// resolve on their own.
+	SeverityWarning Severity = "Warning"
+	// SeverityInfo is used for observations that explain the current state.
+	SeverityInfo Severity = "Info"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Finding is a known problem detected in a Certificate or one of its related
+// resources, together with a hint on how to fix it.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	// Reason is a CamelCase identifier of the detected problem
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	// Remediation is a hint on how to resolve the problem
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// The backoff cert-manager applies between failed issuances, unless
+// configured otherwise in the controller configuration.
+const (
+	defaultBackoffInitialDelay = time.Hour
+	defaultBackoffMaxDelay     = 32 * time.Hour
+)
+
+// diagnosisRule detects a known failure pattern in data. now is the time the
+// diagnosis is made at and buildName is the name of the command, used to
+// suggest commands in the remediation hints.
+type diagnosisRule func(data *Data, now time.Time, buildName string) []Finding
+
+// diagnosisRules are evaluated in order; findings of the same severity are
+// ranked in the order of the rules that produced them.
+var diagnosisRules = []diagnosisRule{
+	diagnoseIssuer,
+	diagnoseSecretOwnership,
+	diagnoseRequest,
+	diagnoseOrder,
+	diagnoseChallenges,
+	diagnoseBackoff,
+	diagnoseExpiry,
+}
+
+// Diagnose runs all diagnosis rules against data and returns the findings,
+// ranked by severity. The returned slice is empty, but not nil, if no known
+// problem was detected.
+func Diagnose(data *Data, now time.Time, buildName string) []Finding {
+	findings := []Finding{}
+	for _, rule := range diagnosisRules {
+		findings = append(findings, rule(data, now, buildName)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity.rank() < findings[j].Severity.rank()
+	})
+	return findings
+}
+
+func diagnoseIssuer(data *Data, _ time.Time, buildName string) []Finding {
+	crt := data.Certificate
+	kind := crt.Spec.IssuerRef.Kind
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+
+	if data.IssuerError != nil {
+		if !apierrors.IsNotFound(data.IssuerError) {
+			return nil
+		}
+		location := fmt.Sprintf("in namespace %q", crt.Namespace)
+		if kind == cmapi.ClusterIssuerKind {
+			location = "in the cluster"
+		}
+		return []Finding{{
+			Severity:    SeverityError,
+			Reason:      "IssuerNotFound",
+			Message:     fmt.Sprintf("The %s %q referenced by the Certificate does not exist %s.", kind, crt.Spec.IssuerRef.Name, location),
+			Remediation: fmt.Sprintf("Create the %s, or fix spec.issuerRef of the Certificate to reference an existing Issuer or ClusterIssuer.", kind),
+		}}
+	}
+
+	if data.Issuer == nil {
+		return nil
+	}
+
+	for _, con := range data.Issuer.GetStatus().Conditions {
+		if con.Type != cmapi.IssuerConditionReady {
+			continue
+		}
+		if con.Status == cmmeta.ConditionTrue {
+			return nil
+		}
+		return []Finding{{
+			Severity:    SeverityError,
+			Reason:      "IssuerNotReady",
+			Message:     fmt.Sprintf("The %s %q is not ready: %s (%s).", kind, data.Issuer.GetName(), con.Message, con.Reason),
+			Remediation: fmt.Sprintf("Fix the configuration of the %s; run '%s' for details.", kind, issuerStatusCommand(buildName, kind, data.Issuer.GetName(), crt.Namespace)),
+		}}
+	}
+
+	return []Finding{{
+		Severity:    SeverityError,
+		Reason:      "IssuerNotReady",
+		Message:     fmt.Sprintf("The %s %q has no Ready condition, it has not been processed by cert-manager yet.", kind, data.Issuer.GetName()),
+		Remediation: "Check that the cert-manager controller is running and that its logs do not report errors for the issuer.",
+	}}
+}
+
+func issuerStatusCommand(buildName, kind, name, namespace string) string {
+	if kind == cmapi.ClusterIssuerKind {
+		return fmt.Sprintf("%s status clusterissuer %s", buildName, name)
+	}
+	return fmt.Sprintf("%s status issuer %s -n %s", buildName, name, namespace)
+}
+
+func diagnoseSecretOwnership(data *Data, _ time.Time, _ string) []Finding {
+	crt, secret := data.Certificate, data.Secret
+	if secret == nil {
+		return nil
+	}
+
+	owner := secret.Annotations[cmapi.CertificateNameKey]
+	if owner == "" || owner == crt.Name {
+		for _, ref := range secret.OwnerReferences {
+			if ref.Kind == cmapi.CertificateKind && ref.Name != crt.Name {
+				owner = ref.Name
+				break
+			}
+		}
+	}
+	if owner == "" || owner == crt.Name {
+		return nil
+	}
+
+	return []Finding{{
+		Severity:    SeverityError,
+		Reason:      "SecretOwnedByOtherCertificate",
+		Message:     fmt.Sprintf("The Secret %q is also used by the Certificate %q, both Certificates keep overwriting each other's certificate.", secret.Name, owner),
+		Remediation: "Give each Certificate its own Secret by changing spec.secretName of one of the Certificates.",
+	}}
+}
+
+func diagnoseRequest(data *Data, _ time.Time, buildName string) []Finding {
+	req := data.Req
+	if req == nil {
+		return nil
+	}
+
+	if con := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied); con != nil && con.Status == cmmeta.ConditionTrue {
+		return []Finding{{
+			Severity:    SeverityError,
+			Reason:      "RequestDenied",
+			Message:     fmt.Sprintf("The CertificateRequest %q was denied: %s (%s).", req.Name, con.Message, con.Reason),
+			Remediation: fmt.Sprintf("Check the policy of the approver that denied the request, then run '%s renew %s -n %s' to request a new certificate.", buildName, data.Certificate.Name, data.Certificate.Namespace),
+		}}
+	}
+
+	if con := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest); con != nil && con.Status == cmmeta.ConditionTrue {
+		return []Finding{{
+			Severity:    SeverityError,
+			Reason:      "RequestInvalid",
+			Message:     fmt.Sprintf("The CertificateRequest %q is invalid: %s (%s).", req.Name, con.Message, con.Reason),
+			Remediation: "Fix the spec of the Certificate so that the issuer accepts the request.",
+		}}
+	}
+
+	ready := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	if ready != nil && ready.Reason == cmapi.CertificateRequestReasonFailed {
+		return []Finding{{
+			Severity:    SeverityError,
+			Reason:      "RequestFailed",
+			Message:     fmt.Sprintf("The CertificateRequest %q failed: %s", req.Name, ready.Message),
+			Remediation: "Fix the cause reported by the issuer; cert-manager creates a new CertificateRequest once the backoff period has passed.",
+		}}
+	}
+
+	if !apiutil.CertificateRequestIsApproved(req) {
+		return []Finding{{
+			Severity:    SeverityWarning,
+			Reason:      "RequestNotApproved",
+			Message:     fmt.Sprintf("The CertificateRequest %q has been neither approved nor denied, the issuer does not sign it until it is approved.", req.Name),
+			Remediation: fmt.Sprintf("Check that an approver (e.g. the cert-manager internal approver or approver-policy) is running and handles requests for this issuer, or approve it manually with '%s approve %s -n %s'.", buildName, req.Name, req.Namespace),
+		}}
+	}
+
+	if ready != nil && ready.Status != cmmeta.ConditionTrue && ready.Reason == cmapi.CertificateRequestReasonPending {
+		return []Finding{{
+			Severity: SeverityInfo,
+			Reason:   "RequestPending",
+			Message:  fmt.Sprintf("The CertificateRequest %q is waiting for the issuer: %s", req.Name, ready.Message),
+		}}
+	}
+
+	return nil
+}
+
+func diagnoseOrder(data *Data, _ time.Time, _ string) []Finding {
+	order := data.Order
+	if order == nil {
+		return nil
+	}
+
+	switch order.Status.State {
+	case cmacme.Errored, cmacme.Invalid, cmacme.Expired:
+		return []Finding{{
+			Severity:    SeverityError,
+			Reason:      "OrderFailed",
+			Message:     fmt.Sprintf("The ACME Order %q is in state %q: %s", order.Name, order.Status.State, order.Status.Reason),
+			Remediation: "Check the failed Challenges of the Order; the ACME server does not reuse a failed Order, cert-manager creates a new one once the backoff period has passed.",
+		}}
+	}
+
+	return nil
+}
+
+func diagnoseChallenges(data *Data, _ time.Time, _ string) []Finding {
+	var findings []Finding
+	for _, ch := range data.Challenges {
+		switch {
+		case ch.Status.State == cmacme.Invalid || ch.Status.State == cmacme.Errored:
+			findings = append(findings, Finding{
+				Severity:    SeverityError,
+				Reason:      "ChallengeFailed",
+				Message:     fmt.Sprintf("The %s Challenge %q for %q is in state %q: %s", ch.Spec.Type, ch.Name, ch.Spec.DNSName, ch.Status.State, ch.Status.Reason),
+				Remediation: "Fix the cause reported by the ACME server; a new Order with new Challenges is created once the backoff period has passed.",
+			})
+
+		case ch.Status.Presented && strings.Contains(ch.Status.Reason, "challenge propagation"):
+			if ch.Spec.Type == cmacme.ACMEChallengeTypeDNS01 {
+				findings = append(findings, Finding{
+					Severity:    SeverityWarning,
+					Reason:      "DNS01PropagationCheckFailing",
+					Message:     fmt.Sprintf("The self check of the DNS01 Challenge %q for %q is failing: %s", ch.Name, ch.Spec.DNSName, ch.Status.Reason),
+					Remediation: fmt.Sprintf("Check that the TXT record '_acme-challenge.%s' was created in the expected DNS zone and resolves from the nameservers cert-manager queries; these can be changed with the --dns01-recursive-nameservers and --dns01-recursive-nameservers-only controller flags.", strings.TrimPrefix(ch.Spec.DNSName, "*.")),
+				})
+			} else {
+				findings = append(findings, Finding{
+					Severity:    SeverityWarning,
+					Reason:      "HTTP01PropagationCheckFailing",
+					Message:     fmt.Sprintf("The self check of the HTTP01 Challenge %q for %q is failing: %s", ch.Name, ch.Spec.DNSName, ch.Status.Reason),
+					Remediation: fmt.Sprintf("Check that 'http://%s/.well-known/acme-challenge/%s' is reachable from inside the cluster and routed to the solver by the ingress controller or gateway.", ch.Spec.DNSName, ch.Spec.Token),
+				})
+			}
+
+		case !ch.Status.Presented && ch.Status.Reason != "":
+			findings = append(findings, Finding{
+				Severity:    SeverityError,
+				Reason:      "ChallengeNotPresented",
+				Message:     fmt.Sprintf("The %s Challenge %q for %q could not be presented: %s", ch.Spec.Type, ch.Name, ch.Spec.DNSName, ch.Status.Reason),
+				Remediation: "Check the solver configuration of the issuer, e.g. the credentials of the DNS provider or the ingress class.",
+			})
+		}
+	}
+	return findings
+}
+
+func diagnoseBackoff(data *Data, now time.Time, buildName string) []Finding {
+	crt := data.Certificate
+	if crt.Status.LastFailureTime == nil {
+		return nil
+	}
+	if issuing := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); issuing != nil && issuing.Status == cmmeta.ConditionTrue {
+		return nil
+	}
+
+	attempts := 1
+	if crt.Status.FailedIssuanceAttempts != nil && *crt.Status.FailedIssuanceAttempts > 0 {
+		attempts = *crt.Status.FailedIssuanceAttempts
+	}
+	nextRetry := crt.Status.LastFailureTime.Add(issuanceBackoff(attempts))
+
+	message := fmt.Sprintf("Issuance failed %d time(s), most recently at %s", attempts, util.FormatTimeString(crt.Status.LastFailureTime))
+	if issuing := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); issuing != nil && issuing.Message != "" {
+		message += fmt.Sprintf(" (%s)", issuing.Message)
+	}
+	if nextRetry.After(now) {
+		message += fmt.Sprintf(". cert-manager backs off and retries at %s, in %s.",
+			util.FormatTimeString(&metav1.Time{Time: nextRetry}), duration.HumanDuration(nextRetry.Sub(now)))
+	} else {
+		message += fmt.Sprintf(". The backoff period ended at %s, a retry is due.",
+			util.FormatTimeString(&metav1.Time{Time: nextRetry}))
+	}
+
+	return []Finding{{
+		Severity:    SeverityWarning,
+		Reason:      "IssuanceBackoff",
+		Message:     message,
+		Remediation: fmt.Sprintf("Fix the cause of the failure, then run '%s renew %s -n %s' to retry immediately. Changing the spec of the Certificate also triggers a new issuance.", buildName, crt.Name, crt.Namespace),
+	}}
+}
+
+// issuanceBackoff returns the delay cert-manager waits after the given number
+// of failed issuance attempts, assuming the default backoff configuration.
+func issuanceBackoff(attempts int) time.Duration {
+	delay := defaultBackoffInitialDelay * time.Duration(math.Pow(2, float64(attempts-1)))
+	if delay < defaultBackoffInitialDelay || delay > defaultBackoffMaxDelay {
+		return defaultBackoffMaxDelay
+	}
+	return delay
+}
+
+func diagnoseExpiry(data *Data, now time.Time, buildName string) []Finding {
+	crt := data.Certificate
+	if crt.Status.NotAfter == nil || crt.Status.NotAfter.After(now) {
+		return nil
+	}
+
+	return []Finding{{
+		Severity:    SeverityError,
+		Reason:      "CertificateExpired",
+		Message:     fmt.Sprintf("The certificate in the Secret %q expired at %s.", crt.Spec.SecretName, util.FormatTimeString(crt.Status.NotAfter)),
+		Remediation: fmt.Sprintf("Run '%s renew %s -n %s' to request a new certificate, after resolving the other findings if there are any.", buildName, crt.Name, crt.Namespace),
+	}}
+}
+
+// FindingsToString returns the findings as a numbered list, in the order of
+// their rank.
+func FindingsToString(findings []Finding) string {
+	if len(findings) == 0 {
+		return "Diagnosis: no known problems found\n"
+	}
+
+	output := "Diagnosis:\n"
+	for i, f := range findings {
+		output += fmt.Sprintf("  %d. [%s] %s: %s\n", i+1, f.Severity, f.Reason, f.Message)
+		if f.Remediation != "" {
+			output += fmt.Sprintf("     Hint: %s\n", f.Remediation)
+		}
+	}
+	return output
+}
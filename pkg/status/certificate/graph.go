@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+	"strings"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Formats supported by the --graph flag.
+const (
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+)
+
+var graphFormats = []string{graphFormatDOT, graphFormatMermaid}
+
+// readiness is the state a node of the graph is coloured by.
+type readiness string
+
+const (
+	readinessReady   readiness = "Ready"
+	readinessPending readiness = "Pending"
+	readinessFailed  readiness = "Failed"
+	readinessUnknown readiness = "Unknown"
+)
+
+// Fill colours of the nodes, by readiness.
+var readinessColors = map[readiness]string{
+	readinessReady:   "#a6e3a1",
+	readinessPending: "#f9e2af",
+	readinessFailed:  "#f38ba8",
+	readinessUnknown: "#cdd6f4",
+}
+
+type graphNode struct {
+	id    string
+	kind  string
+	name  string
+	state readiness
+}
+
+type graphEdge struct {
+	from, to string
+}
+
+type graph struct {
+	nodes []graphNode
+	edges []graphEdge
+}
+
+// graphFromResources returns the graph of the Certificate in data and its
+// related resources. Resources that are referenced by name but could not be
+// found are added as failed nodes, other resources only if they were found.
+func graphFromResources(data *Data) *graph {
+	crt := data.Certificate
+	g := &graph{}
+
+	g.nodes = append(g.nodes, graphNode{id: "certificate", kind: cmapi.CertificateKind, name: crt.Name, state: certificateReadiness(crt)})
+
+	issuerState := readinessFailed
+	if data.Issuer != nil {
+		issuerState = issuerReadiness(data.Issuer)
+	}
+	g.nodes = append(g.nodes, graphNode{id: "issuer", kind: apiutil.IssuerKind(crt.Spec.IssuerRef), name: crt.Spec.IssuerRef.Name, state: issuerState})
+	g.edges = append(g.edges, graphEdge{from: "certificate", to: "issuer"})
+
+	g.nodes = append(g.nodes, graphNode{id: "secret", kind: "Secret", name: crt.Spec.SecretName, state: secretReadiness(data.Secret)})
+	g.edges = append(g.edges, graphEdge{from: "certificate", to: "secret"})
+
+	if data.Req == nil {
+		return g
+	}
+	g.nodes = append(g.nodes, graphNode{id: "certificaterequest", kind: cmapi.CertificateRequestKind, name: data.Req.Name, state: requestReadiness(data.Req)})
+	g.edges = append(g.edges, graphEdge{from: "certificate", to: "certificaterequest"})
+
+	if data.Order == nil {
+		return g
+	}
+	g.nodes = append(g.nodes, graphNode{id: "order", kind: cmacme.OrderKind, name: data.Order.Name, state: acmeStateReadiness(data.Order.Status.State)})
+	g.edges = append(g.edges, graphEdge{from: "certificaterequest", to: "order"})
+
+	for i, ch := range data.Challenges {
+		id := fmt.Sprintf("challenge%d", i)
+		g.nodes = append(g.nodes, graphNode{id: id, kind: cmacme.ChallengeKind, name: ch.Name, state: acmeStateReadiness(ch.Status.State)})
+		g.edges = append(g.edges, graphEdge{from: "order", to: id})
+	}
+
+	return g
+}
+
+func certificateReadiness(crt *cmapi.Certificate) readiness {
+	if issuing := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); issuing != nil && issuing.Status == cmmeta.ConditionTrue {
+		return readinessPending
+	}
+	ready := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady)
+	switch {
+	case ready == nil:
+		return readinessUnknown
+	case ready.Status == cmmeta.ConditionTrue:
+		return readinessReady
+	default:
+		return readinessFailed
+	}
+}
+
+func issuerReadiness(issuer cmapi.GenericIssuer) readiness {
+	for _, con := range issuer.GetStatus().Conditions {
+		if con.Type != cmapi.IssuerConditionReady {
+			continue
+		}
+		if con.Status == cmmeta.ConditionTrue {
+			return readinessReady
+		}
+		return readinessFailed
+	}
+	return readinessUnknown
+}
+
+func secretReadiness(secret *corev1.Secret) readiness {
+	if secret == nil || len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return readinessFailed
+	}
+	return readinessReady
+}
+
+func requestReadiness(req *cmapi.CertificateRequest) readiness {
+	if apiutil.CertificateRequestIsDenied(req) {
+		return readinessFailed
+	}
+	if invalid := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest); invalid != nil && invalid.Status == cmmeta.ConditionTrue {
+		return readinessFailed
+	}
+	ready := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	switch {
+	case ready == nil:
+		return readinessPending
+	case ready.Status == cmmeta.ConditionTrue:
+		return readinessReady
+	case ready.Reason == cmapi.CertificateRequestReasonFailed:
+		return readinessFailed
+	default:
+		return readinessPending
+	}
+}
+
+func acmeStateReadiness(state cmacme.State) readiness {
+	switch state {
+	case cmacme.Valid:
+		return readinessReady
+	case cmacme.Invalid, cmacme.Errored, cmacme.Expired:
+		return readinessFailed
+	case "":
+		return readinessUnknown
+	default:
+		return readinessPending
+	}
+}
+
+// render returns the graph in the given format, which must be one of
+// graphFormats.
+func (g *graph) render(format string) string {
+	if format == graphFormatMermaid {
+		return g.mermaid()
+	}
+	return g.dot()
+}
+
+// dot returns the graph in the Graphviz DOT language.
+func (g *graph) dot() string {
+	var b strings.Builder
+	b.WriteString("digraph certificate {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"sans-serif\"];\n")
+	for _, n := range g.nodes {
+		fmt.Fprintf(&b, "  %s [label=\"%s\\n%s\\n%s\", fillcolor=\"%s\"];\n", n.id, n.kind, n.name, n.state, readinessColors[n.state])
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", e.from, e.to)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// mermaid returns the graph as a Mermaid flowchart.
+func (g *graph) mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.nodes {
+		fmt.Fprintf(&b, "  %s[\"%s<br/>%s<br/>%s\"]:::%s\n", n.id, n.kind, n.name, n.state, strings.ToLower(string(n.state)))
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", e.from, e.to)
+	}
+	for _, state := range []readiness{readinessReady, readinessPending, readinessFailed, readinessUnknown} {
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", strings.ToLower(string(state)), readinessColors[state])
+	}
+	return b.String()
+}
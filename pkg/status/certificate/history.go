@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// Outcomes of a CertificateRequest listed in the issuance history.
+const (
+	outcomeIssued  = "Issued"
+	outcomeFailed  = "Failed"
+	outcomeDenied  = "Denied"
+	outcomeInvalid = "Invalid"
+	outcomePending = "Pending"
+)
+
+// HistoryEntry is a CertificateRequest of a Certificate, listed in its
+// issuance history.
+type HistoryEntry struct {
+	// Name of the CertificateRequest resource
+	Name string `json:"name"`
+	// Revision of the Certificate the CertificateRequest was created for,
+	// empty if the revision annotation is missing
+	Revision     string      `json:"revision,omitempty"`
+	CreationTime metav1.Time `json:"creationTime"`
+	// Approval is Approved, Denied or Pending
+	Approval string `json:"approval"`
+	// Outcome is Issued, Failed, Denied, Invalid or Pending
+	Outcome string `json:"outcome"`
+	// FailureMessage is the message of the condition the request failed with
+	FailureMessage string `json:"failureMessage,omitempty"`
+	// Issued certificate, only set if the certificate could be decoded
+	SerialNumber string       `json:"serialNumber,omitempty"`
+	NotBefore    *metav1.Time `json:"notBefore,omitempty"`
+	NotAfter     *metav1.Time `json:"notAfter,omitempty"`
+	// CertificateError is set if the issued certificate could not be decoded
+	CertificateError string `json:"certificateError,omitempty"`
+}
+
+// History is the issuance history of a Certificate, with one entry per
+// retained CertificateRequest ordered by revision.
+type History struct {
+	// If Error is not nil, the CertificateRequests could not be listed and
+	// Entries is empty
+	Error   string          `json:"error,omitempty"`
+	Entries []*HistoryEntry `json:"entries,omitempty"`
+}
+
+// HistoryFromRequests returns the issuance history built from reqs, the
+// CertificateRequests owned by a Certificate, and err, the error that occurred
+// when listing them.
+func HistoryFromRequests(reqs []*cmapi.CertificateRequest, err error) *History {
+	if err != nil {
+		return &History{Error: err.Error()}
+	}
+
+	history := &History{}
+	for _, req := range reqs {
+		history.Entries = append(history.Entries, historyEntryFromRequest(req))
+	}
+
+	// Requests without a valid revision are listed first, ordered by creation
+	// time like requests with the same revision.
+	revision := func(e *HistoryEntry) int {
+		r, err := strconv.Atoi(e.Revision)
+		if err != nil {
+			return 0
+		}
+		return r
+	}
+	sort.SliceStable(history.Entries, func(i, j int) bool {
+		ri, rj := revision(history.Entries[i]), revision(history.Entries[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return history.Entries[i].CreationTime.Before(&history.Entries[j].CreationTime)
+	})
+
+	return history
+}
+
+func historyEntryFromRequest(req *cmapi.CertificateRequest) *HistoryEntry {
+	entry := &HistoryEntry{
+		Name:         req.Name,
+		Revision:     req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey],
+		CreationTime: req.CreationTimestamp,
+		Approval:     outcomePending,
+		Outcome:      outcomePending,
+	}
+
+	switch {
+	case apiutil.CertificateRequestIsApproved(req):
+		entry.Approval = "Approved"
+	case apiutil.CertificateRequestIsDenied(req):
+		entry.Approval = outcomeDenied
+	}
+
+	denied := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied)
+	invalid := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest)
+	ready := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	switch {
+	case denied != nil && denied.Status == cmmeta.ConditionTrue:
+		entry.Outcome, entry.FailureMessage = outcomeDenied, denied.Message
+	case invalid != nil && invalid.Status == cmmeta.ConditionTrue:
+		entry.Outcome, entry.FailureMessage = outcomeInvalid, invalid.Message
+	case ready != nil && ready.Status == cmmeta.ConditionTrue:
+		entry.Outcome = outcomeIssued
+	case ready != nil && ready.Reason == cmapi.CertificateRequestReasonFailed:
+		entry.Outcome, entry.FailureMessage = outcomeFailed, ready.Message
+	}
+
+	if len(req.Status.Certificate) > 0 {
+		cert, err := pki.DecodeX509CertificateBytes(req.Status.Certificate)
+		if err != nil {
+			entry.CertificateError = fmt.Sprintf("error when decoding the issued certificate: %s", err)
+		} else {
+			entry.SerialNumber = hex.EncodeToString(cert.SerialNumber.Bytes())
+			entry.NotBefore = &metav1.Time{Time: cert.NotBefore}
+			entry.NotAfter = &metav1.Time{Time: cert.NotAfter}
+		}
+	}
+
+	return entry
+}
+
+// String returns the issuance history as a string to be printed as output
+func (history *History) String() string {
+	if history.Error != "" {
+		return fmt.Sprintf("History: %s\n", history.Error)
+	}
+	if len(history.Entries) == 0 {
+		return "History: no CertificateRequests found for this Certificate\n"
+	}
+
+	output := "History:\n"
+	for _, e := range history.Entries {
+		output += fmt.Sprintf("  Revision %s: %s, Created at: %s, Approval: %s, Outcome: %s\n",
+			util.FormatOptionalString(e.Revision), e.Name, util.FormatTimeString(&e.CreationTime), e.Approval, e.Outcome)
+		if e.FailureMessage != "" {
+			output += fmt.Sprintf("    Failure: %s\n", e.FailureMessage)
+		}
+		switch {
+		case e.CertificateError != "":
+			output += fmt.Sprintf("    Certificate: %s\n", e.CertificateError)
+		case e.SerialNumber != "":
+			output += fmt.Sprintf("    Serial Number: %s, Not Before: %s, Not After: %s\n",
+				e.SerialNumber, util.FormatTimeString(e.NotBefore), util.FormatTimeString(e.NotAfter))
+		}
+	}
+	return output
+}
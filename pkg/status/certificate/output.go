@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"encoding/hex"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+	verifycertificate "github.com/cert-manager/cmctl/v2/pkg/verify/certificate"
+)
+
+// CertificateStatusKind is the kind of the structured output of the status certificate command.
+const CertificateStatusKind = "CertificateStatus"
+
+// CertificateStatusOutput is the structured representation of a CertificateStatus,
+// printed by the status certificate command when --output is used.
+// Each nested status carries an Error field instead of its content if the
+// related resource could not be found.
+type CertificateStatusOutput struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Error is set in a CertificateStatusList if the related resources of the
+	// Certificate could not be fetched
+	Error              string                       `json:"error,omitempty"`
+	Name               string                       `json:"name"`
+	Namespace          string                       `json:"namespace"`
+	CreationTime       metav1.Time                  `json:"creationTime"`
+	Conditions         []cmapi.CertificateCondition `json:"conditions,omitempty"`
+	DNSNames           []string                     `json:"dnsNames,omitempty"`
+	NotBefore          *metav1.Time                 `json:"notBefore,omitempty"`
+	NotAfter           *metav1.Time                 `json:"notAfter,omitempty"`
+	RenewalTime        *metav1.Time                 `json:"renewalTime,omitempty"`
+	Events             []util.Event                 `json:"events,omitempty"`
+	Issuer             *IssuerStatusOutput          `json:"issuer,omitempty"`
+	Secret             *SecretStatusOutput          `json:"secret,omitempty"`
+	CertificateRequest *CRStatusOutput              `json:"certificateRequest,omitempty"`
+	Order              *OrderStatusOutput           `json:"order,omitempty"`
+	Challenges         *ChallengeStatusListOutput   `json:"challenges,omitempty"`
+	History            *History                     `json:"history,omitempty"`
+	Findings           []Finding                    `json:"findings,omitempty"`
+}
+
+// IssuerStatusOutput is the structured representation of an IssuerStatus.
+type IssuerStatusOutput struct {
+	Error      string                  `json:"error,omitempty"`
+	Name       string                  `json:"name,omitempty"`
+	Kind       string                  `json:"kind,omitempty"`
+	Conditions []cmapi.IssuerCondition `json:"conditions,omitempty"`
+	Events     []util.Event            `json:"events,omitempty"`
+}
+
+// SecretStatusOutput is the structured representation of a SecretStatus.
+type SecretStatusOutput struct {
+	Error              string                       `json:"error,omitempty"`
+	Name               string                       `json:"name,omitempty"`
+	IssuerCountry      []string                     `json:"issuerCountry,omitempty"`
+	IssuerOrganisation []string                     `json:"issuerOrganisation,omitempty"`
+	IssuerCommonName   string                       `json:"issuerCommonName,omitempty"`
+	KeyUsages          []cmapi.KeyUsage             `json:"keyUsages,omitempty"`
+	PublicKeyAlgorithm string                       `json:"publicKeyAlgorithm,omitempty"`
+	SignatureAlgorithm string                       `json:"signatureAlgorithm,omitempty"`
+	SubjectKeyID       string                       `json:"subjectKeyId,omitempty"`
+	AuthorityKeyID     string                       `json:"authorityKeyId,omitempty"`
+	SerialNumber       string                       `json:"serialNumber,omitempty"`
+	Drift              []verifycertificate.Mismatch `json:"drift,omitempty"`
+	DriftError         string                       `json:"driftError,omitempty"`
+	Events             []util.Event                 `json:"events,omitempty"`
+}
+
+// CRStatusOutput is the structured representation of a CRStatus.
+type CRStatusOutput struct {
+	Error      string                              `json:"error,omitempty"`
+	Name       string                              `json:"name,omitempty"`
+	Namespace  string                              `json:"namespace,omitempty"`
+	Conditions []cmapi.CertificateRequestCondition `json:"conditions,omitempty"`
+	Events     []util.Event                        `json:"events,omitempty"`
+}
+
+// OrderStatusOutput is the structured representation of an OrderStatus.
+type OrderStatusOutput struct {
+	Error          string                     `json:"error,omitempty"`
+	Name           string                     `json:"name,omitempty"`
+	State          cmacme.State               `json:"state,omitempty"`
+	Reason         string                     `json:"reason,omitempty"`
+	Authorizations []cmacme.ACMEAuthorization `json:"authorizations,omitempty"`
+	FailureTime    *metav1.Time               `json:"failureTime,omitempty"`
+}
+
+// ChallengeStatusListOutput is the structured representation of a ChallengeStatusList.
+type ChallengeStatusListOutput struct {
+	Error             string                  `json:"error,omitempty"`
+	ChallengeStatuses []ChallengeStatusOutput `json:"items,omitempty"`
+}
+
+// ChallengeStatusOutput is the structured representation of a ChallengeStatus.
+type ChallengeStatusOutput struct {
+	Name       string                   `json:"name"`
+	Type       cmacme.ACMEChallengeType `json:"type"`
+	Token      string                   `json:"token"`
+	Key        string                   `json:"key"`
+	State      cmacme.State             `json:"state,omitempty"`
+	Reason     string                   `json:"reason,omitempty"`
+	Processing bool                     `json:"processing"`
+	Presented  bool                     `json:"presented"`
+}
+
+// Output returns the structured representation of status.
+func (status *CertificateStatus) Output() *CertificateStatusOutput {
+	out := &CertificateStatusOutput{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       CertificateStatusKind,
+		},
+		Name:         status.Name,
+		Namespace:    status.Namespace,
+		CreationTime: status.CreationTime,
+		Conditions:   status.Conditions,
+		DNSNames:     status.DNSNames,
+		NotBefore:    status.NotBefore,
+		NotAfter:     status.NotAfter,
+		RenewalTime:  status.RenewalTime,
+		Events:       util.EventsFromList(status.Events),
+		History:      status.History,
+		Findings:     status.Findings,
+	}
+
+	if s := status.IssuerStatus; s != nil {
+		out.Issuer = &IssuerStatusOutput{
+			Error:      util.ErrorString(s.Error),
+			Name:       s.Name,
+			Kind:       s.Kind,
+			Conditions: s.Conditions,
+			Events:     util.EventsFromList(s.Events),
+		}
+	}
+
+	if s := status.SecretStatus; s != nil {
+		out.Secret = &SecretStatusOutput{Error: util.ErrorString(s.Error)}
+		if s.Error == nil {
+			out.Secret.Name = s.Name
+			out.Secret.IssuerCountry = s.IssuerCountry
+			out.Secret.IssuerOrganisation = s.IssuerOrganisation
+			out.Secret.IssuerCommonName = s.IssuerCommonName
+			out.Secret.KeyUsages = append(apiutil.KeyUsageStrings(s.KeyUsage), apiutil.ExtKeyUsageStrings(s.ExtKeyUsage)...)
+			out.Secret.PublicKeyAlgorithm = s.PublicKeyAlgorithm.String()
+			out.Secret.SignatureAlgorithm = s.SignatureAlgorithm.String()
+			out.Secret.SubjectKeyID = hex.EncodeToString(s.SubjectKeyId)
+			out.Secret.AuthorityKeyID = hex.EncodeToString(s.AuthorityKeyId)
+			if s.SerialNumber != nil {
+				out.Secret.SerialNumber = hex.EncodeToString(s.SerialNumber.Bytes())
+			}
+			out.Secret.Drift = s.Drift
+			out.Secret.DriftError = util.ErrorString(s.DriftError)
+			out.Secret.Events = util.EventsFromList(s.Events)
+		}
+	}
+
+	if s := status.CRStatus; s != nil {
+		out.CertificateRequest = &CRStatusOutput{
+			Error:      util.ErrorString(s.Error),
+			Name:       s.Name,
+			Namespace:  s.Namespace,
+			Conditions: s.Conditions,
+			Events:     util.EventsFromList(s.Events),
+		}
+	}
+
+	if s := status.OrderStatus; s != nil {
+		out.Order = &OrderStatusOutput{
+			Error:          util.ErrorString(s.Error),
+			Name:           s.Name,
+			State:          s.State,
+			Reason:         s.Reason,
+			Authorizations: s.Authorizations,
+			FailureTime:    s.FailureTime,
+		}
+	}
+
+	if s := status.ChallengeStatusList; s != nil {
+		out.Challenges = &ChallengeStatusListOutput{Error: util.ErrorString(s.Error)}
+		for _, c := range s.ChallengeStatuses {
+			out.Challenges.ChallengeStatuses = append(out.Challenges.ChallengeStatuses, ChallengeStatusOutput{
+				Name:       c.Name,
+				Type:       c.Type,
+				Token:      c.Token,
+				Key:        c.Key,
+				State:      c.State,
+				Reason:     c.Reason,
+				Processing: c.Processing,
+				Presented:  c.Presented,
+			})
+		}
+	}
+
+	return out
+}
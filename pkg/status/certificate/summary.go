@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// CertificateStatusListKind is the kind of the structured output of the status
+// certificate command when multiple Certificates are selected.
+const CertificateStatusListKind = "CertificateStatusList"
+
+// CertificateStatusListOutput is the structured representation of the status
+// of multiple Certificates.
+type CertificateStatusListOutput struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Items []*CertificateStatusOutput `json:"items"`
+}
+
+// CertificateSummary is a one line summary of the status of a Certificate and
+// its related resources, printed when multiple Certificates are selected.
+type CertificateSummary struct {
+	// Namespace of the Certificate resource
+	Namespace string
+	// Name of the Certificate resource
+	Name string
+	// Status of the Ready condition of the Certificate resource
+	Ready cmmeta.ConditionStatus
+	// Kind and name of the Issuer/ClusterIssuer referenced by the Certificate
+	Issuer string
+	// Not After of Certificate resource
+	NotAfter *metav1.Time
+	// Renewal Time of Certificate resource
+	RenewalTime *metav1.Time
+	// State of the CertificateRequest for the current revision of the Certificate
+	Request string
+	// Number of failing ACME Challenges of the current CertificateRequest
+	FailingChallenges int
+	// Error returned when fetching the related resources of the Certificate,
+	// empty if they were fetched
+	Error string
+}
+
+// SummaryFromResources takes in a Data struct and returns a CertificateSummary
+// built using the information in data.
+func SummaryFromResources(data *Data) *CertificateSummary {
+	crt := data.Certificate
+
+	summary := &CertificateSummary{
+		Namespace:   crt.Namespace,
+		Name:        crt.Name,
+		Ready:       cmmeta.ConditionUnknown,
+		NotAfter:    crt.Status.NotAfter,
+		RenewalTime: crt.Status.RenewalTime,
+		Request:     requestState(data.Req),
+	}
+
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
+		summary.Ready = cond.Status
+	}
+
+	issuerKind := crt.Spec.IssuerRef.Kind
+	if issuerKind == "" {
+		issuerKind = "Issuer"
+	}
+	summary.Issuer = fmt.Sprintf("%s/%s", issuerKind, crt.Spec.IssuerRef.Name)
+
+	if data.Error != nil {
+		summary.Request = "<unknown>"
+		summary.Error = util.ErrorString(data.Error)
+	}
+
+	for _, challenge := range data.Challenges {
+		if challengeIsFailing(challenge) {
+			summary.FailingChallenges++
+		}
+	}
+
+	return summary
+}
+
+// requestState returns a short description of the state of req.
+func requestState(req *cmapi.CertificateRequest) string {
+	if req == nil {
+		return "<none>"
+	}
+
+	if apiutil.CertificateRequestIsDenied(req) {
+		return "Denied"
+	}
+	if apiutil.CertificateRequestHasInvalidRequest(req) {
+		return "InvalidRequest"
+	}
+
+	ready := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+	switch {
+	case ready == nil && !apiutil.CertificateRequestIsApproved(req):
+		return "WaitingForApproval"
+	case ready == nil:
+		return "Pending"
+	case ready.Status == cmmeta.ConditionTrue:
+		return "Issued"
+	case ready.Reason != "":
+		return ready.Reason
+	default:
+		return "Pending"
+	}
+}
+
+// challengeIsFailing returns true if challenge is in a failed state, or if it
+// is still being processed but reports a reason, e.g. a failing self check.
+func challengeIsFailing(challenge *cmacme.Challenge) bool {
+	if acme.IsFailureState(challenge.Status.State) {
+		return true
+	}
+	return challenge.Status.Processing && challenge.Status.Reason != ""
+}
+
+// runSummary prints the status of all Certificates selected with --all or a
+// label selector.
+func (o *Options) runSummary(ctx context.Context) error {
+	clientSet, err := kubernetes.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return err
+	}
+
+	allData, exitCode, err := o.getSummaryData(ctx, clientSet)
+	if err != nil {
+		return cmcmdutil.SetFetchErrorExitCode(err)
+	}
+
+	if err := o.printSummary(allData); err != nil {
+		return err
+	}
+
+	cmcmdutil.SetExitCodeValue(exitCode)
+	return nil
+}
+
+// getSummaryData collects the related resources of all selected Certificates,
+// and returns them with the exit code reporting their health. The related
+// resources are listed once per namespace instead of once per Certificate. If
+// the resources of a Certificate cannot be fetched, the error is set in its
+// Data instead of failing the whole summary.
+func (o *Options) getSummaryData(ctx context.Context, clientSet kubernetes.Interface) ([]*Data, int, error) {
+	ns := o.Namespace
+	if o.AllNamespaces {
+		ns = metav1.NamespaceAll
+	}
+
+	crts, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: o.LabelSelector,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+
+	owned := util.NewOwnedResources(o.CMClient)
+	var allData []*Data
+	exitCode := cmcmdutil.ExitCodeReady
+	for i := range crts.Items {
+		data, err := o.getResourcesForCertificate(ctx, clientSet, owned, &crts.Items[i])
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, 0, err
+			}
+			data = &Data{Certificate: &crts.Items[i], Error: err}
+			exitCode = max(exitCode, cmcmdutil.ExitCodeFetchError)
+		}
+		allData = append(allData, data)
+		exitCode = max(exitCode, exitCodeFromResources(data, clock.Now(), o.ExpiringSoonThreshold))
+	}
+
+	return allData, exitCode, nil
+}
+
+// printSummary prints the status of the Certificates in allData, as a list in
+// the requested output format or as a summary table.
+func (o *Options) printSummary(allData []*Data) error {
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+
+		list := &CertificateStatusListOutput{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: util.OutputGroupVersion.String(),
+				Kind:       CertificateStatusListKind,
+			},
+			Items: []*CertificateStatusOutput{},
+		}
+		for _, data := range allData {
+			out := StatusFromResources(data).Output()
+			out.Error = util.ErrorString(data.Error)
+			list.Items = append(list.Items, out)
+		}
+		return util.PrintObject(printer, list, o.Out)
+	}
+
+	if len(allData) == 0 {
+		if o.AllNamespaces {
+			fmt.Fprintln(o.ErrOut, "No Certificates found")
+		} else {
+			fmt.Fprintf(o.ErrOut, "No Certificates found in %s namespace.\n", o.Namespace)
+		}
+		return nil
+	}
+
+	var summaries []*CertificateSummary
+	for _, data := range allData {
+		summaries = append(summaries, SummaryFromResources(data))
+	}
+
+	if err := printSummaries(o.Out, summaries, o.AllNamespaces); err != nil {
+		return err
+	}
+
+	for _, summary := range summaries {
+		if summary.Error != "" {
+			fmt.Fprintf(o.ErrOut, "error when getting the related resources of Certificate %s/%s: %s\n", summary.Namespace, summary.Name, summary.Error)
+		}
+	}
+	return nil
+}
+
+// printSummaries writes summaries as a table to w. The namespace column is only
+// printed if withNamespace is true.
+func printSummaries(w io.Writer, summaries []*CertificateSummary, withNamespace bool) error {
+	tabWriter := util.NewTabWriter(w)
+
+	if withNamespace {
+		fmt.Fprint(tabWriter, "NAMESPACE\t")
+	}
+	fmt.Fprintln(tabWriter, "NAME\tREADY\tISSUER\tNOT AFTER\tRENEWAL TIME\tREQUEST\tFAILING CHALLENGES")
+
+	for _, summary := range summaries {
+		if withNamespace {
+			fmt.Fprintf(tabWriter, "%s\t", summary.Namespace)
+		}
+		fmt.Fprintf(tabWriter, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			summary.Name, summary.Ready, summary.Issuer,
+			util.FormatTimeString(summary.NotAfter), util.FormatTimeString(summary.RenewalTime),
+			summary.Request, summary.FailingChallenges)
+	}
+
+	return tabWriter.Flush()
+}
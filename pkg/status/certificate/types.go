@@ -17,7 +17,6 @@ limitations under the License.
 package certificate
 
 import (
-	"bytes"
 	"crypto/x509"
 	"encoding/hex"
 	"fmt"
@@ -29,9 +28,9 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/kubectl/pkg/describe"
 
 	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+	verifycertificate "github.com/cert-manager/cmctl/v2/pkg/verify/certificate"
 )
 
 type CertificateStatus struct {
@@ -63,6 +62,14 @@ type CertificateStatus struct {
 	OrderStatus *OrderStatus
 
 	ChallengeStatusList *ChallengeStatusList
+
+	// Findings of the diagnosis of the Certificate, nil if no diagnosis was
+	// requested
+	Findings []Finding
+
+	// History of the issuances of the Certificate, nil if no history was
+	// requested
+	History *History
 }
 
 type IssuerStatus struct {
@@ -105,6 +112,12 @@ type SecretStatus struct {
 	AuthorityKeyId []byte
 	// Serial Number of the x509 certificate in the Secret
 	SerialNumber *big.Int
+	// Drift lists the differences between the Certificate spec and the
+	// certificate, private key and metadata of the Secret
+	Drift []verifycertificate.Mismatch
+	// If DriftError is not nil, the Secret could not be compared with the
+	// Certificate spec
+	DriftError error
 	// Events of Secret resource
 	Events *v1.EventList
 }
@@ -221,6 +234,16 @@ func (status *CertificateStatus) withSecret(secret *v1.Secret, secretEvents *v1.
 	return status
 }
 
+// withDrift compares the Secret with the spec of crt, if the certificate in
+// the Secret could be parsed.
+func (status *CertificateStatus) withDrift(crt *cmapi.Certificate, secret *v1.Secret) *CertificateStatus {
+	if status.SecretStatus == nil || status.SecretStatus.Error != nil {
+		return status
+	}
+	status.SecretStatus.Drift, status.SecretStatus.DriftError = verifycertificate.Drift(crt, secret)
+	return status
+}
+
 func (status *CertificateStatus) withCR(req *cmapi.CertificateRequest, events *v1.EventList, err error) *CertificateStatus {
 	if err != nil {
 		status.CRStatus = &CRStatus{Error: err}
@@ -278,7 +301,7 @@ func (status *CertificateStatus) String() string {
 	output := ""
 	output += fmt.Sprintf("Name: %s\n", status.Name)
 	output += fmt.Sprintf("Namespace: %s\n", status.Namespace)
-	output += fmt.Sprintf("Created at: %s\n", formatTimeString(&status.CreationTime))
+	output += fmt.Sprintf("Created at: %s\n", util.FormatTimeString(&status.CreationTime))
 
 	// Output one line about each type of Condition that is set.
 	// Certificate can have multiple Conditions of different types set, e.g. "Ready" or "Issuing"
@@ -293,14 +316,14 @@ func (status *CertificateStatus) String() string {
 
 	output += fmt.Sprintf("DNS Names:\n%s", formatStringSlice(status.DNSNames))
 
-	output += eventsToString(status.Events, 0)
+	output += util.EventsToString(status.Events, 0)
 
 	output += status.IssuerStatus.String()
 	output += status.SecretStatus.String()
 
-	output += fmt.Sprintf("Not Before: %s\n", formatTimeString(status.NotBefore))
-	output += fmt.Sprintf("Not After: %s\n", formatTimeString(status.NotAfter))
-	output += fmt.Sprintf("Renewal Time: %s\n", formatTimeString(status.RenewalTime))
+	output += fmt.Sprintf("Not Before: %s\n", util.FormatTimeString(status.NotBefore))
+	output += fmt.Sprintf("Not After: %s\n", util.FormatTimeString(status.NotAfter))
+	output += fmt.Sprintf("Renewal Time: %s\n", util.FormatTimeString(status.RenewalTime))
 
 	output += status.CRStatus.String()
 
@@ -313,6 +336,14 @@ func (status *CertificateStatus) String() string {
 		output += status.ChallengeStatusList.String()
 	}
 
+	if status.History != nil {
+		output += status.History.String()
+	}
+
+	if status.Findings != nil {
+		output += FindingsToString(status.Findings)
+	}
+
 	return output
 }
 
@@ -335,7 +366,7 @@ func (issuerStatus *IssuerStatus) String() string {
 		conditionMsg = "  No Conditions set\n"
 	}
 	output := fmt.Sprintf(issuerFormat, issuerStatus.Name, issuerStatus.Kind, conditionMsg)
-	output += eventsToString(issuerStatus.Events, 1)
+	output += util.EventsToString(issuerStatus.Events, 1)
 	return output
 }
 
@@ -369,7 +400,18 @@ func (secretStatus *SecretStatus) String() string {
 		extKeyUsageString, secretStatus.PublicKeyAlgorithm, secretStatus.SignatureAlgorithm,
 		hex.EncodeToString(secretStatus.SubjectKeyId), hex.EncodeToString(secretStatus.AuthorityKeyId),
 		hex.EncodeToString(secretStatus.SerialNumber.Bytes()))
-	output += eventsToString(secretStatus.Events, 1)
+	switch {
+	case secretStatus.DriftError != nil:
+		output += fmt.Sprintf("  Drift: %s\n", secretStatus.DriftError)
+	case len(secretStatus.Drift) == 0:
+		output += "  Drift: Secret matches the Certificate spec\n"
+	default:
+		output += "  Drift:\n"
+		for _, m := range secretStatus.Drift {
+			output += fmt.Sprintf("  - %s\n", m)
+		}
+	}
+	output += util.EventsToString(secretStatus.Events, 1)
 	return output
 }
 
@@ -444,7 +486,7 @@ func (crStatus *CRStatus) String() string {
 	infos := fmt.Sprintf(crFormat, crStatus.Name, crStatus.Namespace, conditionMsg)
 	infos = fmt.Sprintf("CertificateRequest:%s", infos)
 
-	infos += eventsToString(crStatus.Events, 1)
+	infos += util.EventsToString(crStatus.Events, 1)
 	return infos
 }
 
@@ -472,7 +514,7 @@ func (orderStatus *OrderStatus) String() string {
 		output += authString.String()
 	}
 	if orderStatus.FailureTime != nil {
-		output += fmt.Sprintf("  FailureTime: %s\n", formatTimeString(orderStatus.FailureTime))
+		output += fmt.Sprintf("  FailureTime: %s\n", util.FormatTimeString(orderStatus.FailureTime))
 	}
 
 	return output
@@ -497,13 +539,3 @@ func (challengeStatus *ChallengeStatus) String() string {
 		challengeStatus.Name, challengeStatus.Type, challengeStatus.Token, challengeStatus.Key, challengeStatus.State,
 		challengeStatus.Reason, challengeStatus.Processing, challengeStatus.Presented)
 }
-
-func eventsToString(events *v1.EventList, baseLevel int) string {
-	var buf bytes.Buffer
-	defer buf.Reset()
-	tabWriter := util.NewTabWriter(&buf)
-	prefixWriter := describe.NewPrefixWriter(tabWriter)
-	util.DescribeEvents(events, prefixWriter, baseLevel)
-	tabWriter.Flush()
-	return buf.String()
-}
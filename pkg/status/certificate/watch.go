@@ -0,0 +1,357 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	k8sclock "k8s.io/utils/clock"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+// Transition is a change in the state of the Certificate or one of its
+// related resources, observed while watching the Certificate.
+type Transition struct {
+	// Time the change was observed
+	Time time.Time
+	// Resource whose state changed, e.g. "CertificateRequest my-crt-1"
+	Resource string
+	// State of the resource before the change, empty if the resource did not exist
+	From string
+	// State of the resource after the change, empty if the resource was deleted
+	To string
+}
+
+// String returns the Transition as a single line to be printed in the timeline.
+func (t Transition) String() string {
+	from, to := t.From, t.To
+	if from == "" {
+		from = "<none>"
+	}
+	if to == "" {
+		to = "<none>"
+	}
+	return fmt.Sprintf("%s  %s: %s -> %s", t.Time.Format(time.RFC3339), t.Resource, from, to)
+}
+
+// stateFromResources flattens the state of the Certificate and its related
+// resources in data into a map keyed by resource. Comparing the maps of two
+// points in time gives the transitions that happened in between.
+func stateFromResources(data *Data) map[string]string {
+	state := map[string]string{}
+
+	crt := data.Certificate
+	for _, condType := range []cmapi.CertificateConditionType{cmapi.CertificateConditionReady, cmapi.CertificateConditionIssuing} {
+		if cond := apiutil.GetCertificateCondition(crt, condType); cond != nil {
+			state[fmt.Sprintf("Certificate %s condition", condType)] = conditionState(cond.Status, cond.Reason)
+		}
+	}
+
+	if data.Secret != nil {
+		secretState := "exists"
+		if cert, err := pki.DecodeX509CertificateBytes(data.Secret.Data["tls.crt"]); err == nil {
+			secretState = "serial " + hex.EncodeToString(cert.SerialNumber.Bytes())
+		}
+		state["Secret "+data.Secret.Name] = secretState
+	}
+
+	if data.Req != nil {
+		state["CertificateRequest "+data.Req.Name] = requestState(data.Req)
+	}
+
+	if data.Order != nil {
+		state["Order "+data.Order.Name] = string(data.Order.Status.State)
+	}
+
+	for _, challenge := range data.Challenges {
+		state["Challenge "+challenge.Name] = string(challenge.Status.State)
+	}
+
+	return state
+}
+
+func conditionState(status cmmeta.ConditionStatus, reason string) string {
+	if reason == "" {
+		return string(status)
+	}
+	return fmt.Sprintf("%s (%s)", status, reason)
+}
+
+// transitionsBetween returns the transitions from the state prev to the state
+// cur, sorted by resource.
+func transitionsBetween(prev, cur map[string]string, now time.Time) []Transition {
+	var transitions []Transition
+	for resource, to := range cur {
+		if from := prev[resource]; from != to {
+			transitions = append(transitions, Transition{Time: now, Resource: resource, From: from, To: to})
+		}
+	}
+	for resource, from := range prev {
+		if _, ok := cur[resource]; !ok {
+			transitions = append(transitions, Transition{Time: now, Resource: resource, From: from})
+		}
+	}
+
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].Resource < transitions[j].Resource
+	})
+	return transitions
+}
+
+// finalState returns a description of the state of crt and true if no
+// issuance is in progress and the Certificate either is Ready or its last
+// issuance failed. Otherwise it returns false.
+func finalState(crt *cmapi.Certificate) (string, bool) {
+	issuing := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	if issuing != nil && issuing.Status == cmmeta.ConditionTrue {
+		return "", false
+	}
+
+	if issuing != nil && issuing.Reason == "Failed" {
+		return fmt.Sprintf("Issuance failed: %s", issuing.Message), true
+	}
+	if crt.Status.LastFailureTime != nil {
+		return fmt.Sprintf("Issuance failed at %s", util.FormatTimeString(crt.Status.LastFailureTime)), true
+	}
+
+	ready := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady)
+	if ready != nil && ready.Status == cmmeta.ConditionTrue {
+		return fmt.Sprintf("Ready: %s", ready.Message), true
+	}
+
+	return "", false
+}
+
+// runWatch prints the status of the Certificate with name crtName, and prints
+// it again whenever the Certificate or one of its related resources changes.
+// It returns once the Certificate is in a final state, right away if it
+// already is, or when ctx is cancelled. The timeline of all the transitions
+// is printed before returning. Unless ctx is cancelled, the exit code is set
+// from the final state.
+func (o *Options) runWatch(ctx context.Context, crtName string) error {
+	clientSet, err := kubernetes.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return err
+	}
+
+	return o.watch(ctx, clientSet, crtName)
+}
+
+// watch implements runWatch, clientSet is used to watch the Secret and to get
+// the events.
+func (o *Options) watch(ctx context.Context, clientSet kubernetes.Interface, crtName string) error {
+	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, crtName, metav1.GetOptions{})
+	if err != nil {
+		return cmcmdutil.SetFetchErrorExitCode(fmt.Errorf("error when getting Certificate resource: %v", err))
+	}
+
+	// Informer events are coalesced, only one refresh is pending at any time
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	cmFactory := cminformers.NewSharedInformerFactoryWithOptions(o.CMClient, 0,
+		cminformers.WithNamespace(crt.Namespace))
+	kubeFactory := kubeinformers.NewSharedInformerFactoryWithOptions(clientSet, 0,
+		kubeinformers.WithNamespace(crt.Namespace),
+		kubeinformers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", crt.Spec.SecretName).String()
+		}))
+
+	reqInformer := cmFactory.Certmanager().V1().CertificateRequests().Informer()
+	orderInformer := cmFactory.Acme().V1().Orders().Informer()
+
+	// The informers watch all resources of the namespace, only changes to the
+	// resources related to the Certificate trigger a refresh
+	filter := &relatedFilter{crt: crt, requests: reqInformer.GetStore(), orders: orderInformer.GetStore()}
+	handler := cache.FilteringResourceEventHandler{
+		FilterFunc: filter.isRelated,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(any) { notify() },
+			UpdateFunc: func(any, any) { notify() },
+			DeleteFunc: func(any) { notify() },
+		},
+	}
+
+	for _, informer := range []cache.SharedIndexInformer{
+		cmFactory.Certmanager().V1().Certificates().Informer(),
+		reqInformer,
+		orderInformer,
+		cmFactory.Acme().V1().Challenges().Informer(),
+		kubeFactory.Core().V1().Secrets().Informer(),
+	} {
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return err
+		}
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	cmFactory.Start(watchCtx.Done())
+	kubeFactory.Start(watchCtx.Done())
+	// Shutdown waits for the informers, which only stop once watchCtx is
+	// cancelled
+	defer func() {
+		cancel()
+		cmFactory.Shutdown()
+		kubeFactory.Shutdown()
+	}()
+
+	// Messages about the watch itself must not end up in between structured output
+	logOut := o.Out
+	if util.IsStructuredOutput(o.PrintFlags) {
+		logOut = o.ErrOut
+	}
+
+	var (
+		prevState map[string]string
+		timeline  []Transition
+		final     string
+		data      *Data
+	)
+	for {
+		crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, crtName, metav1.GetOptions{})
+		if err != nil {
+			return cmcmdutil.SetFetchErrorExitCode(fmt.Errorf("error when getting Certificate resource: %v", err))
+		}
+
+		data, err = o.GetResourcesForCertificate(ctx, clientSet, crt)
+		if err != nil {
+			return cmcmdutil.SetFetchErrorExitCode(err)
+		}
+
+		state := stateFromResources(data)
+		transitions := transitionsBetween(prevState, state, clock.Now())
+		if prevState == nil || len(transitions) > 0 {
+			if prevState != nil {
+				timeline = append(timeline, transitions...)
+				fmt.Fprintf(logOut, "\n--- %s: status changed ---\n", clock.Now().Format(time.RFC3339))
+			}
+			if err := o.printStatus(o.statusFromResources(data)); err != nil {
+				return err
+			}
+		}
+		prevState = state
+
+		var isFinal bool
+		if final, isFinal = finalState(crt); isFinal {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			final = "Watch interrupted before the Certificate reached a final state"
+			printTimeline(logOut, final, timeline)
+			return nil
+		case <-changed:
+		}
+	}
+
+	printTimeline(logOut, final, timeline)
+
+	cmcmdutil.SetExitCodeValue(exitCodeFromResources(data, clock.Now(), o.ExpiringSoonThreshold))
+	return nil
+}
+
+// relatedFilter filters the informer events of the resources in the namespace
+// of a Certificate, to only the resources related to the Certificate.
+type relatedFilter struct {
+	crt *cmapi.Certificate
+	// requests and orders are the stores of the CertificateRequest and Order
+	// informers, used to look up the owners of Orders and Challenges
+	requests cache.Store
+	orders   cache.Store
+}
+
+// isRelated returns true if obj is the Certificate, or a CertificateRequest,
+// Order or Challenge controlled by it, directly or through a CertificateRequest
+// and an Order. The Secret informer only watches the Secret of the Certificate,
+// so Secrets are always related.
+func (f *relatedFilter) isRelated(obj any) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	switch obj := obj.(type) {
+	case *cmapi.Certificate:
+		return obj.Name == f.crt.Name
+	case *cmapi.CertificateRequest:
+		return metav1.IsControlledBy(obj, f.crt)
+	case *cmacme.Order:
+		return f.controllerIsRelated(obj, f.requests)
+	case *cmacme.Challenge:
+		return f.controllerIsRelated(obj, f.orders)
+	default:
+		return true
+	}
+}
+
+// controllerIsRelated returns true if the controller of obj is found in owners
+// and is related to the Certificate.
+func (f *relatedFilter) controllerIsRelated(obj metav1.Object, owners cache.Store) bool {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil {
+		return false
+	}
+
+	owner, exists, err := owners.GetByKey(obj.GetNamespace() + "/" + ref.Name)
+	if err != nil || !exists {
+		return false
+	}
+
+	ownerMeta, ok := owner.(metav1.Object)
+	return ok && ownerMeta.GetUID() == ref.UID && f.isRelated(owner)
+}
+
+// printTimeline writes the final state and all observed transitions to w.
+func printTimeline(w io.Writer, final string, timeline []Transition) {
+	fmt.Fprintf(w, "\nFinal state: %s\n", final)
+
+	if len(timeline) == 0 {
+		fmt.Fprintln(w, "Timeline: <none>")
+		return
+	}
+
+	lines := make([]string, 0, len(timeline))
+	for _, transition := range timeline {
+		lines = append(lines, transition.String())
+	}
+	fmt.Fprintf(w, "Timeline:\n%s", formatStringSlice(lines))
+}
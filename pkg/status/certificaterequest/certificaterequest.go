@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/reference"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/convert"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	// Dedicated scheme used by the ctl tool that has the internal cert-manager types,
+	// and their conversion functions registered
+	scheme = convert.Scheme
+)
+
+// Options is a struct to support status certificaterequest command
+type Options struct {
+	// PrintFlags holds the flags used to print the status in a structured
+	// format. If no output format is set, the human readable output is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// Data is a struct containing the information to build a CertificateRequestStatus
+type Data struct {
+	Req    *cmapi.CertificateRequest
+	Events *corev1.EventList
+	// Certificate owning the CertificateRequest, nil if the CertificateRequest
+	// is not owned by a Certificate
+	Certificate      *cmapi.Certificate
+	CertificateError error
+	// ACME Order created for the CertificateRequest, nil if there is none
+	Order      *cmacme.Order
+	OrderError error
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		PrintFlags: util.NewPrintFlags(),
+		IOStreams:  ioStreams,
+	}
+}
+
+// NewCmdStatusCertificateRequest returns a cobra command for status certificaterequest
+func NewCmdStatusCertificateRequest(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "certificaterequest",
+		Aliases: []string{"certificaterequests", "cr", "crs"},
+		Short:   "Get details about the current status of a cert-manager CertificateRequest resource",
+		Long: templates.LongDesc(`
+Get details about the current status of a cert-manager CertificateRequest resource, including the decoded CSR, who requested it, who approved or denied it, and the issued certificate.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Query status of CertificateRequest with name 'my-cr' in namespace 'my-namespace'
+{{.BuildName}} status certificaterequest my-cr --namespace my-namespace
+
+# Print who approved the CertificateRequest with name 'my-cr'
+{{.BuildName}} status certificaterequest my-cr -o jsonpath='{.conditions[?(@.type=="Approved")].setBy}'
+`)),
+		ValidArgsFunction: factory.ValidArgsListCertificateRequests(&o.Factory),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the CertificateRequest has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the CertificateRequest")
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run executes status certificaterequest command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	data, err := o.GetResources(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	status := StatusFromResources(data)
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		return util.PrintObject(printer, status, o.Out)
+	}
+
+	fmt.Fprint(o.Out, status.String())
+
+	return nil
+}
+
+// GetResources collects the CertificateRequest with the given name and its
+// related resources in a Data struct and returns it.
+// Returns error if the CertificateRequest cannot be found or its events cannot
+// be listed. Errors when finding related resources are stored in Data.
+func (o *Options) GetResources(ctx context.Context, name string) (*Data, error) {
+	req, err := o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when getting CertificateRequest resource: %v", err)
+	}
+
+	reqRef, err := reference.GetReference(scheme, req)
+	if err != nil {
+		return nil, err
+	}
+	// If no events found, events would be nil and handled down the line in DescribeEvents
+	events, err := o.KubeClient.CoreV1().Events(req.Namespace).SearchWithContext(ctx, scheme, reqRef)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &Data{Req: req, Events: events}
+
+	if owner := metav1.GetControllerOf(req); owner != nil && owner.Kind == cmapi.CertificateKind {
+		crt, err := o.CMClient.CertmanagerV1().Certificates(req.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			data.CertificateError = fmt.Errorf("owning Certificate %q not found", owner.Name)
+		case err != nil:
+			data.CertificateError = fmt.Errorf("error when getting owning Certificate: %w", err)
+		default:
+			data.Certificate = crt
+		}
+	}
+
+	data.Order, data.OrderError = util.FindMatchingOrder(ctx, util.NewOwnedResources(o.CMClient), req)
+
+	return data, nil
+}
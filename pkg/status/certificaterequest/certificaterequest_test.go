@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionManagers(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2020, 9, 16, 9, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(time.Date(2020, 9, 16, 10, 0, 0, 0, time.UTC))
+
+	entries := []metav1.ManagedFieldsEntry{
+		{
+			Manager:   "cert-manager-certificates-request-manager",
+			Operation: metav1.ManagedFieldsOperationApply,
+			Time:      &earlier,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:request":{}}}`)},
+		},
+		{
+			Manager:     "cmctl",
+			Operation:   metav1.ManagedFieldsOperationUpdate,
+			Subresource: "status",
+			Time:        &earlier,
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{".":{},"k:{\"type\":\"Approved\"}":{".":{},"f:status":{}}}}}`)},
+		},
+		{
+			Manager:     "cert-manager-certificaterequests-issuer-ca",
+			Operation:   metav1.ManagedFieldsOperationApply,
+			Subresource: "status",
+			Time:        &later,
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{"k:{\"type\":\"Ready\"}":{".":{},"f:status":{}}}}}`)},
+		},
+		{
+			Manager:     "co-owner",
+			Operation:   metav1.ManagedFieldsOperationApply,
+			Subresource: "status",
+			Time:        &later,
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{"k:{\"type\":\"Approved\"}":{".":{},"f:status":{}}}}}`)},
+		},
+		{
+			Manager:  "invalid",
+			Time:     &later,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`not json`)},
+		},
+	}
+
+	managers := conditionManagers(entries)
+
+	assert.Len(t, managers, 2)
+	assert.Equal(t, "co-owner", managers[cmapi.CertificateRequestConditionApproved].Manager)
+	assert.Equal(t, "cert-manager-certificaterequests-issuer-ca", managers[cmapi.CertificateRequestConditionReady].Manager)
+}
+
+func TestStatusFromResources(t *testing.T) {
+	timestamp := metav1.NewTime(time.Date(2020, 9, 16, 9, 26, 18, 0, time.UTC))
+
+	csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRCommonName("example.com"), gen.SetCSRDNSNames("example.com", "www.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revision := 1
+	crt := gen.Certificate("test-crt", gen.SetCertificateNamespace("ns1"), gen.SetCertificateRevision(revision))
+
+	req := gen.CertificateRequest("test-crt-2",
+		gen.SetCertificateRequestNamespace("ns1"),
+		gen.SetCertificateRequestCSR(csr),
+		gen.SetCertificateRequestIssuer(cmmeta.IssuerReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}),
+		gen.SetCertificateRequestUsername("system:serviceaccount:cert-manager:cert-manager"),
+		gen.SetCertificateRequestGroups([]string{"system:serviceaccounts", "system:authenticated"}),
+		gen.SetCertificateRequestRevision("2"),
+		gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Reason: "KubectlCertManager", Message: "manually denied by cmctl"}),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))),
+	)
+	req.CreationTimestamp = timestamp
+	req.ManagedFields = []metav1.ManagedFieldsEntry{{
+		Manager:     "cmctl",
+		Operation:   metav1.ManagedFieldsOperationUpdate,
+		Subresource: "status",
+		Time:        &timestamp,
+		FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{"k:{\"type\":\"Denied\"}":{}}}}`)},
+	}}
+
+	status := StatusFromResources(&Data{
+		Req:         req,
+		Certificate: crt,
+		OrderError:  errors.New("error when listing Order resources: forbidden"),
+	})
+
+	assert.Equal(t, `Name: test-crt-2
+Namespace: ns1
+Created at: 2020-09-16T09:26:18Z
+Issuer: ClusterIssuer/ca
+Requester:
+  Username: system:serviceaccount:cert-manager:cert-manager
+  UID: <none>
+  Groups: system:serviceaccounts, system:authenticated
+Owning Certificate: test-crt, Request Revision: 2, Certificate Revision: 1
+Conditions:
+  Denied: True, Reason: KubectlCertManager, Message: manually denied by cmctl
+    Set by: cmctl at 2020-09-16T09:26:18Z
+Request:
+  Subject: CN=example.com
+  DNS Names: example.com, www.example.com
+  IP Addresses: <none>
+  URIs: <none>
+  Email Addresses: <none>
+  Public Key Algorithm: ECDSA
+  Signature Algorithm: ECDSA-SHA256
+  Is CA: false
+  Duration: <none>
+  Usages: digital signature
+Order: error when listing Order resources: forbidden
+Issued Certificate: <none>
+Events:  <none>
+`, status.String())
+}
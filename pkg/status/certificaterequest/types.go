@@ -0,0 +1,389 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// CertificateRequestStatusKind is the kind of the structured output of the
+// status certificaterequest command.
+const CertificateRequestStatusKind = "CertificateRequestStatus"
+
+// CertificateRequestStatus is the status of a CertificateRequest, printed by
+// the status certificaterequest command.
+// The json tags define the structured output printed when --output is used.
+type CertificateRequestStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Name of the CertificateRequest resource
+	Name string `json:"name"`
+	// Namespace of the CertificateRequest resource
+	Namespace string `json:"namespace"`
+	// Creation Time of CertificateRequest resource
+	CreationTime metav1.Time `json:"creationTime"`
+	// Issuer the CertificateRequest is requested from, as "Kind/name" with the
+	// group appended for external issuers
+	Issuer string `json:"issuer"`
+	// Requester is the identity of the user that created the CertificateRequest
+	Requester Requester `json:"requester"`
+	// Conditions of CertificateRequest resource, together with who set them
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Request is the decoded CSR of the CertificateRequest
+	Request *Request `json:"request,omitempty"`
+	// Certificate is the issued certificate, nil if not issued yet
+	Certificate *IssuedCertificate `json:"certificate,omitempty"`
+	// OwningCertificate is the Certificate owning the CertificateRequest
+	OwningCertificate *OwningCertificate `json:"owningCertificate,omitempty"`
+	// Order is the ACME Order created for the CertificateRequest
+	Order *Order `json:"order,omitempty"`
+	// Events of CertificateRequest resource
+	Events []util.Event `json:"events,omitempty"`
+
+	events *corev1.EventList
+}
+
+// Requester is the identity of the user that created a CertificateRequest, as
+// recorded by the cert-manager webhook.
+type Requester struct {
+	Username string   `json:"username,omitempty"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// Condition is a condition of a CertificateRequest, together with the field
+// manager that last set it.
+type Condition struct {
+	cmapi.CertificateRequestCondition `json:",inline"`
+
+	// SetBy is the name of the field manager that last set the condition,
+	// taken from the managedFields of the CertificateRequest
+	SetBy string `json:"setBy,omitempty"`
+	// SetAt is the time the field manager last set the condition
+	SetAt *metav1.Time `json:"setAt,omitempty"`
+}
+
+// Request is the decoded CSR of a CertificateRequest.
+type Request struct {
+	Error              string           `json:"error,omitempty"`
+	Subject            string           `json:"subject,omitempty"`
+	DNSNames           []string         `json:"dnsNames,omitempty"`
+	IPAddresses        []string         `json:"ipAddresses,omitempty"`
+	URIs               []string         `json:"uris,omitempty"`
+	EmailAddresses     []string         `json:"emailAddresses,omitempty"`
+	PublicKeyAlgorithm string           `json:"publicKeyAlgorithm,omitempty"`
+	SignatureAlgorithm string           `json:"signatureAlgorithm,omitempty"`
+	IsCA               bool             `json:"isCA"`
+	Duration           *metav1.Duration `json:"duration,omitempty"`
+	Usages             []cmapi.KeyUsage `json:"usages,omitempty"`
+}
+
+// IssuedCertificate is the decoded certificate issued for a CertificateRequest.
+type IssuedCertificate struct {
+	Error        string       `json:"error,omitempty"`
+	Subject      string       `json:"subject,omitempty"`
+	Issuer       string       `json:"issuer,omitempty"`
+	SerialNumber string       `json:"serialNumber,omitempty"`
+	NotBefore    *metav1.Time `json:"notBefore,omitempty"`
+	NotAfter     *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// OwningCertificate is the Certificate owning a CertificateRequest.
+type OwningCertificate struct {
+	Error string `json:"error,omitempty"`
+	Name  string `json:"name,omitempty"`
+	// Revision of the Certificate the CertificateRequest was created for
+	Revision string `json:"revision,omitempty"`
+	// CertificateRevision is the revision of the last certificate issued for
+	// the Certificate
+	CertificateRevision *int `json:"certificateRevision,omitempty"`
+}
+
+// Order is the ACME Order created for a CertificateRequest.
+type Order struct {
+	Error  string       `json:"error,omitempty"`
+	Name   string       `json:"name,omitempty"`
+	State  cmacme.State `json:"state,omitempty"`
+	Reason string       `json:"reason,omitempty"`
+}
+
+// StatusFromResources takes in a Data struct and returns a
+// CertificateRequestStatus built using the information in data.
+func StatusFromResources(data *Data) *CertificateRequestStatus {
+	req := data.Req
+
+	status := &CertificateRequestStatus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       CertificateRequestStatusKind,
+		},
+		Name:         req.Name,
+		Namespace:    req.Namespace,
+		CreationTime: req.CreationTimestamp,
+		Issuer:       util.FormatIssuerRef(req.Spec.IssuerRef),
+		Requester: Requester{
+			Username: req.Spec.Username,
+			UID:      req.Spec.UID,
+			Groups:   req.Spec.Groups,
+		},
+		Request:     requestFromCSR(req),
+		Certificate: issuedCertificate(req.Status.Certificate),
+		Events:      util.EventsFromList(data.Events),
+		events:      data.Events,
+	}
+
+	managers := conditionManagers(req.ManagedFields)
+	for _, cond := range req.Status.Conditions {
+		condition := Condition{CertificateRequestCondition: cond}
+		if entry, ok := managers[cond.Type]; ok {
+			condition.SetBy = entry.Manager
+			condition.SetAt = entry.Time
+		}
+		status.Conditions = append(status.Conditions, condition)
+	}
+
+	if data.CertificateError != nil {
+		status.OwningCertificate = &OwningCertificate{Error: util.ErrorString(data.CertificateError)}
+	} else if crt := data.Certificate; crt != nil {
+		status.OwningCertificate = &OwningCertificate{
+			Name:                crt.Name,
+			Revision:            req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey],
+			CertificateRevision: crt.Status.Revision,
+		}
+	}
+
+	if data.OrderError != nil {
+		status.Order = &Order{Error: util.ErrorString(data.OrderError)}
+	} else if order := data.Order; order != nil {
+		status.Order = &Order{Name: order.Name, State: order.Status.State, Reason: order.Status.Reason}
+	}
+
+	return status
+}
+
+// conditionManagers returns the managedFields entry of the field manager that
+// last set each condition type, based on the ownership of the
+// status.conditions list entries.
+func conditionManagers(entries []metav1.ManagedFieldsEntry) map[cmapi.CertificateRequestConditionType]metav1.ManagedFieldsEntry {
+	managers := map[cmapi.CertificateRequestConditionType]metav1.ManagedFieldsEntry{}
+
+	for _, entry := range entries {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+
+		var fields map[string]any
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		statusFields, _ := fields["f:status"].(map[string]any)
+		conditionFields, _ := statusFields["f:conditions"].(map[string]any)
+
+		for key := range conditionFields {
+			// List entries are keyed by the type of the condition, e.g. k:{"type":"Approved"}
+			var listKey struct {
+				Type cmapi.CertificateRequestConditionType `json:"type"`
+			}
+			if !strings.HasPrefix(key, "k:") || json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &listKey) != nil {
+				continue
+			}
+
+			// The same condition can be co-owned by multiple managers, the
+			// last one to write it is the one that set its current value
+			if prev, ok := managers[listKey.Type]; ok && !isAfter(entry.Time, prev.Time) {
+				continue
+			}
+			managers[listKey.Type] = entry
+		}
+	}
+
+	return managers
+}
+
+func isAfter(t, other *metav1.Time) bool {
+	if t == nil {
+		return false
+	}
+	return other == nil || t.After(other.Time)
+}
+
+func requestFromCSR(req *cmapi.CertificateRequest) *Request {
+	csr, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+	if err != nil {
+		return &Request{Error: fmt.Sprintf("error when decoding the CSR: %s", err)}
+	}
+
+	request := &Request{
+		Subject:            csr.Subject.String(),
+		DNSNames:           csr.DNSNames,
+		EmailAddresses:     csr.EmailAddresses,
+		PublicKeyAlgorithm: csr.PublicKeyAlgorithm.String(),
+		SignatureAlgorithm: csr.SignatureAlgorithm.String(),
+		IsCA:               req.Spec.IsCA,
+		Duration:           req.Spec.Duration,
+		Usages:             req.Spec.Usages,
+	}
+	for _, ip := range csr.IPAddresses {
+		request.IPAddresses = append(request.IPAddresses, ip.String())
+	}
+	for _, uri := range csr.URIs {
+		request.URIs = append(request.URIs, uri.String())
+	}
+	return request
+}
+
+func issuedCertificate(certData []byte) *IssuedCertificate {
+	if len(certData) == 0 {
+		return nil
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(certData)
+	if err != nil {
+		return &IssuedCertificate{Error: fmt.Sprintf("error when decoding the issued certificate: %s", err)}
+	}
+
+	return &IssuedCertificate{
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: hex.EncodeToString(cert.SerialNumber.Bytes()),
+		NotBefore:    &metav1.Time{Time: cert.NotBefore},
+		NotAfter:     &metav1.Time{Time: cert.NotAfter},
+	}
+}
+
+// String returns the information about the status of a CertificateRequest as
+// a string to be printed as output
+func (status *CertificateRequestStatus) String() string {
+	output := ""
+	output += fmt.Sprintf("Name: %s\n", status.Name)
+	output += fmt.Sprintf("Namespace: %s\n", status.Namespace)
+	output += fmt.Sprintf("Created at: %s\n", util.FormatTimeString(&status.CreationTime))
+	output += fmt.Sprintf("Issuer: %s\n", status.Issuer)
+
+	output += "Requester:\n"
+	output += fmt.Sprintf("  Username: %s\n", util.FormatOptionalString(status.Requester.Username))
+	output += fmt.Sprintf("  UID: %s\n", util.FormatOptionalString(status.Requester.UID))
+	output += fmt.Sprintf("  Groups: %s\n", util.FormatOptionalString(strings.Join(status.Requester.Groups, ", ")))
+
+	switch owner := status.OwningCertificate; {
+	case owner == nil:
+		output += "Owning Certificate: <none>\n"
+	case owner.Error != "":
+		output += fmt.Sprintf("Owning Certificate: %s\n", owner.Error)
+	default:
+		certificateRevision := "<none>"
+		if owner.CertificateRevision != nil {
+			certificateRevision = fmt.Sprint(*owner.CertificateRevision)
+		}
+		output += fmt.Sprintf("Owning Certificate: %s, Request Revision: %s, Certificate Revision: %s\n",
+			owner.Name, util.FormatOptionalString(owner.Revision), certificateRevision)
+	}
+
+	conditionMsg := ""
+	for _, con := range status.Conditions {
+		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, con.Status, con.Reason, con.Message)
+		if con.SetBy != "" {
+			conditionMsg += fmt.Sprintf("    Set by: %s at %s\n", con.SetBy, util.FormatTimeString(con.SetAt))
+		}
+	}
+	if conditionMsg == "" {
+		conditionMsg = "  No Conditions set\n"
+	}
+	output += fmt.Sprintf("Conditions:\n%s", conditionMsg)
+
+	output += status.Request.String()
+
+	if status.Order != nil {
+		output += status.Order.String()
+	}
+
+	if status.Certificate == nil {
+		output += "Issued Certificate: <none>\n"
+	} else {
+		output += status.Certificate.String()
+	}
+
+	output += util.EventsToString(status.events, 0)
+
+	return output
+}
+
+// String returns the decoded CSR as a string to be printed as output
+func (request *Request) String() string {
+	if request.Error != "" {
+		return fmt.Sprintf("Request: %s\n", request.Error)
+	}
+
+	usages := make([]string, 0, len(request.Usages))
+	for _, usage := range request.Usages {
+		usages = append(usages, string(usage))
+	}
+	duration := "<none>"
+	if request.Duration != nil {
+		duration = request.Duration.Duration.String()
+	}
+
+	output := "Request:\n"
+	output += fmt.Sprintf("  Subject: %s\n", util.FormatOptionalString(request.Subject))
+	output += fmt.Sprintf("  DNS Names: %s\n", util.FormatOptionalString(strings.Join(request.DNSNames, ", ")))
+	output += fmt.Sprintf("  IP Addresses: %s\n", util.FormatOptionalString(strings.Join(request.IPAddresses, ", ")))
+	output += fmt.Sprintf("  URIs: %s\n", util.FormatOptionalString(strings.Join(request.URIs, ", ")))
+	output += fmt.Sprintf("  Email Addresses: %s\n", util.FormatOptionalString(strings.Join(request.EmailAddresses, ", ")))
+	output += fmt.Sprintf("  Public Key Algorithm: %s\n", request.PublicKeyAlgorithm)
+	output += fmt.Sprintf("  Signature Algorithm: %s\n", request.SignatureAlgorithm)
+	output += fmt.Sprintf("  Is CA: %t\n", request.IsCA)
+	output += fmt.Sprintf("  Duration: %s\n", duration)
+	output += fmt.Sprintf("  Usages: %s\n", util.FormatOptionalString(strings.Join(usages, ", ")))
+	return output
+}
+
+// String returns the information about the Order as a string to be printed as output
+func (order *Order) String() string {
+	if order.Error != "" {
+		return fmt.Sprintf("Order: %s\n", order.Error)
+	}
+	return fmt.Sprintf("Order:\n  Name: %s\n  State: %s, Reason: %s\n", order.Name, order.State, order.Reason)
+}
+
+// String returns the information about the issued certificate as a string to be printed as output
+func (cert *IssuedCertificate) String() string {
+	if cert.Error != "" {
+		return fmt.Sprintf("Issued Certificate: %s\n", cert.Error)
+	}
+
+	output := "Issued Certificate:\n"
+	output += fmt.Sprintf("  Subject: %s\n", util.FormatOptionalString(cert.Subject))
+	output += fmt.Sprintf("  Issuer: %s\n", util.FormatOptionalString(cert.Issuer))
+	output += fmt.Sprintf("  Serial Number: %s\n", cert.SerialNumber)
+	output += fmt.Sprintf("  Not Before: %s\n", util.FormatTimeString(cert.NotBefore))
+	output += fmt.Sprintf("  Not After: %s\n", util.FormatTimeString(cert.NotAfter))
+	return output
+}
+
+// formatTimeString returns the time as a string
+// If nil, return "<none>"
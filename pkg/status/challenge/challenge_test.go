@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package challenge
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestSolverDescription(t *testing.T) {
+	tests := map[string]struct {
+		solver         cmacme.ACMEChallengeSolver
+		expDescription string
+	}{
+		"No solver": {
+			expDescription: "<unknown>",
+		},
+		"HTTP01 Ingress with class name": {
+			solver: cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: ptr.To("nginx"), ServiceType: "NodePort"},
+			}},
+			expDescription: "HTTP01 Ingress (ingressClassName: nginx, serviceType: NodePort)",
+		},
+		"HTTP01 Ingress without details": {
+			solver: cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			}},
+			expDescription: "HTTP01 Ingress",
+		},
+		"HTTP01 Gateway HTTPRoute": {
+			solver: cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{ParentRefs: []gwapi.ParentReference{
+					{Name: "gateway", Namespace: ptr.To(gwapi.Namespace("gateway-ns"))},
+					{Name: "other"},
+				}},
+			}},
+			expDescription: "HTTP01 Gateway HTTPRoute (parentRefs: gateway-ns/gateway, other)",
+		},
+		"DNS01 cloudflare with CNAME strategy": {
+			solver: cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{
+				CNAMEStrategy: cmacme.FollowStrategy,
+				Cloudflare:    &cmacme.ACMEIssuerDNS01ProviderCloudflare{},
+			}},
+			expDescription: "DNS01 cloudflare (cnameStrategy: Follow)",
+		},
+		"DNS01 webhook": {
+			solver: cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{
+				Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{GroupName: "acme.example.com", SolverName: "example"},
+			}},
+			expDescription: "DNS01 webhook (groupName: acme.example.com, solverName: example)",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expDescription, SolverDescription(test.solver))
+		})
+	}
+}
+
+func TestStatusFromResources(t *testing.T) {
+	timestamp := metav1.NewTime(time.Date(2020, 9, 16, 9, 26, 18, 0, time.UTC))
+
+	challenge := gen.Challenge("test-challenge",
+		gen.SetChallengeNamespace("ns1"),
+		gen.SetChallengeIssuer(cmmeta.IssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"}),
+		gen.SetChallengeDNSName("example.com"),
+		gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+		gen.SetChallengeURL("https://acme.example.com/chall/1"),
+		gen.SetChallengeToken("token"),
+		gen.SetChallengeKey("key"),
+		gen.SetChallengeSolverDNS01(cmacme.ACMEChallengeSolverDNS01{Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{HostedZoneID: "Z123"}}),
+		gen.SetChallengeState(cmacme.Pending),
+		gen.SetChallengeProcessing(true),
+		gen.SetChallengePresented(true),
+		gen.SetChallengePresentedAt(timestamp),
+		gen.SetChallengeReason("Waiting for DNS-01 challenge propagation: DNS record for \"example.com\" not yet propagated"),
+	)
+	challenge.CreationTimestamp = timestamp
+	challenge.Spec.AuthorizationURL = "https://acme.example.com/authz/1"
+	challenge.Spec.Solver.Selector = &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}
+
+	status := StatusFromResources(&Data{
+		Challenge:  challenge,
+		OrderError: errors.New("owning Order \"test-order\" not found"),
+	})
+
+	assert.Equal(t, `Name: test-challenge
+Namespace: ns1
+Created at: 2020-09-16T09:26:18Z
+Issuer: ClusterIssuer/letsencrypt
+Order: owning Order "test-order" not found
+DNS Name: example.com, Wildcard: false
+Type: DNS-01
+URL: https://acme.example.com/chall/1
+Authorization URL: https://acme.example.com/authz/1
+Token: token
+Key: key
+Solver: DNS01 route53 (hostedZoneID: Z123)
+Solver Selector: dnsZones: example.com
+State: pending, Processing: true, Presented: true
+Presented at: 2020-09-16T09:26:18Z
+Reason: Waiting for DNS-01 challenge propagation: DNS record for "example.com" not yet propagated
+Events:  <none>
+`, status.String())
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package challenge
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// ChallengeStatusKind is the kind of the structured output of the status
+// challenge command.
+const ChallengeStatusKind = "ChallengeStatus"
+
+// ChallengeStatus is the status of an ACME Challenge, printed by the status
+// challenge command.
+// The json tags define the structured output printed when --output is used.
+type ChallengeStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Name of the Challenge resource
+	Name string `json:"name"`
+	// Namespace of the Challenge resource
+	Namespace string `json:"namespace"`
+	// Creation Time of Challenge resource
+	CreationTime metav1.Time `json:"creationTime"`
+	// Issuer the Challenge is solved for
+	Issuer string `json:"issuer"`
+	// Order owning the Challenge
+	Order *OrderRef `json:"order,omitempty"`
+	// DNSName is the identifier that is being validated
+	DNSName string `json:"dnsName"`
+	// Wildcard is true if the Challenge is for a wildcard identifier
+	Wildcard bool `json:"wildcard"`
+	// Type of the Challenge, e.g. HTTP-01 or DNS-01
+	Type cmacme.ACMEChallengeType `json:"type"`
+	// URL of the Challenge on the ACME server
+	URL string `json:"url"`
+	// AuthorizationURL is the URL of the authorization the Challenge is part of
+	AuthorizationURL string `json:"authorizationURL"`
+	Token            string `json:"token"`
+	Key              string `json:"key"`
+	// Solver is a description of the solver selected for the Challenge
+	Solver string `json:"solver"`
+	// SolverSelector is a description of the selector of the selected solver
+	SolverSelector string `json:"solverSelector,omitempty"`
+	// State of the Challenge on the ACME server
+	State cmacme.State `json:"state,omitempty"`
+	// Reason contains human readable information on why the Challenge is in
+	// its current state, e.g. the reason the self check is failing
+	Reason      string       `json:"reason,omitempty"`
+	Processing  bool         `json:"processing"`
+	Presented   bool         `json:"presented"`
+	PresentedAt *metav1.Time `json:"presentedAt,omitempty"`
+	// Events of Challenge resource
+	Events []util.Event `json:"events,omitempty"`
+
+	events *corev1.EventList
+}
+
+// OrderRef is the Order owning a Challenge.
+type OrderRef struct {
+	Error string       `json:"error,omitempty"`
+	Name  string       `json:"name,omitempty"`
+	State cmacme.State `json:"state,omitempty"`
+}
+
+// StatusFromResources takes in a Data struct and returns a ChallengeStatus
+// built using the information in data.
+func StatusFromResources(data *Data) *ChallengeStatus {
+	challenge := data.Challenge
+
+	status := &ChallengeStatus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       ChallengeStatusKind,
+		},
+		Name:             challenge.Name,
+		Namespace:        challenge.Namespace,
+		CreationTime:     challenge.CreationTimestamp,
+		Issuer:           util.FormatIssuerRef(challenge.Spec.IssuerRef),
+		DNSName:          challenge.Spec.DNSName,
+		Wildcard:         challenge.Spec.Wildcard,
+		Type:             challenge.Spec.Type,
+		URL:              challenge.Spec.URL,
+		AuthorizationURL: challenge.Spec.AuthorizationURL,
+		Token:            challenge.Spec.Token,
+		Key:              challenge.Spec.Key,
+		Solver:           SolverDescription(challenge.Spec.Solver),
+		SolverSelector:   solverSelectorDescription(challenge.Spec.Solver.Selector),
+		State:            challenge.Status.State,
+		Reason:           challenge.Status.Reason,
+		Processing:       challenge.Status.Processing,
+		Presented:        challenge.Status.Presented,
+		PresentedAt:      challenge.Status.PresentedAt,
+		Events:           util.EventsFromList(data.Events),
+		events:           data.Events,
+	}
+
+	if data.OrderError != nil {
+		status.Order = &OrderRef{Error: util.ErrorString(data.OrderError)}
+	} else if data.Order != nil {
+		status.Order = &OrderRef{Name: data.Order.Name, State: data.Order.Status.State}
+	}
+
+	return status
+}
+
+// SolverDescription returns a short description of solver, naming the HTTP01
+// ingress or gateway configuration or the DNS01 provider.
+func SolverDescription(solver cmacme.ACMEChallengeSolver) string {
+	switch {
+	case solver.HTTP01 != nil && solver.HTTP01.Ingress != nil:
+		ingress := solver.HTTP01.Ingress
+		var details []string
+		if ingress.IngressClassName != nil {
+			details = append(details, "ingressClassName: "+*ingress.IngressClassName)
+		}
+		if ingress.Class != nil {
+			details = append(details, "class: "+*ingress.Class)
+		}
+		if ingress.Name != "" {
+			details = append(details, "name: "+ingress.Name)
+		}
+		if ingress.ServiceType != "" {
+			details = append(details, "serviceType: "+string(ingress.ServiceType))
+		}
+		return withDetails("HTTP01 Ingress", details)
+
+	case solver.HTTP01 != nil && solver.HTTP01.GatewayHTTPRoute != nil:
+		route := solver.HTTP01.GatewayHTTPRoute
+		var parents []string
+		for _, ref := range route.ParentRefs {
+			parent := string(ref.Name)
+			if ref.Namespace != nil {
+				parent = string(*ref.Namespace) + "/" + parent
+			}
+			parents = append(parents, parent)
+		}
+		var details []string
+		if len(parents) > 0 {
+			details = append(details, "parentRefs: "+strings.Join(parents, ", "))
+		}
+		if route.ServiceType != "" {
+			details = append(details, "serviceType: "+string(route.ServiceType))
+		}
+		return withDetails("HTTP01 Gateway HTTPRoute", details)
+
+	case solver.HTTP01 != nil:
+		return "HTTP01"
+
+	case solver.DNS01 != nil:
+		dns01 := solver.DNS01
+		var details []string
+		if dns01.CNAMEStrategy != "" {
+			details = append(details, "cnameStrategy: "+string(dns01.CNAMEStrategy))
+		}
+		switch {
+		case dns01.Akamai != nil:
+			return withDetails("DNS01 akamai", details)
+		case dns01.CloudDNS != nil:
+			return withDetails("DNS01 cloudDNS", append(details, "project: "+dns01.CloudDNS.Project))
+		case dns01.Cloudflare != nil:
+			return withDetails("DNS01 cloudflare", details)
+		case dns01.Route53 != nil:
+			if dns01.Route53.HostedZoneID != "" {
+				details = append(details, "hostedZoneID: "+dns01.Route53.HostedZoneID)
+			}
+			return withDetails("DNS01 route53", details)
+		case dns01.AzureDNS != nil:
+			return withDetails("DNS01 azureDNS", append(details, "resourceGroupName: "+dns01.AzureDNS.ResourceGroupName))
+		case dns01.DigitalOcean != nil:
+			return withDetails("DNS01 digitalocean", details)
+		case dns01.AcmeDNS != nil:
+			return withDetails("DNS01 acmeDNS", append(details, "host: "+dns01.AcmeDNS.Host))
+		case dns01.RFC2136 != nil:
+			return withDetails("DNS01 rfc2136", append(details, "nameserver: "+dns01.RFC2136.Nameserver))
+		case dns01.Webhook != nil:
+			return withDetails("DNS01 webhook", append(details,
+				"groupName: "+dns01.Webhook.GroupName, "solverName: "+dns01.Webhook.SolverName))
+		default:
+			return withDetails("DNS01", details)
+		}
+
+	default:
+		return "<unknown>"
+	}
+}
+
+func withDetails(name string, details []string) string {
+	if len(details) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, strings.Join(details, ", "))
+}
+
+// solverSelectorDescription returns a short description of selector, or an
+// empty string if selector is not set, in which case the solver matches all
+// identifiers.
+func solverSelectorDescription(selector *cmacme.CertificateDNSNameSelector) string {
+	if selector == nil {
+		return ""
+	}
+
+	var parts []string
+	if len(selector.DNSNames) > 0 {
+		parts = append(parts, "dnsNames: "+strings.Join(selector.DNSNames, ", "))
+	}
+	if len(selector.DNSZones) > 0 {
+		parts = append(parts, "dnsZones: "+strings.Join(selector.DNSZones, ", "))
+	}
+	if len(selector.MatchLabels) > 0 {
+		labels := make([]string, 0, len(selector.MatchLabels))
+		for k, v := range selector.MatchLabels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		parts = append(parts, "matchLabels: "+strings.Join(labels, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// String returns the information about the status of a Challenge as a string
+// to be printed as output
+func (status *ChallengeStatus) String() string {
+	output := ""
+	output += fmt.Sprintf("Name: %s\n", status.Name)
+	output += fmt.Sprintf("Namespace: %s\n", status.Namespace)
+	output += fmt.Sprintf("Created at: %s\n", status.CreationTime.Time.Format(time.RFC3339))
+	output += fmt.Sprintf("Issuer: %s\n", status.Issuer)
+
+	switch order := status.Order; {
+	case order == nil:
+		output += "Order: <none>\n"
+	case order.Error != "":
+		output += fmt.Sprintf("Order: %s\n", order.Error)
+	default:
+		output += fmt.Sprintf("Order: %s, State: %s\n", order.Name, order.State)
+	}
+
+	output += fmt.Sprintf("DNS Name: %s, Wildcard: %t\n", status.DNSName, status.Wildcard)
+	output += fmt.Sprintf("Type: %s\n", status.Type)
+	output += fmt.Sprintf("URL: %s\n", status.URL)
+	output += fmt.Sprintf("Authorization URL: %s\n", status.AuthorizationURL)
+	output += fmt.Sprintf("Token: %s\n", status.Token)
+	output += fmt.Sprintf("Key: %s\n", status.Key)
+	output += fmt.Sprintf("Solver: %s\n", status.Solver)
+	if status.SolverSelector != "" {
+		output += fmt.Sprintf("Solver Selector: %s\n", status.SolverSelector)
+	}
+
+	output += fmt.Sprintf("State: %s, Processing: %t, Presented: %t\n", status.State, status.Processing, status.Presented)
+	if status.PresentedAt != nil {
+		output += fmt.Sprintf("Presented at: %s\n", status.PresentedAt.Time.Format(time.RFC3339))
+	}
+	if status.Reason != "" {
+		output += fmt.Sprintf("Reason: %s\n", status.Reason)
+	}
+
+	output += util.EventsToString(status.events, 0)
+
+	return output
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/reference"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/convert"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	// Dedicated scheme used by the ctl tool that has the internal cert-manager types,
+	// and their conversion functions registered
+	scheme = convert.Scheme
+)
+
+// Options is a struct to support status issuer and status clusterissuer commands
+type Options struct {
+	// Kind of the issuer to get the status of, either Issuer or ClusterIssuer
+	Kind string
+
+	// ClusterResourceNamespace is the namespace in which the Secrets referenced
+	// by a ClusterIssuer are looked up. It has to match the
+	// --cluster-resource-namespace flag of the cert-manager controller.
+	ClusterResourceNamespace string
+
+	// PrintFlags holds the flags used to print the status in a structured
+	// format. If no output format is set, the human readable output is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// Data is a struct containing the information to build an IssuerStatus
+type Data struct {
+	Issuer cmapi.GenericIssuer
+	Kind   string
+	Events *corev1.EventList
+	// Secrets referenced by the issuer, keyed by name. Secrets that could not
+	// be found are missing from the map, and have an entry in SecretErrors.
+	Secrets      map[string]*corev1.Secret
+	SecretErrors map[string]error
+	// Certificates referencing the issuer
+	Certificates      []cmapi.Certificate
+	CertificatesError error
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams, kind string) *Options {
+	return &Options{
+		Kind:                     kind,
+		ClusterResourceNamespace: "cert-manager",
+		PrintFlags:               util.NewPrintFlags(),
+		IOStreams:                ioStreams,
+	}
+}
+
+// NewCmdStatusIssuer returns a cobra command for status issuer
+func NewCmdStatusIssuer(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams, cmapi.IssuerKind)
+
+	cmd := &cobra.Command{
+		Use:     "issuer",
+		Aliases: []string{"issuers"},
+		Short:   "Get details about the current status of a cert-manager Issuer resource",
+		Long: templates.LongDesc(`
+Get details about the current status of a cert-manager Issuer resource, including the Secrets it references and the Certificates using it.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Query status of Issuer with name 'my-issuer' in namespace 'my-namespace'
+{{.BuildName}} status issuer my-issuer --namespace my-namespace
+
+# Print the status of Issuer with name 'my-issuer' as YAML
+{{.BuildName}} status issuer my-issuer -o yaml
+`)),
+		ValidArgsFunction: factory.ValidArgsListIssuers(&o.Factory),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(cmd)
+
+	return cmd
+}
+
+// NewCmdStatusClusterIssuer returns a cobra command for status clusterissuer
+func NewCmdStatusClusterIssuer(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams, cmapi.ClusterIssuerKind)
+
+	cmd := &cobra.Command{
+		Use:     "clusterissuer",
+		Aliases: []string{"clusterissuers"},
+		Short:   "Get details about the current status of a cert-manager ClusterIssuer resource",
+		Long: templates.LongDesc(`
+Get details about the current status of a cert-manager ClusterIssuer resource, including the Secrets it references and the Certificates using it.
+
+The Secrets referenced by a ClusterIssuer are looked up in the cluster resource namespace of cert-manager, set with --cluster-resource-namespace.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Query status of ClusterIssuer with name 'letsencrypt'
+{{.BuildName}} status clusterissuer letsencrypt
+
+# Query status of ClusterIssuer with name 'letsencrypt', when cert-manager is installed in namespace 'security'
+{{.BuildName}} status clusterissuer letsencrypt --cluster-resource-namespace security
+`)),
+		ValidArgsFunction: factory.ValidArgsListClusterIssuers(&o.Factory),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", o.ClusterResourceNamespace, "Namespace in which the Secrets referenced by ClusterIssuers are stored, as configured on the cert-manager controller.")
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("the name of the %s has to be provided as argument", o.Kind)
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("only one argument can be passed in: the name of the %s", o.Kind)
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run executes status issuer or status clusterissuer command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	data, err := o.GetResources(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	status := StatusFromResources(data)
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		return util.PrintObject(printer, status.Output(), o.Out)
+	}
+
+	fmt.Fprint(o.Out, status.String())
+
+	return nil
+}
+
+// GetResources collects the issuer with the given name and its related
+// resources in a Data struct and returns it.
+// Returns error if the issuer cannot be found or its events cannot be listed.
+// Errors when finding referenced Secrets or Certificates are stored in Data.
+func (o *Options) GetResources(ctx context.Context, name string) (*Data, error) {
+	var (
+		issuer    cmapi.GenericIssuer
+		err       error
+		secretsNS string
+	)
+	if o.Kind == cmapi.ClusterIssuerKind {
+		issuer, err = o.CMClient.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
+		secretsNS = o.ClusterResourceNamespace
+	} else {
+		issuer, err = o.CMClient.CertmanagerV1().Issuers(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+		secretsNS = o.Namespace
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error when getting %s resource: %v", o.Kind, err)
+	}
+
+	issuerRef, err := reference.GetReference(scheme, issuer)
+	if err != nil {
+		return nil, err
+	}
+	// If no events found, events would be nil and handled down the line in DescribeEvents
+	events, err := o.KubeClient.CoreV1().Events(issuer.GetNamespace()).SearchWithContext(ctx, scheme, issuerRef)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &Data{
+		Issuer:       issuer,
+		Kind:         o.Kind,
+		Events:       events,
+		Secrets:      map[string]*corev1.Secret{},
+		SecretErrors: map[string]error{},
+	}
+
+	for _, ref := range secretRefsForIssuer(issuer.GetSpec()) {
+		if _, ok := data.Secrets[ref.Name]; ok {
+			continue
+		}
+		if _, ok := data.SecretErrors[ref.Name]; ok {
+			continue
+		}
+		secret, err := o.KubeClient.CoreV1().Secrets(secretsNS).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			data.SecretErrors[ref.Name] = err
+			continue
+		}
+		data.Secrets[ref.Name] = secret
+	}
+
+	// Certificates can only reference Issuers in their own namespace, but
+	// ClusterIssuers from any namespace
+	crtsNS := o.Namespace
+	if o.Kind == cmapi.ClusterIssuerKind {
+		crtsNS = metav1.NamespaceAll
+	}
+	crts, err := o.CMClient.CertmanagerV1().Certificates(crtsNS).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		data.CertificatesError = fmt.Errorf("error when listing Certificate resources: %w", err)
+	} else {
+		for _, crt := range crts.Items {
+			if certificateReferencesIssuer(&crt, o.Kind, name) /* #nosec G601 -- Pointer does not outlive function scope */ {
+				data.Certificates = append(data.Certificates, crt)
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// certificateReferencesIssuer returns true if the issuerRef of crt points to
+// the cert-manager issuer of the given kind and name.
+func certificateReferencesIssuer(crt *cmapi.Certificate, kind, name string) bool {
+	ref := crt.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != "cert-manager.io" {
+		return false
+	}
+	refKind := ref.Kind
+	if refKind == "" {
+		refKind = cmapi.IssuerKind
+	}
+	return refKind == kind && ref.Name == name
+}
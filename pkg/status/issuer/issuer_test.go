@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestSecretRefsForIssuer(t *testing.T) {
+	tests := map[string]struct {
+		issuer  *cmapi.Issuer
+		expRefs []secretRef
+	}{
+		"SelfSigned issuer references no Secrets": {
+			issuer:  gen.Issuer("test", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+			expRefs: nil,
+		},
+		"CA issuer references its key pair": {
+			issuer: gen.Issuer("test", gen.SetIssuerCASecretName("ca-key-pair")),
+			expRefs: []secretRef{
+				{Name: "ca-key-pair", Purpose: "CA key pair", Keys: []string{"tls.crt", "tls.key"}},
+			},
+		},
+		"ACME issuer references account key, EAB key and DNS01 credentials": {
+			issuer: gen.Issuer("test",
+				gen.SetIssuerACMEPrivKeyRef("account-key"),
+				gen.SetIssuerACMEEAB("key-id", "eab-key"),
+				gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{
+					{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}},
+					{DNS01: &cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+						APIToken: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "cloudflare"}, Key: "api-token"},
+					}}},
+				})),
+			expRefs: []secretRef{
+				{Name: "account-key", Purpose: "ACME account private key", Keys: []string{"tls.key"}},
+				{Name: "eab-key", Purpose: "ACME external account binding key", Keys: []string{"key"}},
+				{Name: "cloudflare", Purpose: "DNS01 Cloudflare API token", Keys: []string{"api-token"}},
+			},
+		},
+		"Vault issuer references token and CA bundle": {
+			issuer: gen.Issuer("test",
+				gen.SetIssuerVaultURL("https://vault.example.com"),
+				gen.SetIssuerVaultTokenAuth("token", "vault-token"),
+				gen.SetIssuerVaultCABundleSecretRef("vault-ca", "", "")),
+			expRefs: []secretRef{
+				{Name: "vault-token", Purpose: "Vault token", Keys: []string{"token"}},
+				{Name: "vault-ca", Purpose: "Vault CA bundle", Keys: []string{"ca.crt"}},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expRefs, secretRefsForIssuer(test.issuer.GetSpec()))
+		})
+	}
+}
+
+func TestStatusFromResources(t *testing.T) {
+	timestamp, err := time.Parse(time.RFC3339, "2020-09-16T09:26:18Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := gen.ClusterIssuer("letsencrypt",
+		gen.SetIssuerACMEURL("https://acme-v02.api.letsencrypt.org/directory"),
+		gen.SetIssuerACMEEmail("admin@example.com"),
+		gen.SetIssuerACMEAccountURL("https://acme-v02.api.letsencrypt.org/acme/acct/1"),
+		gen.SetIssuerACMELastRegisteredEmail("admin@example.com"),
+		gen.SetIssuerACMEPrivKeyRef("account-key"),
+		gen.SetIssuerACMEEAB("key-id", "eab-key"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, Reason: "ACMEAccountRegistered", Message: "The ACME account was registered with the ACME server"}))
+	issuer.CreationTimestamp = metav1.Time{Time: timestamp}
+
+	data := &Data{
+		Issuer: issuer,
+		Kind:   cmapi.ClusterIssuerKind,
+		Secrets: map[string]*corev1.Secret{
+			"account-key": {ObjectMeta: metav1.ObjectMeta{Name: "account-key"}, Data: map[string][]byte{"tls.crt": []byte("foo")}},
+		},
+		SecretErrors: map[string]error{
+			"eab-key": errors.New(`secrets "eab-key" not found`),
+		},
+		Certificates: []cmapi.Certificate{
+			*gen.Certificate("crt-1", gen.SetCertificateNamespace("ns1"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"})),
+			*gen.Certificate("crt-2", gen.SetCertificateNamespace("ns2")),
+		},
+	}
+
+	status := StatusFromResources(data)
+
+	assert.Equal(t, &IssuerStatus{
+		Name:         "letsencrypt",
+		Kind:         cmapi.ClusterIssuerKind,
+		Type:         "ACME",
+		CreationTime: metav1.Time{Time: timestamp},
+		Conditions:   issuer.Status.Conditions,
+		ACMEStatus: &ACMEStatus{
+			Server:              "https://acme-v02.api.letsencrypt.org/directory",
+			Email:               "admin@example.com",
+			URI:                 "https://acme-v02.api.letsencrypt.org/acme/acct/1",
+			LastRegisteredEmail: "admin@example.com",
+		},
+		SecretStatuses: []*SecretStatus{
+			{Name: "account-key", Purpose: "ACME account private key", Keys: []string{"tls.key"}, Found: true, MissingKeys: []string{"tls.key"}},
+			{Name: "eab-key", Purpose: "ACME external account binding key", Keys: []string{"key"}, Error: `secrets "eab-key" not found`},
+		},
+		CertificateStatuses: []*CertificateStatus{
+			{Namespace: "ns1", Name: "crt-1", Ready: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"},
+			{Namespace: "ns2", Name: "crt-2", Ready: cmmeta.ConditionUnknown},
+		},
+	}, status)
+
+	assert.Equal(t, `Name: letsencrypt
+Kind: ClusterIssuer
+Type: ACME
+Created at: 2020-09-16T09:26:18Z
+Conditions:
+  Ready: True, Reason: ACMEAccountRegistered, Message: The ACME account was registered with the ACME server
+ACME:
+  Server: https://acme-v02.api.letsencrypt.org/directory
+  Email: admin@example.com
+  Account URI: https://acme-v02.api.letsencrypt.org/acme/acct/1
+  Last Registered Email: admin@example.com
+Secrets:
+- Name: account-key
+  Purpose: ACME account private key
+  Keys: tls.key
+  Found: true, Missing Keys: tls.key
+- Name: eab-key
+  Purpose: ACME external account binding key
+  Keys: key
+  Found: false, Error: secrets "eab-key" not found
+Events:  <none>
+Certificates:
+- ns1/crt-1, Ready: True, Message: Certificate is up to date and has not expired
+- ns2/crt-2, Ready: Unknown
+`, status.String())
+}
+
+func TestCertificateReferencesIssuer(t *testing.T) {
+	tests := map[string]struct {
+		ref    cmmeta.IssuerReference
+		kind   string
+		expRef bool
+	}{
+		"Issuer is the default kind": {
+			ref:    cmmeta.IssuerReference{Name: "ca"},
+			kind:   cmapi.IssuerKind,
+			expRef: true,
+		},
+		"ClusterIssuer with the same name is a different issuer": {
+			ref:    cmmeta.IssuerReference{Name: "ca", Kind: cmapi.ClusterIssuerKind},
+			kind:   cmapi.IssuerKind,
+			expRef: false,
+		},
+		"External issuer with the same kind and name is a different issuer": {
+			ref:    cmmeta.IssuerReference{Name: "ca", Kind: cmapi.ClusterIssuerKind, Group: "example.com"},
+			kind:   cmapi.ClusterIssuerKind,
+			expRef: false,
+		},
+		"ClusterIssuer with cert-manager group": {
+			ref:    cmmeta.IssuerReference{Name: "ca", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"},
+			kind:   cmapi.ClusterIssuerKind,
+			expRef: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test", gen.SetCertificateIssuer(test.ref))
+			assert.Equal(t, test.expRef, certificateReferencesIssuer(crt, test.kind, "ca"))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args   []string
+		expErr string
+	}{
+		"no name": {
+			expErr: "the name of the Issuer has to be provided as argument",
+		},
+		"too many names": {
+			args:   []string{"a", "b"},
+			expErr: "only one argument can be passed in: the name of the Issuer",
+		},
+		"single name": {
+			args: []string{"a"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard(), cmapi.IssuerKind)
+			err := o.Validate(test.args)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
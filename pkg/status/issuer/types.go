@@ -0,0 +1,421 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// IssuerStatusKind is the kind of the structured output of the status issuer
+// and status clusterissuer commands.
+const IssuerStatusKind = "IssuerStatus"
+
+type IssuerStatus struct {
+	// Name of the Issuer/ClusterIssuer resource
+	Name string
+	// Namespace of the Issuer resource, empty for a ClusterIssuer
+	Namespace string
+	// Kind of the resource, can be Issuer or ClusterIssuer
+	Kind string
+	// Type of the issuer, e.g. ACME, CA or Vault
+	Type string
+	// Creation Time of Issuer/ClusterIssuer resource
+	CreationTime metav1.Time
+	// Conditions of Issuer/ClusterIssuer resource
+	Conditions []cmapi.IssuerCondition
+	// Events of Issuer/ClusterIssuer resource
+	Events *corev1.EventList
+
+	ACMEStatus *ACMEStatus
+
+	SecretStatuses []*SecretStatus
+
+	// If CertificatesError is not nil, there was a problem listing the
+	// Certificates referencing the issuer, so CertificateStatuses is unusable
+	CertificatesError   error
+	CertificateStatuses []*CertificateStatus
+}
+
+// ACMEStatus is the status of the ACME account of an ACME issuer.
+type ACMEStatus struct {
+	// Server is the URL of the ACME server directory
+	Server string `json:"server"`
+	// Email configured on the issuer
+	Email string `json:"email,omitempty"`
+	// URI of the registered ACME account
+	URI string `json:"uri,omitempty"`
+	// LastRegisteredEmail is the email the ACME account was last registered with
+	LastRegisteredEmail string `json:"lastRegisteredEmail,omitempty"`
+}
+
+// SecretStatus is the status of a Secret referenced by an issuer.
+type SecretStatus struct {
+	// Name of the Secret resource
+	Name string `json:"name"`
+	// Purpose the Secret is referenced for, e.g. "ACME account private key"
+	Purpose string `json:"purpose"`
+	// Keys the issuer reads from the Secret
+	Keys []string `json:"keys,omitempty"`
+	// Found is true if the Secret exists
+	Found bool `json:"found"`
+	// MissingKeys are the Keys that are not set in the Secret
+	MissingKeys []string `json:"missingKeys,omitempty"`
+	// Error is set if the Secret could not be found
+	Error string `json:"error,omitempty"`
+}
+
+// CertificateStatus is the readiness of a Certificate referencing an issuer.
+type CertificateStatus struct {
+	// Namespace of the Certificate resource
+	Namespace string `json:"namespace"`
+	// Name of the Certificate resource
+	Name string `json:"name"`
+	// Status of the Ready condition of the Certificate resource
+	Ready cmmeta.ConditionStatus `json:"ready"`
+	// Message of the Ready condition of the Certificate resource
+	Message string `json:"message,omitempty"`
+}
+
+// IssuerStatusOutput is the structured representation of an IssuerStatus,
+// printed by the status issuer and status clusterissuer commands when
+// --output is used.
+type IssuerStatusOutput struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Name              string                  `json:"name"`
+	Namespace         string                  `json:"namespace,omitempty"`
+	Kind              string                  `json:"issuerKind"`
+	Type              string                  `json:"type"`
+	CreationTime      metav1.Time             `json:"creationTime"`
+	Conditions        []cmapi.IssuerCondition `json:"conditions,omitempty"`
+	Events            []util.Event            `json:"events,omitempty"`
+	ACME              *ACMEStatus             `json:"acme,omitempty"`
+	Secrets           []*SecretStatus         `json:"secrets,omitempty"`
+	CertificatesError string                  `json:"certificatesError,omitempty"`
+	Certificates      []*CertificateStatus    `json:"certificates,omitempty"`
+}
+
+// secretRef is a reference from an issuer to a Secret.
+type secretRef struct {
+	Name    string
+	Purpose string
+	Keys    []string
+}
+
+// StatusFromResources takes in a Data struct and returns an IssuerStatus built
+// using the information in data.
+func StatusFromResources(data *Data) *IssuerStatus {
+	issuer := data.Issuer
+	spec := issuer.GetSpec()
+
+	status := &IssuerStatus{
+		Name:         issuer.GetName(),
+		Namespace:    issuer.GetNamespace(),
+		Kind:         data.Kind,
+		Type:         issuerType(spec),
+		CreationTime: issuer.GetObjectMeta().CreationTimestamp,
+		Conditions:   issuer.GetStatus().Conditions,
+		Events:       data.Events,
+	}
+
+	if spec.ACME != nil {
+		status.ACMEStatus = &ACMEStatus{Server: spec.ACME.Server, Email: spec.ACME.Email}
+		if acmeStatus := issuer.GetStatus().ACME; acmeStatus != nil {
+			status.ACMEStatus.URI = acmeStatus.URI
+			status.ACMEStatus.LastRegisteredEmail = acmeStatus.LastRegisteredEmail
+		}
+	}
+
+	for _, ref := range secretRefsForIssuer(spec) {
+		secretStatus := &SecretStatus{Name: ref.Name, Purpose: ref.Purpose, Keys: ref.Keys}
+		if secret, ok := data.Secrets[ref.Name]; ok {
+			secretStatus.Found = true
+			for _, key := range ref.Keys {
+				if len(secret.Data[key]) == 0 {
+					secretStatus.MissingKeys = append(secretStatus.MissingKeys, key)
+				}
+			}
+		} else if err, ok := data.SecretErrors[ref.Name]; ok {
+			secretStatus.Error = util.ErrorString(err)
+		}
+		status.SecretStatuses = append(status.SecretStatuses, secretStatus)
+	}
+
+	status.CertificatesError = data.CertificatesError
+	for i := range data.Certificates {
+		crt := &data.Certificates[i]
+		crtStatus := &CertificateStatus{Namespace: crt.Namespace, Name: crt.Name, Ready: cmmeta.ConditionUnknown}
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
+			crtStatus.Ready = cond.Status
+			crtStatus.Message = cond.Message
+		}
+		status.CertificateStatuses = append(status.CertificateStatuses, crtStatus)
+	}
+
+	return status
+}
+
+// issuerType returns the name of the type of issuer configured in spec.
+func issuerType(spec *cmapi.IssuerSpec) string {
+	switch {
+	case spec.ACME != nil:
+		return "ACME"
+	case spec.CA != nil:
+		return "CA"
+	case spec.Vault != nil:
+		return "Vault"
+	case spec.SelfSigned != nil:
+		return "SelfSigned"
+	case spec.Venafi != nil:
+		return "Venafi"
+	default:
+		return "<unknown>"
+	}
+}
+
+// secretRefsForIssuer returns all Secrets referenced in spec, together with
+// the keys the issuer reads from them. Keys are left empty where they depend
+// on the way the issuer is configured.
+func secretRefsForIssuer(spec *cmapi.IssuerSpec) []secretRef {
+	var refs []secretRef
+	add := func(name, purpose string, keys ...string) {
+		if name == "" {
+			return
+		}
+		refs = append(refs, secretRef{Name: name, Purpose: purpose, Keys: keys})
+	}
+	addSelector := func(sel *cmmeta.SecretKeySelector, purpose, defaultKey string) {
+		if sel == nil {
+			return
+		}
+		key := sel.Key
+		if key == "" {
+			key = defaultKey
+		}
+		if key == "" {
+			add(sel.Name, purpose)
+			return
+		}
+		add(sel.Name, purpose, key)
+	}
+
+	switch {
+	case spec.ACME != nil:
+		acme := spec.ACME
+		addSelector(&acme.PrivateKey, "ACME account private key", corev1.TLSPrivateKeyKey)
+		if acme.ExternalAccountBinding != nil {
+			addSelector(&acme.ExternalAccountBinding.Key, "ACME external account binding key", "")
+		}
+		for _, solver := range acme.Solvers {
+			if solver.DNS01 != nil {
+				refs = append(refs, secretRefsForDNS01Solver(solver.DNS01)...)
+			}
+		}
+
+	case spec.CA != nil:
+		add(spec.CA.SecretName, "CA key pair", corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+
+	case spec.Vault != nil:
+		vault := spec.Vault
+		addSelector(vault.Auth.TokenSecretRef, "Vault token", "")
+		if vault.Auth.AppRole != nil {
+			addSelector(&vault.Auth.AppRole.SecretRef, "Vault AppRole secret ID", "")
+		}
+		if vault.Auth.Kubernetes != nil {
+			addSelector(&vault.Auth.Kubernetes.SecretRef, "Vault Kubernetes service account token", "token")
+		}
+		if vault.Auth.ClientCertificate != nil {
+			add(vault.Auth.ClientCertificate.SecretName, "Vault client certificate auth", corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		}
+		addSelector(vault.CABundleSecretRef, "Vault CA bundle", "ca.crt")
+		addSelector(vault.ClientCertSecretRef, "Vault mTLS client certificate", corev1.TLSCertKey)
+		addSelector(vault.ClientKeySecretRef, "Vault mTLS client key", corev1.TLSPrivateKeyKey)
+
+	case spec.Venafi != nil:
+		venafi := spec.Venafi
+		if venafi.TPP != nil {
+			add(venafi.TPP.CredentialsRef.Name, "Venafi TPP credentials")
+			addSelector(venafi.TPP.CABundleSecretRef, "Venafi TPP CA bundle", "")
+		}
+		if venafi.Cloud != nil {
+			addSelector(&venafi.Cloud.APITokenSecretRef, "Venafi Cloud API token", "")
+		}
+		if venafi.NGTS != nil {
+			add(venafi.NGTS.CredentialsRef.Name, "Venafi NGTS credentials")
+		}
+	}
+
+	return refs
+}
+
+// secretRefsForDNS01Solver returns the Secrets referenced by the DNS01
+// provider configured in solver.
+func secretRefsForDNS01Solver(solver *cmacme.ACMEChallengeSolverDNS01) []secretRef {
+	var refs []secretRef
+	addSelector := func(sel *cmmeta.SecretKeySelector, purpose string) {
+		if sel == nil || sel.Name == "" {
+			return
+		}
+		var keys []string
+		if sel.Key != "" {
+			keys = []string{sel.Key}
+		}
+		refs = append(refs, secretRef{Name: sel.Name, Purpose: purpose, Keys: keys})
+	}
+
+	switch {
+	case solver.Akamai != nil:
+		addSelector(&solver.Akamai.ClientToken, "DNS01 Akamai client token")
+		addSelector(&solver.Akamai.ClientSecret, "DNS01 Akamai client secret")
+		addSelector(&solver.Akamai.AccessToken, "DNS01 Akamai access token")
+	case solver.CloudDNS != nil:
+		addSelector(solver.CloudDNS.ServiceAccount, "DNS01 Google CloudDNS service account")
+	case solver.Cloudflare != nil:
+		addSelector(solver.Cloudflare.APIKey, "DNS01 Cloudflare API key")
+		addSelector(solver.Cloudflare.APIToken, "DNS01 Cloudflare API token")
+	case solver.Route53 != nil:
+		addSelector(solver.Route53.SecretAccessKeyID, "DNS01 Route53 access key ID")
+		addSelector(&solver.Route53.SecretAccessKey, "DNS01 Route53 secret access key")
+	case solver.AzureDNS != nil:
+		addSelector(solver.AzureDNS.ClientSecret, "DNS01 AzureDNS client secret")
+	case solver.DigitalOcean != nil:
+		addSelector(&solver.DigitalOcean.Token, "DNS01 DigitalOcean token")
+	case solver.AcmeDNS != nil:
+		addSelector(&solver.AcmeDNS.AccountSecret, "DNS01 ACMEDNS account")
+	case solver.RFC2136 != nil:
+		addSelector(&solver.RFC2136.TSIGSecret, "DNS01 RFC2136 TSIG secret")
+	}
+
+	return refs
+}
+
+// String returns the information about the status of an Issuer/ClusterIssuer
+// as a string to be printed as output
+func (status *IssuerStatus) String() string {
+	output := ""
+	output += fmt.Sprintf("Name: %s\n", status.Name)
+	if status.Namespace != "" {
+		output += fmt.Sprintf("Namespace: %s\n", status.Namespace)
+	}
+	output += fmt.Sprintf("Kind: %s\n", status.Kind)
+	output += fmt.Sprintf("Type: %s\n", status.Type)
+	output += fmt.Sprintf("Created at: %s\n", status.CreationTime.Time.Format(time.RFC3339))
+
+	conditionMsg := ""
+	for _, con := range status.Conditions {
+		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, con.Status, con.Reason, con.Message)
+	}
+	if conditionMsg == "" {
+		conditionMsg = "  No Conditions set\n"
+	}
+	output += fmt.Sprintf("Conditions:\n%s", conditionMsg)
+
+	if status.ACMEStatus != nil {
+		output += status.ACMEStatus.String()
+	}
+
+	if len(status.SecretStatuses) == 0 {
+		output += "Secrets: <none>\n"
+	} else {
+		output += "Secrets:\n"
+		for _, secretStatus := range status.SecretStatuses {
+			output += secretStatus.String()
+		}
+	}
+
+	output += util.EventsToString(status.Events, 0)
+
+	switch {
+	case status.CertificatesError != nil:
+		output += status.CertificatesError.Error() + "\n"
+	case len(status.CertificateStatuses) == 0:
+		output += "Certificates: <none>\n"
+	default:
+		output += "Certificates:\n"
+		for _, crtStatus := range status.CertificateStatuses {
+			output += fmt.Sprintf("- %s/%s, Ready: %s", crtStatus.Namespace, crtStatus.Name, crtStatus.Ready)
+			if crtStatus.Message != "" {
+				output += fmt.Sprintf(", Message: %s", crtStatus.Message)
+			}
+			output += "\n"
+		}
+	}
+
+	return output
+}
+
+// String returns the information about the ACME account of an issuer as a
+// string to be printed as output
+func (acmeStatus *ACMEStatus) String() string {
+	output := "ACME:\n"
+	output += fmt.Sprintf("  Server: %s\n", acmeStatus.Server)
+	output += fmt.Sprintf("  Email: %s\n", util.FormatOptionalString(acmeStatus.Email))
+	output += fmt.Sprintf("  Account URI: %s\n", util.FormatOptionalString(acmeStatus.URI))
+	output += fmt.Sprintf("  Last Registered Email: %s\n", util.FormatOptionalString(acmeStatus.LastRegisteredEmail))
+	return output
+}
+
+// String returns the information about a Secret referenced by an issuer as a
+// string to be printed as output
+func (secretStatus *SecretStatus) String() string {
+	output := fmt.Sprintf("- Name: %s\n", secretStatus.Name)
+	output += fmt.Sprintf("  Purpose: %s\n", secretStatus.Purpose)
+	if len(secretStatus.Keys) > 0 {
+		output += fmt.Sprintf("  Keys: %s\n", strings.Join(secretStatus.Keys, ", "))
+	}
+	switch {
+	case secretStatus.Error != "":
+		output += fmt.Sprintf("  Found: false, Error: %s\n", secretStatus.Error)
+	case len(secretStatus.MissingKeys) > 0:
+		output += fmt.Sprintf("  Found: true, Missing Keys: %s\n", strings.Join(secretStatus.MissingKeys, ", "))
+	default:
+		output += "  Found: true\n"
+	}
+	return output
+}
+
+// Output returns the structured representation of status.
+func (status *IssuerStatus) Output() *IssuerStatusOutput {
+	return &IssuerStatusOutput{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       IssuerStatusKind,
+		},
+		Name:              status.Name,
+		Namespace:         status.Namespace,
+		Kind:              status.Kind,
+		Type:              status.Type,
+		CreationTime:      status.CreationTime,
+		Conditions:        status.Conditions,
+		Events:            util.EventsFromList(status.Events),
+		ACME:              status.ACMEStatus,
+		Secrets:           status.SecretStatuses,
+		CertificatesError: util.ErrorString(status.CertificatesError),
+		Certificates:      status.CertificateStatuses,
+	}
+}
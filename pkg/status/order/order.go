@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/reference"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/convert"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+var (
+	// Dedicated scheme used by the ctl tool that has the internal cert-manager types,
+	// and their conversion functions registered
+	scheme = convert.Scheme
+)
+
+// Options is a struct to support status order command
+type Options struct {
+	// PrintFlags holds the flags used to print the status in a structured
+	// format. If no output format is set, the human readable output is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// Data is a struct containing the information to build an OrderStatus
+type Data struct {
+	Order  *cmacme.Order
+	Events *corev1.EventList
+	// Challenges owned by the Order
+	Challenges      []*cmacme.Challenge
+	ChallengesError error
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		PrintFlags: util.NewPrintFlags(),
+		IOStreams:  ioStreams,
+	}
+}
+
+// NewCmdStatusOrder returns a cobra command for status order
+func NewCmdStatusOrder(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "order",
+		Aliases: []string{"orders"},
+		Short:   "Get details about the current status of a cert-manager ACME Order resource",
+		Long: templates.LongDesc(`
+Get details about the current status of a cert-manager ACME Order resource, including its authorizations and the Challenges created to solve them.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Query status of Order with name 'my-order' in namespace 'my-namespace'
+{{.BuildName}} status order my-order --namespace my-namespace
+
+# Print the identifiers of the authorizations of Order with name 'my-order'
+{{.BuildName}} status order my-order -o jsonpath='{.authorizations[*].identifier}'
+`)),
+		ValidArgsFunction: factory.ValidArgsListOrders(&o.Factory),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the Order has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the Order")
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run executes status order command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	data, err := o.GetResources(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	status := StatusFromResources(data)
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		return util.PrintObject(printer, status, o.Out)
+	}
+
+	fmt.Fprint(o.Out, status.String())
+
+	return nil
+}
+
+// GetResources collects the Order with the given name and its Challenges in a
+// Data struct and returns it.
+// Returns error if the Order cannot be found or its events cannot be listed.
+// Errors when finding the Challenges are stored in Data.
+func (o *Options) GetResources(ctx context.Context, name string) (*Data, error) {
+	order, err := o.CMClient.AcmeV1().Orders(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when getting Order resource: %v", err)
+	}
+
+	orderRef, err := reference.GetReference(scheme, order)
+	if err != nil {
+		return nil, err
+	}
+	// If no events found, events would be nil and handled down the line in DescribeEvents
+	events, err := o.KubeClient.CoreV1().Events(order.Namespace).SearchWithContext(ctx, scheme, orderRef)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &Data{Order: order, Events: events}
+
+	challenges, err := o.CMClient.AcmeV1().Challenges(order.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		data.ChallengesError = fmt.Errorf("error when listing Challenge resources: %w", err)
+		return data, nil
+	}
+	for _, challenge := range challenges.Items {
+		if predicate.ResourceOwnedBy[*cmacme.Challenge](order)(&challenge) /* #nosec G601 -- Pointer does not outlive function scope */ {
+			data.Challenges = append(data.Challenges, challenge.DeepCopy())
+		}
+	}
+
+	return data, nil
+}
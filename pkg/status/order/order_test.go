@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package order
+
+import (
+	"testing"
+	"time"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestStatusFromResources(t *testing.T) {
+	timestamp := metav1.NewTime(time.Date(2020, 9, 16, 9, 26, 18, 0, time.UTC))
+
+	order := gen.Order("test-order",
+		gen.SetOrderNamespace("ns1"),
+		gen.SetOrderIssuer(cmmeta.IssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"}),
+		gen.SetOrderDNSNames("example.com", "*.example.com"),
+		gen.SetOrderOwnerReference(metav1.OwnerReference{Kind: "CertificateRequest", Name: "test-crt-1", Controller: ptr.To(true)}),
+		gen.SetOrderStatus(cmacme.OrderStatus{
+			URL:         "https://acme.example.com/order/1",
+			FinalizeURL: "https://acme.example.com/order/1/finalize",
+			State:       cmacme.Pending,
+			Authorizations: []cmacme.ACMEAuthorization{{
+				URL:          "https://acme.example.com/authz/1",
+				Identifier:   "example.com",
+				Wildcard:     ptr.To(true),
+				InitialState: cmacme.Pending,
+				Challenges: []cmacme.ACMEChallenge{
+					{URL: "https://acme.example.com/chall/1", Type: "dns-01"},
+				},
+			}},
+		}),
+	)
+	order.CreationTimestamp = timestamp
+
+	challenge := gen.Challenge("test-order-1",
+		gen.SetChallengeDNSName("example.com"),
+		gen.SetChallengeWildcard(true),
+		gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+		gen.SetChallengeSolverDNS01(cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{}}),
+		gen.SetChallengeState(cmacme.Pending),
+		gen.SetChallengeProcessing(true),
+		gen.SetChallengeReason("Waiting for DNS-01 challenge propagation"),
+	)
+
+	status := StatusFromResources(&Data{
+		Order:      order,
+		Challenges: []*cmacme.Challenge{challenge},
+	})
+
+	assert.Equal(t, `Name: test-order
+Namespace: ns1
+Created at: 2020-09-16T09:26:18Z
+Issuer: ClusterIssuer/letsencrypt
+CertificateRequest: test-crt-1
+State: pending, Reason: 
+URL: https://acme.example.com/order/1
+Finalize URL: https://acme.example.com/order/1/finalize
+Certificate: <none>
+Identifiers:
+  Common Name: <none>
+  DNS Names: example.com, *.example.com
+  IP Addresses: <none>
+Authorizations:
+- Identifier: example.com, Wildcard: true, Initial State: pending
+  URL: https://acme.example.com/authz/1
+  Offered Challenge: dns-01, URL: https://acme.example.com/chall/1
+Challenges:
+- Name: test-order-1, Type: DNS-01, DNS Name: example.com, Wildcard: true
+  Solver: DNS01 cloudflare
+  State: pending, Processing: true, Presented: false
+  Reason: Waiting for DNS-01 challenge propagation
+Events:  <none>
+`, status.String())
+}
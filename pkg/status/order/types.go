@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package order
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cmctl/v2/pkg/status/challenge"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// OrderStatusKind is the kind of the structured output of the status order
+// command.
+const OrderStatusKind = "OrderStatus"
+
+// OrderStatus is the status of an ACME Order, printed by the status order
+// command.
+// The json tags define the structured output printed when --output is used.
+type OrderStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Name of the Order resource
+	Name string `json:"name"`
+	// Namespace of the Order resource
+	Namespace string `json:"namespace"`
+	// Creation Time of Order resource
+	CreationTime metav1.Time `json:"creationTime"`
+	// Issuer the Order is placed with
+	Issuer string `json:"issuer"`
+	// CertificateRequest owning the Order
+	CertificateRequest string `json:"certificateRequest,omitempty"`
+	// Identifiers requested in the Order
+	CommonName  string   `json:"commonName,omitempty"`
+	DNSNames    []string `json:"dnsNames,omitempty"`
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// URL of the Order on the ACME server
+	URL string `json:"url,omitempty"`
+	// FinalizeURL of the Order on the ACME server
+	FinalizeURL string `json:"finalizeURL,omitempty"`
+	// State of the Order on the ACME server
+	State       cmacme.State `json:"state,omitempty"`
+	Reason      string       `json:"reason,omitempty"`
+	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+	// Certificate issued for the Order, nil if not issued yet
+	Certificate *IssuedCertificate `json:"certificate,omitempty"`
+	// Authorizations that must be completed for the Order
+	Authorizations []cmacme.ACMEAuthorization `json:"authorizations,omitempty"`
+	// Challenges created for the Order
+	ChallengesError string              `json:"challengesError,omitempty"`
+	Challenges      []*ChallengeSummary `json:"challenges,omitempty"`
+	// Events of Order resource
+	Events []util.Event `json:"events,omitempty"`
+
+	events *corev1.EventList
+}
+
+// IssuedCertificate is the decoded certificate issued for an Order.
+type IssuedCertificate struct {
+	Error        string       `json:"error,omitempty"`
+	SerialNumber string       `json:"serialNumber,omitempty"`
+	NotAfter     *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// ChallengeSummary is a summary of the status of a Challenge created for an
+// Order.
+type ChallengeSummary struct {
+	Name       string                   `json:"name"`
+	DNSName    string                   `json:"dnsName"`
+	Wildcard   bool                     `json:"wildcard"`
+	Type       cmacme.ACMEChallengeType `json:"type"`
+	Solver     string                   `json:"solver"`
+	State      cmacme.State             `json:"state,omitempty"`
+	Reason     string                   `json:"reason,omitempty"`
+	Processing bool                     `json:"processing"`
+	Presented  bool                     `json:"presented"`
+}
+
+// StatusFromResources takes in a Data struct and returns an OrderStatus built
+// using the information in data.
+func StatusFromResources(data *Data) *OrderStatus {
+	order := data.Order
+
+	status := &OrderStatus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       OrderStatusKind,
+		},
+		Name:            order.Name,
+		Namespace:       order.Namespace,
+		CreationTime:    order.CreationTimestamp,
+		Issuer:          util.FormatIssuerRef(order.Spec.IssuerRef),
+		CommonName:      order.Spec.CommonName,
+		DNSNames:        order.Spec.DNSNames,
+		IPAddresses:     order.Spec.IPAddresses,
+		URL:             order.Status.URL,
+		FinalizeURL:     order.Status.FinalizeURL,
+		State:           order.Status.State,
+		Reason:          order.Status.Reason,
+		FailureTime:     order.Status.FailureTime,
+		Authorizations:  order.Status.Authorizations,
+		ChallengesError: util.ErrorString(data.ChallengesError),
+		Events:          util.EventsFromList(data.Events),
+		events:          data.Events,
+	}
+
+	if owner := metav1.GetControllerOf(order); owner != nil && owner.Kind == "CertificateRequest" {
+		status.CertificateRequest = owner.Name
+	}
+
+	if len(order.Status.Certificate) > 0 {
+		cert, err := pki.DecodeX509CertificateBytes(order.Status.Certificate)
+		if err != nil {
+			status.Certificate = &IssuedCertificate{Error: fmt.Sprintf("error when decoding the issued certificate: %s", err)}
+		} else {
+			status.Certificate = &IssuedCertificate{
+				SerialNumber: hex.EncodeToString(cert.SerialNumber.Bytes()),
+				NotAfter:     &metav1.Time{Time: cert.NotAfter},
+			}
+		}
+	}
+
+	for _, ch := range data.Challenges {
+		status.Challenges = append(status.Challenges, &ChallengeSummary{
+			Name:       ch.Name,
+			DNSName:    ch.Spec.DNSName,
+			Wildcard:   ch.Spec.Wildcard,
+			Type:       ch.Spec.Type,
+			Solver:     challenge.SolverDescription(ch.Spec.Solver),
+			State:      ch.Status.State,
+			Reason:     ch.Status.Reason,
+			Processing: ch.Status.Processing,
+			Presented:  ch.Status.Presented,
+		})
+	}
+
+	return status
+}
+
+// String returns the information about the status of an Order as a string to
+// be printed as output
+func (status *OrderStatus) String() string {
+	output := ""
+	output += fmt.Sprintf("Name: %s\n", status.Name)
+	output += fmt.Sprintf("Namespace: %s\n", status.Namespace)
+	output += fmt.Sprintf("Created at: %s\n", status.CreationTime.Time.Format(time.RFC3339))
+	output += fmt.Sprintf("Issuer: %s\n", status.Issuer)
+	output += fmt.Sprintf("CertificateRequest: %s\n", util.FormatOptionalString(status.CertificateRequest))
+	output += fmt.Sprintf("State: %s, Reason: %s\n", status.State, status.Reason)
+	if status.FailureTime != nil {
+		output += fmt.Sprintf("Failure Time: %s\n", status.FailureTime.Time.Format(time.RFC3339))
+	}
+	output += fmt.Sprintf("URL: %s\n", util.FormatOptionalString(status.URL))
+	output += fmt.Sprintf("Finalize URL: %s\n", util.FormatOptionalString(status.FinalizeURL))
+
+	switch cert := status.Certificate; {
+	case cert == nil:
+		output += "Certificate: <none>\n"
+	case cert.Error != "":
+		output += fmt.Sprintf("Certificate: %s\n", cert.Error)
+	default:
+		output += fmt.Sprintf("Certificate: Serial Number: %s, Not After: %s\n", cert.SerialNumber, cert.NotAfter.Time.Format(time.RFC3339))
+	}
+
+	output += "Identifiers:\n"
+	output += fmt.Sprintf("  Common Name: %s\n", util.FormatOptionalString(status.CommonName))
+	output += fmt.Sprintf("  DNS Names: %s\n", util.FormatOptionalString(strings.Join(status.DNSNames, ", ")))
+	output += fmt.Sprintf("  IP Addresses: %s\n", util.FormatOptionalString(strings.Join(status.IPAddresses, ", ")))
+
+	if len(status.Authorizations) == 0 {
+		output += "Authorizations: <none>\n"
+	} else {
+		output += "Authorizations:\n"
+		for _, auth := range status.Authorizations {
+			wildcard := false
+			if auth.Wildcard != nil {
+				wildcard = *auth.Wildcard
+			}
+			output += fmt.Sprintf("- Identifier: %s, Wildcard: %t, Initial State: %s\n", auth.Identifier, wildcard, auth.InitialState)
+			output += fmt.Sprintf("  URL: %s\n", auth.URL)
+			for _, ch := range auth.Challenges {
+				output += fmt.Sprintf("  Offered Challenge: %s, URL: %s\n", ch.Type, ch.URL)
+			}
+		}
+	}
+
+	switch {
+	case status.ChallengesError != "":
+		output += fmt.Sprintf("Challenges: %s\n", status.ChallengesError)
+	case len(status.Challenges) == 0:
+		output += "Challenges: <none>\n"
+	default:
+		output += "Challenges:\n"
+		for _, ch := range status.Challenges {
+			output += fmt.Sprintf("- Name: %s, Type: %s, DNS Name: %s, Wildcard: %t\n", ch.Name, ch.Type, ch.DNSName, ch.Wildcard)
+			output += fmt.Sprintf("  Solver: %s\n", ch.Solver)
+			output += fmt.Sprintf("  State: %s, Processing: %t, Presented: %t\n", ch.State, ch.Processing, ch.Presented)
+			if ch.Reason != "" {
+				output += fmt.Sprintf("  Reason: %s\n", ch.Reason)
+			}
+		}
+	}
+
+	output += util.EventsToString(status.events, 0)
+
+	return output
+}
@@ -23,16 +23,25 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/cert-manager/cmctl/v2/pkg/status/certificate"
+	"github.com/cert-manager/cmctl/v2/pkg/status/certificaterequest"
+	"github.com/cert-manager/cmctl/v2/pkg/status/challenge"
+	"github.com/cert-manager/cmctl/v2/pkg/status/issuer"
+	"github.com/cert-manager/cmctl/v2/pkg/status/order"
 )
 
 func NewCmdStatus(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
 	cmds := &cobra.Command{
 		Use:   "status",
 		Short: "Get details on current status of cert-manager resources",
-		Long:  `Get details on current status of cert-manager resources, e.g. Certificate`,
+		Long:  `Get details on current status of cert-manager resources, e.g. Certificate or Issuer`,
 	}
 
 	cmds.AddCommand(certificate.NewCmdStatusCert(setupCtx, ioStreams))
+	cmds.AddCommand(certificaterequest.NewCmdStatusCertificateRequest(setupCtx, ioStreams))
+	cmds.AddCommand(issuer.NewCmdStatusIssuer(setupCtx, ioStreams))
+	cmds.AddCommand(issuer.NewCmdStatusClusterIssuer(setupCtx, ioStreams))
+	cmds.AddCommand(order.NewCmdStatusOrder(setupCtx, ioStreams))
+	cmds.AddCommand(challenge.NewCmdStatusChallenge(setupCtx, ioStreams))
 
 	return cmds
 }
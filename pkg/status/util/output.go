@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/event"
+)
+
+// OutputGroupVersion is the group and version set on the structured output
+// printed by the status commands when --output is used.
+// Fields may be added to this version, but existing fields are never renamed
+// or removed. Incompatible changes require a new version.
+var OutputGroupVersion = schema.GroupVersion{Group: "status.cmctl.cert-manager.io", Version: "v1alpha1"}
+
+// Event is the structured representation of a Kubernetes Event in the output
+// of the status commands.
+type Event struct {
+	Type           string      `json:"type"`
+	Reason         string      `json:"reason"`
+	Message        string      `json:"message"`
+	Source         string      `json:"source,omitempty"`
+	Count          int32       `json:"count,omitempty"`
+	FirstTimestamp metav1.Time `json:"firstTimestamp"`
+	LastTimestamp  metav1.Time `json:"lastTimestamp"`
+}
+
+// NewPrintFlags returns the PrintFlags used by the status commands. No output
+// format is set by default, in which case the commands print their human
+// readable output.
+func NewPrintFlags() *genericclioptions.PrintFlags {
+	outputFormat := ""
+	return &genericclioptions.PrintFlags{
+		OutputFormat:         &outputFormat,
+		JSONYamlPrintFlags:   genericclioptions.NewJSONYamlPrintFlags(),
+		TemplatePrinterFlags: genericclioptions.NewKubeTemplatePrintFlags(),
+	}
+}
+
+// IsStructuredOutput returns true if an output format has been set on
+// printFlags, in which case the structured output should be printed instead
+// of the human readable output.
+func IsStructuredOutput(printFlags *genericclioptions.PrintFlags) bool {
+	return printFlags != nil && printFlags.OutputFormat != nil && len(*printFlags.OutputFormat) > 0
+}
+
+// PrintObject prints obj with printer. obj must be a struct that can be
+// marshalled to JSON and contain the apiVersion and kind fields, so that it
+// can be handled by the json, yaml, jsonpath and go-template printers.
+func PrintObject(printer printers.ResourcePrinter, obj any, w io.Writer) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(data, &u.Object); err != nil {
+		return err
+	}
+
+	return printer.PrintObj(u, w)
+}
+
+// EventsFromList returns the Events in el in the structured output format,
+// sorted by the time they were last seen.
+func EventsFromList(el *corev1.EventList) []Event {
+	if el == nil || len(el.Items) == 0 {
+		return nil
+	}
+
+	items := make([]corev1.Event, len(el.Items))
+	copy(items, el.Items)
+	sort.Sort(event.SortableEvents(items))
+
+	events := make([]Event, 0, len(items))
+	for _, e := range items {
+		events = append(events, Event{
+			Type:           e.Type,
+			Reason:         e.Reason,
+			Message:        strings.TrimSpace(e.Message),
+			Source:         formatEventSource(e.Source),
+			Count:          e.Count,
+			FirstTimestamp: e.FirstTimestamp,
+			LastTimestamp:  e.LastTimestamp,
+		})
+	}
+	return events
+}
+
+// ErrorString returns the message of err, or an empty string if err is nil.
+func ErrorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return strings.TrimSpace(err.Error())
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// OwnedResources finds the CertificateRequests, Orders and Challenges owned by
+// a resource. Each resource type is listed at most once per namespace and
+// indexed by the UID of the controlling owner, so that the resources owned by
+// many resources of the same namespace are found without listing them again.
+// OwnedResources is not safe for concurrent use.
+type OwnedResources struct {
+	cmClient cmclient.Interface
+
+	requests   map[string]*ownerIndex[*cmapi.CertificateRequest]
+	orders     map[string]*ownerIndex[*cmacme.Order]
+	challenges map[string]*ownerIndex[*cmacme.Challenge]
+}
+
+// NewOwnedResources returns an OwnedResources that lists resources with
+// cmClient.
+func NewOwnedResources(cmClient cmclient.Interface) *OwnedResources {
+	return &OwnedResources{
+		cmClient:   cmClient,
+		requests:   map[string]*ownerIndex[*cmapi.CertificateRequest]{},
+		orders:     map[string]*ownerIndex[*cmacme.Order]{},
+		challenges: map[string]*ownerIndex[*cmacme.Challenge]{},
+	}
+}
+
+// CertificateRequests returns the CertificateRequests controlled by crt.
+func (o *OwnedResources) CertificateRequests(ctx context.Context, crt *cmapi.Certificate) ([]*cmapi.CertificateRequest, error) {
+	index, ok := o.requests[crt.Namespace]
+	if !ok {
+		var items []*cmapi.CertificateRequest
+		list, err := o.cmClient.CertmanagerV1().CertificateRequests(crt.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			err = fmt.Errorf("error when listing CertificateRequest resources: %w", err)
+		} else {
+			for i := range list.Items {
+				items = append(items, &list.Items[i])
+			}
+		}
+		index = newOwnerIndex(items, err)
+		o.requests[crt.Namespace] = index
+	}
+	return index.ownedBy(crt)
+}
+
+// Orders returns the Orders controlled by req.
+func (o *OwnedResources) Orders(ctx context.Context, req *cmapi.CertificateRequest) ([]*cmacme.Order, error) {
+	index, ok := o.orders[req.Namespace]
+	if !ok {
+		var items []*cmacme.Order
+		list, err := o.cmClient.AcmeV1().Orders(req.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			err = fmt.Errorf("error when listing Order resources: %w", err)
+		} else {
+			for i := range list.Items {
+				items = append(items, &list.Items[i])
+			}
+		}
+		index = newOwnerIndex(items, err)
+		o.orders[req.Namespace] = index
+	}
+	return index.ownedBy(req)
+}
+
+// Challenges returns the Challenges controlled by order.
+func (o *OwnedResources) Challenges(ctx context.Context, order *cmacme.Order) ([]*cmacme.Challenge, error) {
+	index, ok := o.challenges[order.Namespace]
+	if !ok {
+		var items []*cmacme.Challenge
+		list, err := o.cmClient.AcmeV1().Challenges(order.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			err = fmt.Errorf("error when listing Challenge resources: %w", err)
+		} else {
+			for i := range list.Items {
+				items = append(items, &list.Items[i])
+			}
+		}
+		index = newOwnerIndex(items, err)
+		o.challenges[order.Namespace] = index
+	}
+	return index.ownedBy(order)
+}
+
+// ownerIndex holds the resources of a namespace indexed by the UID of their
+// controlling owner, or the error returned when listing them.
+type ownerIndex[T metav1.Object] struct {
+	byOwner map[types.UID][]T
+	err     error
+}
+
+func newOwnerIndex[T metav1.Object](items []T, err error) *ownerIndex[T] {
+	index := &ownerIndex[T]{byOwner: map[types.UID][]T{}, err: err}
+	for _, item := range items {
+		if owner := metav1.GetControllerOf(item); owner != nil {
+			index.byOwner[owner.UID] = append(index.byOwner[owner.UID], item)
+		}
+	}
+	return index
+}
+
+// ownedBy returns the resources controlled by owner, see metav1.IsControlledBy.
+func (i *ownerIndex[T]) ownedBy(owner metav1.Object) ([]T, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	return i.byOwner[owner.GetUID()], nil
+}
+
+// FindMatchingOrder tries to find an Order that is owned by req.
+// If none found returns nil
+// If one found returns the Order
+// If multiple found or error occurs when listing Orders, returns error
+func FindMatchingOrder(ctx context.Context, owned *OwnedResources, req *cmapi.CertificateRequest) (*cmacme.Order, error) {
+	orders, err := owned.Orders(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(orders) < 1:
+		return nil, nil
+	case len(orders) == 1:
+		return orders[0], nil
+	default:
+		return nil, fmt.Errorf("found multiple orders owned by CertificateRequest %s", req.Name)
+	}
+}
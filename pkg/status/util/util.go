@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"sort"
@@ -24,6 +25,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -68,6 +70,46 @@ func DescribeEvents(el *corev1.EventList, w describe.PrefixWriter, baseLevel int
 	w.Flush()
 }
 
+// EventsToString returns the Events in el formatted with DescribeEvents as a
+// string, indented by baseLevel.
+func EventsToString(el *corev1.EventList, baseLevel int) string {
+	var buf bytes.Buffer
+	tabWriter := NewTabWriter(&buf)
+	prefixWriter := describe.NewPrefixWriter(tabWriter)
+	DescribeEvents(el, prefixWriter, baseLevel)
+	tabWriter.Flush()
+	return buf.String()
+}
+
+// FormatIssuerRef returns ref as "Kind/name", with the group appended to the
+// kind for issuers outside of the cert-manager.io group.
+func FormatIssuerRef(ref cmmeta.IssuerReference) string {
+	kind := ref.Kind
+	if kind == "" {
+		kind = "Issuer"
+	}
+	if ref.Group != "" && ref.Group != "cert-manager.io" {
+		return fmt.Sprintf("%s.%s/%s", kind, ref.Group, ref.Name)
+	}
+	return fmt.Sprintf("%s/%s", kind, ref.Name)
+}
+
+// FormatTimeString returns t formatted as RFC 3339, or "<none>" if t is nil.
+func FormatTimeString(t *metav1.Time) string {
+	if t == nil {
+		return "<none>"
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+// FormatOptionalString returns s, or "<none>" if s is empty.
+func FormatOptionalString(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
 // NewTabWriter returns a *tabwriter.Writer with fixed parameters to be used in the status command
 func NewTabWriter(writer io.Writer) *tabwriter.Writer {
 	return tabwriter.NewWriter(writer, 0, 8, 2, ' ', 0)
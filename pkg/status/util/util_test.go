@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFormatIssuerRef(t *testing.T) {
+	tests := map[string]struct {
+		ref    cmmeta.IssuerReference
+		expRef string
+	}{
+		"Issuer is the default kind": {
+			ref:    cmmeta.IssuerReference{Name: "ca"},
+			expRef: "Issuer/ca",
+		},
+		"cert-manager ClusterIssuer": {
+			ref:    cmmeta.IssuerReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			expRef: "ClusterIssuer/ca",
+		},
+		"External issuer": {
+			ref:    cmmeta.IssuerReference{Name: "pca", Kind: "AWSPCAClusterIssuer", Group: "awspca.cert-manager.io"},
+			expRef: "AWSPCAClusterIssuer.awspca.cert-manager.io/pca",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expRef, FormatIssuerRef(test.ref))
+		})
+	}
+}
+
+func TestFormatTimeString(t *testing.T) {
+	assert.Equal(t, "<none>", FormatTimeString(nil))
+	assert.Equal(t, "2020-09-16T09:26:18Z", FormatTimeString(&metav1.Time{Time: time.Date(2020, 9, 16, 9, 26, 18, 0, time.UTC)}))
+}
+
+func TestFormatOptionalString(t *testing.T) {
+	assert.Equal(t, "<none>", FormatOptionalString(""))
+	assert.Equal(t, "Issued", FormatOptionalString("Issued"))
+}
@@ -23,6 +23,7 @@ import (
 	apiextinstall "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/install"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/templates"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,6 +40,7 @@ type Options struct {
 	skipStoredVersionCheck bool
 	qps                    float32
 	burst                  int
+	dryRunStrategy         cmdutil.DryRunStrategy
 }
 
 // NewOptions returns initialized Options
@@ -71,8 +73,15 @@ This command must be run with a cluster running cert-manager v1.0 or greater.`),
 # This should only be used if you have manually edited/patched the CRDs already.
 # It will force a read and a write of ALL cert-manager resources unconditionally.
 {{.BuildName}} upgrade migrate-api-version --skip-stored-version-check
+
+# List the resources and CRDs that would be updated by the migration, without updating them.
+{{.BuildName}} upgrade migrate-api-version --dry-run=client
 `)),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if o.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd); err != nil {
+				return err
+			}
 			if err := o.Validate(args); err != nil {
 				return err
 			}
@@ -90,6 +99,7 @@ This command must be run with a cluster running cert-manager v1.0 or greater.`),
 		"Use this mode if you have previously manually modified the 'status.storedVersions' field on CRD resources.")
 	cmd.Flags().Float32Var(&o.qps, "qps", 5, "Indicates the maximum QPS to the apiserver from the client.")
 	cmd.Flags().IntVar(&o.burst, "burst", 10, "Maximum burst value for queries set to the apiserver from the client.")
+	cmdutil.AddDryRunFlag(cmd)
 	o.Factory = factory.New(cmd)
 
 	return cmd
@@ -124,7 +134,9 @@ func (o *Options) Complete() error {
 
 // Run executes renew command
 func (o *Options) Run(ctx context.Context, args []string) error {
-	_, err := NewMigrator(o.client, o.skipStoredVersionCheck, o.Out, o.ErrOut).Run(ctx, "v1", []string{
+	migrator := NewMigrator(o.client, o.skipStoredVersionCheck, o.Out, o.ErrOut)
+	migrator.DryRun = o.dryRunStrategy
+	_, err := migrator.Run(ctx, "v1", []string{
 		"certificates.cert-manager.io",
 		"certificaterequests.cert-manager.io",
 		"issuers.cert-manager.io",
@@ -29,7 +29,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cmcmdutil "github.com/cert-manager/cmctl/v2/internal/util"
 )
 
 type Migrator struct {
@@ -43,6 +46,11 @@ type Migrator struct {
 
 	// Writers to write informational & error messages to
 	Out, ErrOut io.Writer
+
+	// DryRun is the --dry-run strategy. With the client strategy, the objects
+	// that would be updated are only printed. With the server strategy, the
+	// updates are sent with DryRun: All, so that nothing is persisted.
+	DryRun cmdutil.DryRunStrategy
 }
 
 // NewMigrator creates a new migrator with the given API client.
@@ -67,7 +75,8 @@ func NewMigrator(client client.Client, skipStoredVersionCheck bool, out, errOut
 // It will attempt to migrate all resources defined as part of these CRDs to the
 // given 'targetVersion', and after completion will update the `status.storedVersions`
 // field on the corresponding CRD version to only contain the given targetVersion.
-// Returns 'true' if a migration was actually performed, and false if migration was not required.
+// Returns 'true' if a migration was actually performed, and false if migration was not required
+// or if DryRun is set, in which case nothing is persisted.
 func (m *Migrator) Run(ctx context.Context, targetVersion string, names []string) (bool, error) {
 	fmt.Fprintf(m.Out, "Checking all CustomResourceDefinitions have storage version set to \"%s\"\n", targetVersion)
 	allTargetVersion, allCRDs, err := m.ensureCRDStorageVersionEquals(ctx, targetVersion, names)
@@ -114,6 +123,11 @@ func (m *Migrator) Run(ctx context.Context, targetVersion string, names []string
 		return false, err
 	}
 
+	if m.DryRun != cmdutil.DryRunNone {
+		fmt.Fprintf(m.Out, "Checked the migration of all cert-manager resource types, no resources were changed%s.\n", cmcmdutil.DryRunSuffix(m.DryRun))
+		return false, nil
+	}
+
 	fmt.Fprintln(m.Out, "Successfully migrated all cert-manager resource types. It is now safe to upgrade to cert-manager v1.7.")
 	return true, nil
 }
@@ -174,6 +188,16 @@ func (m *Migrator) migrateResourcesForCRD(ctx context.Context, crd *apiext.Custo
 		return err
 	}
 	fmt.Fprintf(m.Out, " %d resources to migrate...\n", len(list.Items))
+	if m.DryRun == cmdutil.DryRunClient {
+		for _, obj := range list.Items {
+			fmt.Fprintf(m.Out, " Would update %s %s to store it in version %q%s\n", crd.Spec.Names.Kind, objectName(&obj), storageVersionForCRD(crd), cmcmdutil.DryRunSuffix(m.DryRun)) // #nosec G601 -- Pointer does not outlive function scope
+		}
+		return nil
+	}
+	var updateOptions []client.UpdateOption
+	if m.DryRun == cmdutil.DryRunServer {
+		updateOptions = append(updateOptions, client.DryRunAll)
+	}
 	for _, obj := range list.Items {
 		// retry on any kind of error to handle cases where e.g. the network connection to the apiserver fails
 		if err := retry.OnError(wait.Backoff{
@@ -183,13 +207,17 @@ func (m *Migrator) migrateResourcesForCRD(ctx context.Context, crd *apiext.Custo
 			// Retry on any errors that are not otherwise skipped/ignored
 			return handleUpdateErr(err) != nil
 		}, func() error {
-			return m.Client.Update(ctx, &obj) // #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
+			return m.Client.Update(ctx, &obj, updateOptions...) // #nosec G601 -- False positive. See https://github.com/golang/go/discussions/56010
 		}); handleUpdateErr(err) != nil {
 			return err
 		}
 	}
 	// add 500ms to the duration to ensure we always round up
 	duration := time.Since(startTime) + (time.Millisecond * 500)
+	if m.DryRun == cmdutil.DryRunServer {
+		fmt.Fprintf(m.Out, " Checked the migration of %d %s objects in %s%s\n", len(list.Items), crd.Spec.Names.Kind, duration.Round(time.Second), cmcmdutil.DryRunSuffix(m.DryRun))
+		return nil
+	}
 	fmt.Fprintf(m.Out, " Successfully migrated %d %s objects in %s\n", len(list.Items), crd.Spec.Names.Kind, duration.Round(time.Second))
 	return nil
 }
@@ -219,10 +247,20 @@ func (m *Migrator) patchCRDStoredVersions(ctx context.Context, crds []*apiext.Cu
 			return newUnexpectedChangeError(crd)
 		}
 
+		if m.DryRun == cmdutil.DryRunClient {
+			fmt.Fprintf(m.Out, "Would update CustomResourceDefinition %q to set \"status.storedVersions\" from %q to %q%s\n",
+				crd.Name, freshCRD.Status.StoredVersions, []string{expectedStorageVersion}, cmcmdutil.DryRunSuffix(m.DryRun))
+			continue
+		}
+
 		// Set the `status.storedVersions` field to the target storage version
 		freshCRD.Status.StoredVersions = []string{storageVersionForCRD(crd)}
 
-		if err := m.Client.Status().Update(ctx, freshCRD); err != nil {
+		var updateOptions []client.SubResourceUpdateOption
+		if m.DryRun == cmdutil.DryRunServer {
+			updateOptions = append(updateOptions, client.DryRunAll)
+		}
+		if err := m.Client.Status().Update(ctx, freshCRD, updateOptions...); err != nil {
 			return err
 		}
 	}
@@ -230,6 +268,15 @@ func (m *Migrator) patchCRDStoredVersions(ctx context.Context, crds []*apiext.Cu
 	return nil
 }
 
+// objectName returns the name of obj, prefixed with its namespace if it is
+// namespaced.
+func objectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
 // storageVersionForCRD discovers the storage version for a given CRD.
 func storageVersionForCRD(crd *apiext.CustomResourceDefinition) string {
 	storageVersion := ""
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrateapiversion
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextinstall "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/install"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestMigratorDryRun(t *testing.T) {
+	tests := map[string]struct {
+		dryRun      cmdutil.DryRunStrategy
+		expMigrated bool
+		expUpdates  []string
+		expDryRun   []string
+		expOutput   []string
+	}{
+		"No dry run updates the objects and the stored versions": {
+			dryRun:      cmdutil.DryRunNone,
+			expMigrated: true,
+			expUpdates:  []string{"ns1/crt-1", "ns1/crt-2", "status certificates.cert-manager.io"},
+			expOutput: []string{
+				" Successfully migrated 2 Certificate objects in ",
+				"Successfully migrated all cert-manager resource types.",
+			},
+		},
+		"Client dry run sends no updates": {
+			dryRun:      cmdutil.DryRunClient,
+			expMigrated: false,
+			expOutput: []string{
+				` Would update Certificate ns1/crt-1 to store it in version "v1" (dry run)`,
+				` Would update Certificate ns1/crt-2 to store it in version "v1" (dry run)`,
+				`Would update CustomResourceDefinition "certificates.cert-manager.io" to set "status.storedVersions" from ["v1alpha2" "v1"] to ["v1"] (dry run)`,
+				"Checked the migration of all cert-manager resource types, no resources were changed (dry run).",
+			},
+		},
+		"Server dry run sends the updates with DryRun: All": {
+			dryRun:      cmdutil.DryRunServer,
+			expMigrated: false,
+			expUpdates:  []string{"ns1/crt-1", "ns1/crt-2", "status certificates.cert-manager.io"},
+			expDryRun:   []string{metav1.DryRunAll},
+			expOutput: []string{
+				" Checked the migration of 2 Certificate objects in ",
+				"Checked the migration of all cert-manager resource types, no resources were changed (server dry run).",
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			apiextinstall.Install(scheme)
+
+			crd := &apiext.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert-manager.io"},
+				Spec: apiext.CustomResourceDefinitionSpec{
+					Group: "cert-manager.io",
+					Names: apiext.CustomResourceDefinitionNames{Kind: "Certificate", ListKind: "CertificateList"},
+					Versions: []apiext.CustomResourceDefinitionVersion{
+						{Name: "v1alpha2", Served: true},
+						{Name: "v1", Served: true, Storage: true},
+					},
+				},
+				Status: apiext.CustomResourceDefinitionStatus{StoredVersions: []string{"v1alpha2", "v1"}},
+			}
+
+			var (
+				updates []string
+				dryRuns [][]string
+			)
+			cl := interceptor.NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).Build(), interceptor.Funcs{
+				List: func(_ context.Context, _ client.WithWatch, list client.ObjectList, _ ...client.ListOption) error {
+					for _, name := range []string{"crt-1", "crt-2"} {
+						obj := unstructured.Unstructured{}
+						obj.SetAPIVersion("cert-manager.io/v1")
+						obj.SetKind("Certificate")
+						obj.SetNamespace("ns1")
+						obj.SetName(name)
+						list.(*unstructured.UnstructuredList).Items = append(list.(*unstructured.UnstructuredList).Items, obj)
+					}
+					return nil
+				},
+				Update: func(_ context.Context, _ client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					updates = append(updates, obj.GetNamespace()+"/"+obj.GetName())
+					dryRuns = append(dryRuns, (&client.UpdateOptions{}).ApplyOptions(opts).DryRun)
+					return nil
+				},
+				SubResourceUpdate: func(_ context.Context, _ client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					updates = append(updates, subResource+" "+obj.GetName())
+					dryRuns = append(dryRuns, (&client.SubResourceUpdateOptions{}).ApplyOptions(opts).DryRun)
+					return nil
+				},
+			})
+
+			var out bytes.Buffer
+			m := NewMigrator(cl, false, &out, nil)
+			m.DryRun = test.dryRun
+
+			migrated, err := m.Run(t.Context(), "v1", []string{crd.Name})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expMigrated, migrated)
+			assert.Equal(t, test.expUpdates, updates)
+			for _, dryRun := range dryRuns {
+				assert.Equal(t, test.expDryRun, dryRun)
+			}
+			for _, expOutput := range test.expOutput {
+				assert.Contains(t, out.String(), expOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cmctl/v2/pkg/build"
+	"github.com/cert-manager/cmctl/v2/pkg/factory"
+	"github.com/cert-manager/cmctl/v2/pkg/status/util"
+)
+
+// VerificationKind is the kind of the structured output of the verify
+// certificate command.
+const VerificationKind = "CertificateVerification"
+
+// Options is a struct to support verify certificate command
+type Options struct {
+	// PrintFlags holds the flags used to print the result in a structured
+	// format. If no output format is set, the human readable output is printed.
+	PrintFlags *genericclioptions.PrintFlags
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// Verification is the result of comparing the spec of a Certificate with its
+// Secret.
+type Verification struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Name       string     `json:"name"`
+	Namespace  string     `json:"namespace"`
+	SecretName string     `json:"secretName"`
+	Mismatches []Mismatch `json:"mismatches"`
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		PrintFlags: util.NewPrintFlags(),
+		IOStreams:  ioStreams,
+	}
+}
+
+// NewCmdVerifyCertificate returns a cobra command for verify certificate
+func NewCmdVerifyCertificate(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "certificate",
+		Aliases: []string{"certificates"},
+		Short:   "Verify that the Secret of a cert-manager Certificate matches its spec",
+		Long: templates.LongDesc(`
+Verify that the certificate and private key stored in the Secret of a cert-manager Certificate match the spec of the Certificate.
+
+The DNS names, IP addresses, URIs, email addresses, common name, duration, private key algorithm and size, usages and isCA of the certificate are compared with the spec, as well as the labels and annotations of the secretTemplate and the cert-manager.io annotations of the Secret. Every mismatch is listed, and the command fails if there is any, e.g. because the Secret is stale after the spec was edited.`),
+		Example: templates.Examples(build.WithTemplate(setupCtx, `
+# Verify the Secret of Certificate with name 'my-crt' in namespace 'my-namespace'
+{{.BuildName}} verify certificate my-crt --namespace my-namespace
+
+# Print the mismatches of Certificate with name 'my-crt' as JSON
+{{.BuildName}} verify certificate my-crt -o json
+`)),
+		ValidArgsFunction: factory.ValidArgsListCertificates(&o.Factory),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.Validate(args)
+		},
+		//nolint:contextcheck // False positive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.Run(cmd.Context(), args)
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the Certificate has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the Certificate")
+	}
+	if util.IsStructuredOutput(o.PrintFlags) {
+		if _, err := o.PrintFlags.ToPrinter(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run executes verify certificate command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Certificate resource: %v", err)
+	}
+
+	secret, err := o.KubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Secret %q: %v", crt.Spec.SecretName, err)
+	}
+
+	mismatches, err := Drift(crt, secret)
+	if err != nil {
+		return err
+	}
+
+	verification := newVerification(crt, mismatches)
+
+	if util.IsStructuredOutput(o.PrintFlags) {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		if err := util.PrintObject(printer, verification, o.Out); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(o.Out, verification.String())
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("the Secret %q does not match the spec of the Certificate %q", crt.Spec.SecretName, crt.Name)
+	}
+	return nil
+}
+
+func newVerification(crt *cmapi.Certificate, mismatches []Mismatch) *Verification {
+	if mismatches == nil {
+		mismatches = []Mismatch{}
+	}
+	return &Verification{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: util.OutputGroupVersion.String(),
+			Kind:       VerificationKind,
+		},
+		Name:       crt.Name,
+		Namespace:  crt.Namespace,
+		SecretName: crt.Spec.SecretName,
+		Mismatches: mismatches,
+	}
+}
+
+// String returns the result of the verification as a string to be printed as
+// output
+func (v *Verification) String() string {
+	if len(v.Mismatches) == 0 {
+		return fmt.Sprintf("The Secret %q matches the spec of the Certificate %s/%s\n", v.SecretName, v.Namespace, v.Name)
+	}
+
+	output := fmt.Sprintf("The Secret %q does not match the spec of the Certificate %s/%s:\n", v.SecretName, v.Namespace, v.Name)
+	for _, m := range v.Mismatches {
+		output += fmt.Sprintf("- %s\n", m)
+	}
+	return output
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// durationTolerance is the difference between the requested and the actual
+// duration of a certificate that is not reported, since some issuers backdate
+// or round the validity period.
+const durationTolerance = time.Minute
+
+// Mismatch is a difference between the spec of a Certificate and the
+// certificate, private key or metadata stored in its Secret.
+type Mismatch struct {
+	// Field is the field of the Certificate spec, or the key of the Secret,
+	// that does not match
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// String returns the mismatch as a single line to be printed as output
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s: expected %s, found %s", m.Field, m.Expected, m.Actual)
+}
+
+// Drift compares the spec of crt with the certificate and private key stored
+// in secret, and with the labels and annotations of secret, and returns every
+// mismatch found.
+// Usages and duration are only compared if they are set in the spec, since
+// the issuer decides on them otherwise.
+// Returns error if the certificate in secret cannot be decoded.
+func Drift(crt *cmapi.Certificate, secret *corev1.Secret) ([]Mismatch, error) {
+	certData := secret.Data[corev1.TLSCertKey]
+	if len(certData) == 0 {
+		return nil, fmt.Errorf("'tls.crt' of Secret %q is not set", secret.Name)
+	}
+	cert, err := pki.DecodeX509CertificateBytes(certData)
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing 'tls.crt' of Secret %q: %w", secret.Name, err)
+	}
+
+	spec := crt.Spec
+	var mismatches []Mismatch
+	add := func(field, expected, actual string) {
+		if expected != actual {
+			mismatches = append(mismatches, Mismatch{Field: field, Expected: expected, Actual: actual})
+		}
+	}
+
+	// Some issuers promote a DNS name to be the common name, or add the common
+	// name to the DNS names. Like cert-manager, this is not reported.
+	commonName, dnsNames := cert.Subject.CommonName, cert.DNSNames
+	if spec.CommonName == "" && slices.Contains(spec.DNSNames, commonName) {
+		commonName = ""
+	}
+	if spec.CommonName != "" && !slices.Contains(spec.DNSNames, spec.CommonName) {
+		dnsNames = slices.DeleteFunc(slices.Clone(dnsNames), func(name string) bool { return name == spec.CommonName })
+	}
+
+	// The literal subject replaces the common name and the other subject fields
+	if spec.LiteralSubject == "" {
+		add("spec.commonName", formatValue(spec.CommonName), formatValue(commonName))
+	}
+	add("spec.dnsNames", formatSet(spec.DNSNames), formatSet(dnsNames))
+	add("spec.ipAddresses", formatSet(normalizeIPs(spec.IPAddresses)), formatSet(pki.IPAddressesToString(cert.IPAddresses)))
+	add("spec.uris", formatSet(spec.URIs), formatSet(pki.URLsToString(cert.URIs)))
+	add("spec.emailAddresses", formatSet(spec.EmailAddresses), formatSet(cert.EmailAddresses))
+
+	if spec.Duration != nil {
+		actual := cert.NotAfter.Sub(cert.NotBefore)
+		if diff := actual - spec.Duration.Duration; diff > durationTolerance || diff < -durationTolerance {
+			add("spec.duration", spec.Duration.Duration.String(), actual.String())
+		}
+	}
+
+	expectedAlgorithm, expectedSize := expectedKey(spec)
+	actualAlgorithm, actualSize := publicKeyDetails(cert.PublicKey)
+	add("spec.privateKey.algorithm", string(expectedAlgorithm), string(actualAlgorithm))
+	if expectedAlgorithm == actualAlgorithm && expectedAlgorithm != cmapi.Ed25519KeyAlgorithm {
+		add("spec.privateKey.size", strconv.Itoa(expectedSize), strconv.Itoa(actualSize))
+	}
+
+	if keyData := secret.Data[corev1.TLSPrivateKeyKey]; len(keyData) > 0 {
+		add("tls.key", "the private key of the certificate in tls.crt", privateKeyState(keyData, cert))
+	}
+
+	if len(spec.Usages) > 0 {
+		ku, eku, err := pki.KeyUsagesForCertificateOrCertificateRequest(spec.Usages, spec.IsCA)
+		if err != nil {
+			return nil, err
+		}
+		add("spec.usages", formatUsages(ku, eku), formatUsages(cert.KeyUsage, cert.ExtKeyUsage))
+	}
+
+	add("spec.isCA", strconv.FormatBool(spec.IsCA), strconv.FormatBool(cert.IsCA))
+
+	if tmpl := spec.SecretTemplate; tmpl != nil {
+		for _, key := range sortedKeys(tmpl.Labels) {
+			add(fmt.Sprintf("spec.secretTemplate.labels[%s]", key), formatValue(tmpl.Labels[key]), formatMapValue(secret.Labels, key))
+		}
+		for _, key := range sortedKeys(tmpl.Annotations) {
+			add(fmt.Sprintf("spec.secretTemplate.annotations[%s]", key), formatValue(tmpl.Annotations[key]), formatMapValue(secret.Annotations, key))
+		}
+	}
+
+	// The annotations cert-manager sets on the Secret to identify the
+	// Certificate and its issuer must match the spec
+	annotation := func(key string) string {
+		return fmt.Sprintf("metadata.annotations[%s]", key)
+	}
+	add(annotation(cmapi.CertificateNameKey), formatValue(crt.Name), formatMapValue(secret.Annotations, cmapi.CertificateNameKey))
+	add(annotation(cmapi.IssuerNameAnnotationKey), formatValue(spec.IssuerRef.Name), formatMapValue(secret.Annotations, cmapi.IssuerNameAnnotationKey))
+	add(annotation(cmapi.IssuerKindAnnotationKey), apiutil.IssuerKind(spec.IssuerRef), formatMapValue(secret.Annotations, cmapi.IssuerKindAnnotationKey))
+	if group, ok := secret.Annotations[cmapi.IssuerGroupAnnotationKey]; ok && !issuerGroupsEqual(group, spec.IssuerRef.Group) {
+		add(annotation(cmapi.IssuerGroupAnnotationKey), formatValue(spec.IssuerRef.Group), formatValue(group))
+	}
+
+	// The annotations describing the certificate are only compared if they
+	// exist, like cert-manager does
+	certAnnotations := map[string]string{
+		cmapi.CommonNameAnnotationKey: cert.Subject.CommonName,
+		cmapi.AltNamesAnnotationKey:   strings.Join(cert.DNSNames, ","),
+		cmapi.IPSANAnnotationKey:      strings.Join(pki.IPAddressesToString(cert.IPAddresses), ","),
+		cmapi.URISANAnnotationKey:     strings.Join(pki.URLsToString(cert.URIs), ","),
+	}
+	for _, key := range sortedKeys(certAnnotations) {
+		if value, ok := secret.Annotations[key]; ok {
+			add(annotation(key), formatValue(certAnnotations[key]), formatValue(value))
+		}
+	}
+
+	return mismatches, nil
+}
+
+// expectedKey returns the algorithm and size of the private key requested by
+// spec, applying the same defaults as cert-manager.
+func expectedKey(spec cmapi.CertificateSpec) (cmapi.PrivateKeyAlgorithm, int) {
+	algorithm, size := cmapi.RSAKeyAlgorithm, 0
+	if spec.PrivateKey != nil {
+		if spec.PrivateKey.Algorithm != "" {
+			algorithm = spec.PrivateKey.Algorithm
+		}
+		size = spec.PrivateKey.Size
+	}
+	if size == 0 {
+		switch algorithm {
+		case cmapi.RSAKeyAlgorithm:
+			size = pki.MinRSAKeySize
+		case cmapi.ECDSAKeyAlgorithm:
+			size = pki.ECCurve256
+		}
+	}
+	return algorithm, size
+}
+
+// publicKeyDetails returns the algorithm and size of key.
+func publicKeyDetails(key crypto.PublicKey) (cmapi.PrivateKeyAlgorithm, int) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return cmapi.RSAKeyAlgorithm, k.N.BitLen()
+	case *ecdsa.PublicKey:
+		return cmapi.ECDSAKeyAlgorithm, k.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return cmapi.Ed25519KeyAlgorithm, 0
+	default:
+		return cmapi.PrivateKeyAlgorithm(fmt.Sprintf("unknown (%T)", key)), 0
+	}
+}
+
+// privateKeyState describes whether keyData is the private key of cert.
+func privateKeyState(keyData []byte, cert *x509.Certificate) string {
+	key, err := pki.DecodePrivateKeyBytes(keyData)
+	if err != nil {
+		return fmt.Sprintf("a private key that cannot be decoded: %s", err)
+	}
+	matches, err := pki.PublicKeyMatchesCertificate(key.Public(), cert)
+	if err != nil {
+		return fmt.Sprintf("a private key that cannot be compared: %s", err)
+	}
+	if !matches {
+		return "a private key that does not match the certificate in tls.crt"
+	}
+	return "the private key of the certificate in tls.crt"
+}
+
+func issuerGroupsEqual(l, r string) bool {
+	if l == "" {
+		l = cmapi.SchemeGroupVersion.Group
+	}
+	if r == "" {
+		r = cmapi.SchemeGroupVersion.Group
+	}
+	return l == r
+}
+
+func normalizeIPs(ips []string) []string {
+	normalized := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			ip = parsed.String()
+		}
+		normalized = append(normalized, ip)
+	}
+	return normalized
+}
+
+func formatUsages(ku x509.KeyUsage, eku []x509.ExtKeyUsage) string {
+	var usages []string
+	for _, u := range append(apiutil.KeyUsageStrings(ku), apiutil.ExtKeyUsageStrings(eku)...) {
+		usages = append(usages, string(u))
+	}
+	return formatSet(usages)
+}
+
+// formatSet returns the sorted, deduplicated values as a comma separated
+// list, so that sets can be compared by their string representation.
+func formatSet(values []string) string {
+	set := map[string]struct{}{}
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return formatValue(strings.Join(sortedKeys(set), ", "))
+}
+
+func formatValue(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func formatMapValue(m map[string]string, key string) string {
+	if value, ok := m[key]; ok {
+		return formatValue(value)
+	}
+	return "<missing>"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDrift(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notBefore := time.Date(2020, 9, 16, 9, 0, 0, 0, time.UTC)
+	template := func() *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "example.com"},
+			DNSNames:     []string{"example.com", "www.example.com"},
+			IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+			NotBefore:    notBefore,
+			NotAfter:     notBefore.Add(90 * 24 * time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}
+	}
+	issue := func(tmpl *x509.Certificate, key crypto.Signer) []byte {
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	encodeKey := func(key crypto.Signer) []byte {
+		data, err := pki.EncodePKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	crt := gen.Certificate("test-crt",
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateDNSNames("example.com", "www.example.com"),
+		gen.SetCertificateIPs("10.0.0.1"),
+		gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "ca", Kind: "ClusterIssuer"}),
+		gen.SetCertificateSecretTemplate(nil, map[string]string{"team": "a"}),
+	)
+	secret := func(certData, keyData []byte, mods ...gen.SecretModifier) *corev1.Secret {
+		s := gen.Secret("test-tls", append([]gen.SecretModifier{
+			gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certData, corev1.TLSPrivateKeyKey: keyData}),
+			gen.SetSecretAnnotations(map[string]string{
+				cmapi.CertificateNameKey:       "test-crt",
+				cmapi.IssuerNameAnnotationKey:  "ca",
+				cmapi.IssuerKindAnnotationKey:  "ClusterIssuer",
+				cmapi.IssuerGroupAnnotationKey: "",
+				cmapi.CommonNameAnnotationKey:  "example.com",
+				cmapi.AltNamesAnnotationKey:    "example.com,www.example.com",
+			}),
+		}, mods...)...)
+		s.Labels = map[string]string{"team": "a"}
+		return s
+	}
+
+	tests := map[string]struct {
+		crt           *cmapi.Certificate
+		secret        *corev1.Secret
+		expMismatches []Mismatch
+		expErr        bool
+	}{
+		"Secret matching the spec has no mismatches": {
+			crt:    crt,
+			secret: secret(issue(template(), rsaKey), encodeKey(rsaKey)),
+		},
+		"Common name promoted from the DNS names is not a mismatch": {
+			crt: gen.CertificateFrom(crt, gen.SetCertificateCommonName("")),
+			secret: secret(issue(template(), rsaKey), encodeKey(rsaKey),
+				gen.SetSecretAnnotations(map[string]string{
+					cmapi.CertificateNameKey:      "test-crt",
+					cmapi.IssuerNameAnnotationKey: "ca",
+					cmapi.IssuerKindAnnotationKey: "ClusterIssuer",
+				}),
+			),
+		},
+		"Changed names are reported": {
+			crt: gen.CertificateFrom(crt,
+				gen.SetCertificateDNSNames("example.com", "api.example.com"),
+				gen.SetCertificateIPs("10.0.0.2"),
+				gen.SetCertificateURIs("spiffe://cluster.local/ns/default/sa/test")),
+			secret: secret(issue(template(), rsaKey), encodeKey(rsaKey)),
+			expMismatches: []Mismatch{
+				{Field: "spec.dnsNames", Expected: "api.example.com, example.com", Actual: "example.com, www.example.com"},
+				{Field: "spec.ipAddresses", Expected: "10.0.0.2", Actual: "10.0.0.1"},
+				{Field: "spec.uris", Expected: "spiffe://cluster.local/ns/default/sa/test", Actual: "<none>"},
+			},
+		},
+		"Changed private key algorithm and mismatching tls.key are reported": {
+			crt:    gen.CertificateFrom(crt, gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm)),
+			secret: secret(issue(template(), rsaKey), encodeKey(ecKey)),
+			expMismatches: []Mismatch{
+				{Field: "spec.privateKey.algorithm", Expected: "ECDSA", Actual: "RSA"},
+				{Field: "tls.key", Expected: "the private key of the certificate in tls.crt", Actual: "a private key that does not match the certificate in tls.crt"},
+			},
+		},
+		"Changed private key size is reported": {
+			crt:    gen.CertificateFrom(crt, gen.SetCertificateKeySize(4096)),
+			secret: secret(issue(template(), rsaKey), encodeKey(rsaKey)),
+			expMismatches: []Mismatch{
+				{Field: "spec.privateKey.size", Expected: "4096", Actual: "2048"},
+			},
+		},
+		"Duration, usages and isCA are reported": {
+			crt: gen.CertificateFrom(crt,
+				gen.SetCertificateDuration(&metav1.Duration{Duration: 30 * 24 * time.Hour}),
+				gen.SetCertificateKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageClientAuth),
+				gen.SetCertificateIsCA(true)),
+			secret: secret(issue(template(), rsaKey), encodeKey(rsaKey)),
+			expMismatches: []Mismatch{
+				{Field: "spec.duration", Expected: "720h0m0s", Actual: "2160h0m0s"},
+				{Field: "spec.usages", Expected: "cert sign, client auth, digital signature", Actual: "digital signature, key encipherment, server auth"},
+				{Field: "spec.isCA", Expected: "true", Actual: "false"},
+			},
+		},
+		"Stale Secret metadata is reported": {
+			crt: gen.CertificateFrom(crt,
+				gen.SetCertificateIssuer(cmmeta.IssuerReference{Name: "vault", Kind: "Issuer"}),
+				gen.SetCertificateSecretTemplate(map[string]string{"owner": "team-b"}, map[string]string{"team": "b", "env": "prod"})),
+			secret: secret(issue(template(), rsaKey), encodeKey(rsaKey)),
+			expMismatches: []Mismatch{
+				{Field: "spec.secretTemplate.labels[env]", Expected: "prod", Actual: "<missing>"},
+				{Field: "spec.secretTemplate.labels[team]", Expected: "b", Actual: "a"},
+				{Field: "spec.secretTemplate.annotations[owner]", Expected: "team-b", Actual: "<missing>"},
+				{Field: "metadata.annotations[cert-manager.io/issuer-name]", Expected: "vault", Actual: "ca"},
+				{Field: "metadata.annotations[cert-manager.io/issuer-kind]", Expected: "Issuer", Actual: "ClusterIssuer"},
+			},
+		},
+		"Annotations not matching the certificate are reported": {
+			crt: crt,
+			secret: secret(issue(template(), rsaKey), encodeKey(rsaKey),
+				gen.SetSecretAnnotations(map[string]string{
+					cmapi.CertificateNameKey:      "other-crt",
+					cmapi.IssuerNameAnnotationKey: "ca",
+					cmapi.IssuerKindAnnotationKey: "ClusterIssuer",
+					cmapi.AltNamesAnnotationKey:   "example.com",
+				}),
+			),
+			expMismatches: []Mismatch{
+				{Field: "metadata.annotations[cert-manager.io/certificate-name]", Expected: "test-crt", Actual: "other-crt"},
+				{Field: "metadata.annotations[cert-manager.io/alt-names]", Expected: "example.com,www.example.com", Actual: "example.com"},
+			},
+		},
+		"Missing tls.crt errors": {
+			crt:    crt,
+			secret: gen.Secret("test-tls"),
+			expErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mismatches, err := Drift(test.crt, test.secret)
+			if test.expErr != (err != nil) {
+				t.Fatalf("expected error=%t got=%v", test.expErr, err)
+			}
+			assert.Equal(t, test.expMismatches, mismatches)
+		})
+	}
+}
+
+func TestVerificationString(t *testing.T) {
+	crt := gen.Certificate("test-crt", gen.SetCertificateNamespace("ns1"), gen.SetCertificateSecretName("test-tls"))
+
+	assert.Equal(t, "The Secret \"test-tls\" matches the spec of the Certificate ns1/test-crt\n", newVerification(crt, nil).String())
+	assert.Equal(t, `The Secret "test-tls" does not match the spec of the Certificate ns1/test-crt:
+- spec.dnsNames: expected example.com, found <none>
+`, newVerification(crt, []Mismatch{{Field: "spec.dnsNames", Expected: "example.com", Actual: "<none>"}}).String())
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cmctl/v2/pkg/verify/certificate"
+)
+
+// NewCmdVerify returns a cobra command for verifying cert-manager resources.
+func NewCmdVerify(setupCtx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify that cert-manager resources are in the expected state",
+		Long:  `Verify that cert-manager resources are in the expected state, e.g. that the Secret of a Certificate matches its spec`,
+	}
+
+	cmds.AddCommand(certificate.NewCmdVerifyCertificate(setupCtx, ioStreams))
+
+	return cmds
+}
@@ -289,6 +289,12 @@ Secret:
   Subject Key ID: 
   Authority Key ID: 
   Serial Number: e2f88edc942c148463219da909fd633a
+  Drift:
+  - spec\.commonName: expected <none>, found test
+  - spec\.dnsNames: expected www\.example\.com, found <none>
+  - metadata\.annotations\[cert-manager\.io/certificate-name\]: expected testcrt-2, found <missing>
+  - metadata\.annotations\[cert-manager\.io/issuer-name\]: expected letsencrypt-prod, found <missing>
+  - metadata\.annotations\[cert-manager\.io/issuer-kind\]: expected Issuer, found <missing>
   Events:
     Type  Reason  Age        From  Message
     ----  ------  ----       ----  -------